@@ -0,0 +1,57 @@
+package dots
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Alignment places output horizontally in the terminal.
+type Alignment string
+
+// Supported alignments.
+const (
+	AlignLeft   Alignment = ""
+	AlignCenter Alignment = "center"
+	AlignRight  Alignment = "right"
+)
+
+// Alignments lists the names of all supported alignments.
+var Alignments = []Alignment{AlignCenter, AlignRight}
+
+// ParseAlignment validates an alignment name.
+// The empty string and "left" select left alignment.
+func ParseAlignment(s string) (Alignment, error) {
+	if s == "" || s == "left" {
+		return AlignLeft, nil
+	}
+	for _, a := range Alignments {
+		if Alignment(s) == a {
+			return a, nil
+		}
+	}
+	return AlignLeft, fmt.Errorf("unknown alignment %q", s)
+}
+
+// Offset returns the number of columns to indent output width cells wide by to
+// align it within termWidth columns.
+func (a Alignment) Offset(width, termWidth int) int {
+	free := max(termWidth-width, 0)
+	switch a {
+	case AlignCenter:
+		return free / 2
+	case AlignRight:
+		return free
+	default:
+		return 0
+	}
+}
+
+// alignPadding returns the spaces that align a line width cells wide, including
+// any frame, within the terminal according to opts.Align.
+func alignPadding(width int, opts Options) string {
+	if opts.Align == AlignLeft {
+		return ""
+	}
+	termWidth, _ := getTerminalSize()
+	return strings.Repeat(" ", opts.Align.Offset(width, termWidth))
+}
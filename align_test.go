@@ -0,0 +1,77 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"testing"
+)
+
+func TestParseAlignment(t *testing.T) {
+	for _, tt := range []struct {
+		s       string
+		want    Alignment
+		wantErr bool
+	}{
+		{s: "", want: AlignLeft},
+		{s: "left", want: AlignLeft},
+		{s: "center", want: AlignCenter},
+		{s: "right", want: AlignRight},
+		{s: "middle", wantErr: true},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseAlignment(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAlignment(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAlignment(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlignmentOffset(t *testing.T) {
+	for _, tt := range []struct {
+		align            Alignment
+		width, termWidth int
+		want             int
+	}{
+		{align: AlignLeft, width: 10, termWidth: 80, want: 0},
+		{align: AlignCenter, width: 10, termWidth: 80, want: 35},
+		{align: AlignCenter, width: 11, termWidth: 80, want: 34},
+		{align: AlignRight, width: 10, termWidth: 80, want: 70},
+		{align: AlignRight, width: 100, termWidth: 80, want: 0},
+	} {
+		if got := tt.align.Offset(tt.width, tt.termWidth); got != tt.want {
+			t.Errorf("%q.Offset(%d, %d) = %d, want %d", tt.align, tt.width, tt.termWidth, got, tt.want)
+		}
+	}
+}
+
+// Tests don't run on a terminal, so output is aligned within 80 columns.
+func TestAlignOutput(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 20, 8))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.White}, image.Point{}, draw.Src)
+
+	for _, tt := range []struct {
+		desc string
+		opts Options
+		want string
+	}{
+		{desc: "center", opts: Options{Width: 10, Height: 2, NoColor: true, Align: AlignCenter}, want: strings.Repeat(" ", 35) + "⣿"},
+		{desc: "right", opts: Options{Width: 10, Height: 2, NoColor: true, Align: AlignRight}, want: strings.Repeat(" ", 70) + "⣿"},
+		{desc: "framed", opts: Options{Width: 12, Height: 4, NoColor: true, Frame: true, Align: AlignCenter}, want: strings.Repeat(" ", 34) + "┌"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			converted := Convert(img, tt.opts)
+			rendered := ConvertGrid(img, tt.opts).Render(tt.opts)
+			for _, lines := range [][]string{converted, rendered} {
+				if len(lines) == 0 || !strings.HasPrefix(lines[0], tt.want) || strings.HasPrefix(lines[0], tt.want+" ") {
+					t.Errorf("first line = %q, want it to start with %q", lines[0], tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+)
+
+// compositeMatte blends an alpha-premultiplied image over an opaque matte color,
+// leaving every pixel opaque. A nil matte is black, which leaves the color channels unchanged.
+func compositeMatte(img *image.RGBA, matte color.Color) {
+	var m [3]uint32
+	if matte != nil {
+		c := color.NRGBAModel.Convert(matte).(color.NRGBA)
+		m = [3]uint32{uint32(c.R), uint32(c.G), uint32(c.B)}
+	}
+	for i := 0; i < len(img.Pix); i += 4 {
+		// Premultiplied channels can't exceed alpha, so the sum can't exceed 255
+		a := uint32(img.Pix[i+3])
+		for c := 0; c < 3; c++ {
+			img.Pix[i+c] += uint8((m[c]*(255-a) + 127) / 255)
+		}
+		img.Pix[i+3] = 255
+	}
+}
+
+// alphaMask returns a copy of an image's alpha channel.
+func alphaMask(img *image.RGBA) *image.Alpha {
+	mask := image.NewAlpha(img.Bounds())
+	for i := range mask.Pix {
+		mask.Pix[i] = img.Pix[i*4+3]
+	}
+	return mask
+}
+
+// transparentBlock reports whether every pixel of the w×h block at (x0, y0) is fully transparent.
+func transparentBlock(mask *image.Alpha, x0, y0, w, h int) bool {
+	bounds := mask.Bounds()
+	for y := y0; y < y0+h; y++ {
+		for x := x0; x < x0+w; x++ {
+			if mask.AlphaAt(bounds.Min.X+x, bounds.Min.Y+y).A != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
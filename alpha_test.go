@@ -0,0 +1,76 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestTransparency(t *testing.T) {
+	// A white square in the right half of an otherwise fully transparent image
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 4; x < 8; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{255, 255, 255, 255})
+		}
+	}
+
+	for _, tt := range []struct {
+		desc            string
+		opts            Options
+		wantLeft        rune
+		wantLeftColored bool
+	}{
+		{
+			desc:            "default black matte",
+			opts:            Options{Width: 4, Height: 2},
+			wantLeft:        0x2800,
+			wantLeftColored: true,
+		},
+		{
+			desc:            "white matte lights transparent pixels",
+			opts:            Options{Width: 4, Height: 2, Matte: color.White},
+			wantLeft:        0x28FF,
+			wantLeftColored: true,
+		},
+		{
+			desc:            "transparent cells are blank and uncolored",
+			opts:            Options{Width: 4, Height: 2, Matte: color.White, Transparent: true},
+			wantLeft:        0x2800,
+			wantLeftColored: false,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			grid := ConvertGrid(img, tt.opts)
+			left, right := grid[0][0], grid[0][3]
+			if left.Rune != tt.wantLeft {
+				t.Errorf("transparent cell rune = %U, want %U", left.Rune, tt.wantLeft)
+			}
+			if colored := left.Fg.A != 0; colored != tt.wantLeftColored {
+				t.Errorf("transparent cell colored = %t, want %t", colored, tt.wantLeftColored)
+			}
+			if right.Rune != 0x28FF {
+				t.Errorf("opaque cell rune = %U, want %U", right.Rune, rune(0x28FF))
+			}
+
+			line := renderRow(grid[0][:1], Options{})
+			if hasEscape := strings.Contains(line, "\x1b["); hasEscape != tt.wantLeftColored {
+				t.Errorf("renderRow(%q) has escape codes = %t, want %t", line, hasEscape, tt.wantLeftColored)
+			}
+		})
+	}
+}
+
+func TestCompositeMatte(t *testing.T) {
+	// Half-transparent red, premultiplied
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.SetRGBA(0, 0, color.RGBA{128, 0, 0, 128})
+
+	compositeMatte(img, color.RGBA{0, 0, 255, 255})
+
+	want := color.RGBA{128, 0, 127, 255}
+	if got := img.RGBAAt(0, 0); got != want {
+		t.Errorf("compositeMatte() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,478 @@
+package dots
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Frame is a single image of an animation and how long it is shown.
+type Frame struct {
+	Image image.Image
+	Delay time.Duration
+}
+
+// Animation is a sequence of frames, such as a decoded animated GIF.
+type Animation struct {
+	Frames []Frame
+	// LoopCount is the number of times the animation repeats after playing once,
+	// as in image/gif: 0 repeats forever and -1 plays it once.
+	LoopCount int
+}
+
+// Duration returns the time it takes to play the animation once.
+func (a *Animation) Duration() time.Duration {
+	var d time.Duration
+	for _, f := range a.Frames {
+		d += f.Delay
+	}
+	return d
+}
+
+// FrameAt returns the frame shown at time t after the animation starts playing,
+// ignoring loops: times past the end return the last frame. An animation without
+// frames returns the zero Frame.
+func (a *Animation) FrameAt(t time.Duration) Frame {
+	if len(a.Frames) == 0 {
+		return Frame{}
+	}
+	for _, f := range a.Frames[:len(a.Frames)-1] {
+		if t < f.Delay {
+			return f
+		}
+		t -= f.Delay
+	}
+	return a.Frames[len(a.Frames)-1]
+}
+
+// defaultFrameDelay is used for GIF frames without a usable delay. Browsers
+// treat delays under 20ms the same way, since many GIFs rely on it.
+const defaultFrameDelay = 100 * time.Millisecond
+
+// DecodeGIF decodes every frame of a GIF. Frames only covering part of the image
+// are composited onto the previous ones according to their disposal methods,
+// so every frame is a complete image.
+func DecodeGIF(r io.Reader) (*Animation, error) {
+	g, err := gif.DecodeAll(r)
+	if err != nil {
+		return nil, &DecodeError{Format: "gif", Err: err}
+	}
+
+	anim := &Animation{LoopCount: g.LoopCount}
+	canvas := image.NewRGBA(image.Rect(0, 0, g.Config.Width, g.Config.Height))
+	for i, src := range g.Image {
+		var previous *image.RGBA
+		if i < len(g.Disposal) && g.Disposal[i] == gif.DisposalPrevious {
+			previous = cloneRGBA(canvas)
+		}
+		draw.Draw(canvas, src.Bounds(), src, src.Bounds().Min, draw.Over)
+
+		delay := defaultFrameDelay
+		if i < len(g.Delay) && g.Delay[i] > 1 {
+			delay = time.Duration(g.Delay[i]) * 10 * time.Millisecond
+		}
+		anim.Frames = append(anim.Frames, Frame{Image: cloneRGBA(canvas), Delay: delay})
+
+		switch {
+		case previous != nil:
+			canvas = previous
+		case i < len(g.Disposal) && g.Disposal[i] == gif.DisposalBackground:
+			draw.Draw(canvas, src.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		}
+	}
+	return anim, nil
+}
+
+// cloneRGBA returns a copy of an image.
+func cloneRGBA(img *image.RGBA) *image.RGBA {
+	c := image.NewRGBA(img.Bounds())
+	copy(c.Pix, img.Pix)
+	return c
+}
+
+// WriteStacked writes every frame of an animation to w one after another, each
+// preceded by a caption with its number and start time, for logs and CI output
+// where the cursor can't be moved to play it back.
+func WriteStacked(w io.Writer, anim *Animation, opts Options) error {
+	var start time.Duration
+	for i, frame := range anim.Frames {
+		if _, err := fmt.Fprintf(w, "Frame %d/%d at %.2fs\n", i+1, len(anim.Frames), start.Seconds()); err != nil {
+			return err
+		}
+		err := ConvertFunc(frame.Image, opts, func(_ int, line string) error {
+			_, err := fmt.Fprintln(w, line)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		start += frame.Delay
+	}
+	return nil
+}
+
+// PlayOptions configures animation playback.
+type PlayOptions struct {
+	// LoopCount overrides the animation's loop count if non-nil.
+	LoopCount *int
+	// Speed scales the playback rate, e.g. 2 plays twice as fast. 0 is normal speed.
+	Speed float64
+	// PingPong plays the frames forward, then backward, as one loop.
+	PingPong bool
+	// Workers is the number of upcoming frames converted concurrently while the
+	// current one is shown. 0 uses GOMAXPROCS.
+	Workers int
+	// Progress shows a progress bar under the animation, with the elapsed and total
+	// time of a loop and the frame number.
+	Progress bool
+	// ToggleProgress shows or hides the progress bar each time it receives,
+	// e.g. when a key is pressed.
+	ToggleProgress <-chan struct{}
+}
+
+// Play renders an animation to w, a terminal, drawing each frame over the previous one
+// and honoring each frame's delay. After the first frame, only the cells that changed
+// are redrawn. It returns when the animation ends or ctx is done.
+func Play(ctx context.Context, w io.Writer, anim *Animation, opts Options, play PlayOptions) error {
+	if len(anim.Frames) == 0 {
+		return nil
+	}
+	loopCount := anim.LoopCount
+	if play.LoopCount != nil {
+		loopCount = *play.LoopCount
+	}
+	// The animation plays once more than it repeats
+	plays := loopCount + 1
+	if loopCount < 0 {
+		plays = 1
+	}
+	frames := anim.Frames
+	if play.PingPong {
+		// The first and last frames aren't repeated at the turns
+		frames = slices.Clone(frames)
+		for i := len(anim.Frames) - 2; i > 0; i-- {
+			frames = append(frames, anim.Frames[i])
+		}
+	}
+	speed := play.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+
+	workers := play.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// Start time of each frame within a loop, for the progress bar
+	starts := make([]time.Duration, len(frames))
+	var duration time.Duration
+	for i, f := range frames {
+		starts[i] = duration
+		duration += time.Duration(float64(f.Delay) / speed)
+	}
+
+	p, err := newPlayer(w, opts)
+	if err != nil {
+		return err
+	}
+	defer p.close()
+	p.overlay, p.toggle = play.Progress, play.ToggleProgress
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	total := 0 // Frames to play, or 0 to play forever
+	if loopCount != 0 {
+		total = plays * len(frames)
+	}
+	i := 0
+	for f := range prerender(ctx, frames, total, opts, workers) {
+		delay := time.Duration(float64(f.frame.Delay) / speed)
+		grid := <-f.grid
+		f.done()
+		n := i % len(frames)
+		first, last := i == 0, i == total-1
+		i++
+		// Drop frames when falling behind, except the first and last
+		if !first && !last && p.late(delay) {
+			p.elapsed += delay
+			continue
+		}
+
+		withProgress := func(grid Grid) Grid {
+			if !p.overlay || len(grid) == 0 {
+				return grid
+			}
+			bar := progressBar(len(grid[0]), starts[n], duration, n+1, len(frames))
+			return append(grid[:len(grid):len(grid)], bar)
+		}
+		convert := func() Grid { return withProgress(ConvertGrid(f.frame.Image, opts)) }
+		if err := p.draw(withProgress(grid)); err != nil {
+			return err
+		}
+		if err := p.wait(ctx, delay, convert); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// PlayStream renders live frames to w, a terminal, as they are received, drawing
+// each over the previous one like Play. Frames that arrive while one is being drawn
+// are skipped in favor of the newest. It returns when frames is closed or ctx is done.
+func PlayStream(ctx context.Context, w io.Writer, frames <-chan image.Image, opts Options) error {
+	p, err := newPlayer(w, opts)
+	if err != nil {
+		return err
+	}
+	defer p.close()
+
+	var latest image.Image
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case img, ok := <-frames:
+			if !ok {
+				return nil
+			}
+			// Catch up to the newest frame
+			for caughtUp := false; !caughtUp; {
+				select {
+				case next, ok := <-frames:
+					if !ok {
+						caughtUp = true
+						break
+					}
+					img = next
+				default:
+					caughtUp = true
+				}
+			}
+			latest = img
+			if err := p.draw(ConvertGrid(img, opts)); err != nil {
+				return err
+			}
+		case <-p.resized:
+			if latest == nil {
+				continue
+			}
+			if err := p.redraw(ConvertGrid(latest, opts)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// pendingFrame is a frame being converted ahead of playback.
+type pendingFrame struct {
+	frame Frame
+	grid  <-chan Grid // Receives the converted frame
+	done  func()      // Must be called once the grid is received, to free its worker
+}
+
+// prerender converts frames, repeating them until total have been converted or
+// forever if total is 0, with up to workers conversions running or waiting to be
+// received at once. The frames are sent in order as soon as their conversions
+// start, so playback keeps up with the frame rate on multi-core machines.
+// The channel is closed once all frames are sent, or when ctx is done.
+func prerender(ctx context.Context, frames []Frame, total int, opts Options, workers int) <-chan pendingFrame {
+	sem := make(chan struct{}, workers)
+	pending := make(chan pendingFrame, workers)
+	go func() {
+		defer close(pending)
+		for i := 0; total == 0 || i < total; i++ {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			frame := frames[i%len(frames)]
+			grid := make(chan Grid, 1)
+			go func() { grid <- ConvertGrid(frame.Image, opts) }()
+			pending <- pendingFrame{frame: frame, grid: grid, done: func() { <-sem }}
+		}
+	}()
+	return pending
+}
+
+// player draws a sequence of grids over each other on a terminal, redrawing only
+// the cells that changed, and waits for each grid's scheduled time.
+type player struct {
+	w       io.Writer
+	frames  *FrameWriter  // Draws each grid over the one on screen
+	start   time.Time     // Time playback started
+	elapsed time.Duration // Scheduled time of the current grid, relative to start
+	latency time.Duration // Moving average of the time taken to write a grid
+	resized chan os.Signal
+	overlay bool            // Whether to show the progress bar
+	toggle  <-chan struct{} // Toggles overlay
+
+}
+
+// newPlayer starts playback on w, hiding the cursor until close is called.
+func newPlayer(w io.Writer, opts Options) (*player, error) {
+	if _, err := io.WriteString(w, "\x1b[?25l"); err != nil {
+		return nil, err
+	}
+	p := &player{w: w, frames: NewFrameWriter(w, opts), start: time.Now(), resized: make(chan os.Signal, 1)}
+	NotifyResize(p.resized)
+	return p, nil
+}
+
+// close ends playback, leaving the cursor below the last grid.
+func (p *player) close() {
+	signal.Stop(p.resized)
+	_, _ = io.WriteString(p.w, "\x1b[?25h")
+}
+
+// draw draws grid over the previous one.
+func (p *player) draw(grid Grid) error {
+	out := p.frames.render(grid)
+	start := time.Now()
+	_, err := io.WriteString(p.w, out)
+	p.latency = (3*p.latency + time.Since(start)) / 4
+	return err
+}
+
+// late reports whether a grid due now and shown for d would be replaced before
+// it could be written, given recent write times, so it should be dropped to keep
+// up with the schedule instead of letting playback drift.
+func (p *player) late(d time.Duration) bool {
+	return time.Until(p.start.Add(p.elapsed+d)) < p.latency
+}
+
+// wait waits until d after the current grid's scheduled time, or until ctx is done.
+// Times are relative to the start, so slow conversions don't make playback drift.
+// If the terminal is resized meanwhile, the screen is cleared and redrawn with
+// the grid returned by convert, at the new size. If the progress bar is toggled,
+// the grid is redrawn too.
+func (p *player) wait(ctx context.Context, d time.Duration, convert func() Grid) error {
+	p.elapsed += d
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.resized:
+			if err := p.redraw(convert()); err != nil {
+				return err
+			}
+		case <-p.toggle:
+			p.overlay = !p.overlay
+			if err := p.draw(convert()); err != nil {
+				return err
+			}
+		case <-time.After(time.Until(p.start.Add(p.elapsed))):
+			return nil
+		}
+	}
+}
+
+// redraw clears the screen and draws grid at the top, for when the terminal is
+// resized: it reflows the previous grid, so it can't be drawn over.
+func (p *player) redraw(grid Grid) error {
+	p.frames.Reset()
+	if _, err := io.WriteString(p.w, "\x1b[H\x1b[2J"); err != nil {
+		return err
+	}
+	return p.draw(grid)
+}
+
+// progressBar returns a row of cells showing playback progress: a braille bar filled
+// up to elapsed out of total, then the times and the frame number.
+func progressBar(width int, elapsed, total time.Duration, frame, frames int) []Cell {
+	label := []rune(fmt.Sprintf(" %.1fs/%.1fs %d/%d", elapsed.Seconds(), total.Seconds(), frame, frames))
+	label = label[:min(len(label), width)]
+	barWidth := width - len(label)
+	filled := 0.0
+	if total > 0 {
+		filled = float64(elapsed) / float64(total) * float64(barWidth)
+	}
+
+	cells := make([]Cell, 0, width)
+	for i := range barWidth {
+		pattern := uint8(0xc0) // Bottom dots, ⣀
+		switch {
+		case float64(i+1) <= filled:
+			pattern = 0xff // ⣿
+		case float64(i)+0.5 <= filled:
+			pattern = 0xc7 // Left column and bottom dots, ⣇
+		}
+		cells = append(cells, Cell{Rune: 0x2800 + rune(pattern), Pattern: pattern})
+	}
+	for _, r := range label {
+		cells = append(cells, Cell{Rune: r})
+	}
+	return cells
+}
+
+// canDiff reports whether next can be drawn over prev by redrawing only the changed cells.
+// Hyperlinked output is always redrawn in full, since links span whole lines.
+func canDiff(prev, next Grid, opts Options) bool {
+	if prev == nil || len(prev) != len(next) || opts.Hyperlink != "" {
+		return false
+	}
+	for row := range prev {
+		if len(prev[row]) != len(next[row]) {
+			return false
+		}
+	}
+	return true
+}
+
+// renderDiff emits the escape sequences that turn prev, drawn on the height lines above
+// the cursor, into g by moving the cursor to and redrawing only the runs of changed cells.
+// The cursor is left on the line below the grid, as after a full redraw. Full redraws
+// flicker, and resending unchanged cells saturates slow links such as SSH sessions.
+func (g Grid) renderDiff(prev Grid, height int, opts Options) string {
+	opts.NoColor = opts.NoColor || envNoColor(opts)
+
+	// The frame, if any, adds a line above and a column left of the cells, and
+	// alignment indents the columns
+	offset := 0
+	if opts.Frame {
+		offset = 1
+	}
+	indent := offset
+	if len(g) > 0 {
+		indent += len(alignPadding(len(g[0])+2*offset, opts))
+	}
+
+	var sb strings.Builder
+	line := 0 // Line of the cursor, relative to the top of the grid
+	for row, cells := range g {
+		for col := 0; col < len(cells); col++ {
+			if cells[col] == prev[row][col] {
+				continue
+			}
+			end := col + 1
+			for end < len(cells) && cells[end] != prev[row][end] {
+				end++
+			}
+
+			if sb.Len() == 0 {
+				fmt.Fprintf(&sb, "\x1b[%dF", height)
+			}
+			if row+offset != line {
+				fmt.Fprintf(&sb, "\x1b[%dE", row+offset-line)
+				line = row + offset
+			}
+			fmt.Fprintf(&sb, "\x1b[%dG", col+indent+1)
+			sb.WriteString(renderRow(cells[col:end], opts))
+			col = end
+		}
+	}
+	if sb.Len() > 0 {
+		fmt.Fprintf(&sb, "\x1b[%dE", height-line)
+	}
+	return sb.String()
+}
@@ -0,0 +1,353 @@
+package dots
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	gifpalette "image/color/palette"
+	"image/gif"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testGIF encodes an 8×8 animation: a white frame, then a black square over its
+// top-left quarter, then a frame with no usable delay.
+func testGIF(t *testing.T) []byte {
+	t.Helper()
+	full := image.NewPaletted(image.Rect(0, 0, 8, 8), gifpalette.Plan9)
+	for i := range full.Pix {
+		full.Pix[i] = uint8(full.Palette.Index(color.White))
+	}
+	corner := image.NewPaletted(image.Rect(0, 0, 4, 4), gifpalette.Plan9)
+	for i := range corner.Pix {
+		corner.Pix[i] = uint8(corner.Palette.Index(color.Black))
+	}
+	last := image.NewPaletted(image.Rect(4, 4, 8, 8), gifpalette.Plan9)
+	for i := range last.Pix {
+		last.Pix[i] = uint8(last.Palette.Index(color.Black))
+	}
+
+	var buf bytes.Buffer
+	err := gif.EncodeAll(&buf, &gif.GIF{
+		Image:     []*image.Paletted{full, corner, last},
+		Delay:     []int{5, 20, 0},
+		Disposal:  []byte{gif.DisposalNone, gif.DisposalNone, gif.DisposalNone},
+		LoopCount: 2,
+	})
+	if err != nil {
+		t.Fatalf("failed to encode GIF: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeGIF(t *testing.T) {
+	anim, err := DecodeGIF(bytes.NewReader(testGIF(t)))
+	if err != nil {
+		t.Fatalf("DecodeGIF() unexpected error: %v", err)
+	}
+	if anim.LoopCount != 2 {
+		t.Errorf("LoopCount = %d, want 2", anim.LoopCount)
+	}
+	if len(anim.Frames) != 3 {
+		t.Fatalf("got %d frames, want 3", len(anim.Frames))
+	}
+
+	for i, want := range []time.Duration{50 * time.Millisecond, 200 * time.Millisecond, defaultFrameDelay} {
+		if got := anim.Frames[i].Delay; got != want {
+			t.Errorf("frame %d delay = %v, want %v", i, got, want)
+		}
+	}
+
+	// Partial frames are composited over the previous ones
+	frame := anim.Frames[2].Image
+	if got := frame.Bounds(); got != image.Rect(0, 0, 8, 8) {
+		t.Errorf("frame 2 bounds = %v, want the full image", got)
+	}
+	for _, tt := range []struct {
+		x, y int
+		want color.Gray
+	}{
+		{1, 1, color.Gray{0}},   // From frame 1
+		{6, 6, color.Gray{0}},   // From frame 2
+		{6, 1, color.Gray{255}}, // From frame 0
+	} {
+		if got := color.GrayModel.Convert(frame.At(tt.x, tt.y)); got != tt.want {
+			t.Errorf("frame 2 pixel (%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestFrameAt(t *testing.T) {
+	anim, err := DecodeGIF(bytes.NewReader(testGIF(t)))
+	if err != nil {
+		t.Fatalf("DecodeGIF() unexpected error: %v", err)
+	}
+	if got, want := anim.Duration(), 350*time.Millisecond; got != want {
+		t.Errorf("Duration() = %v, want %v", got, want)
+	}
+	for _, tt := range []struct {
+		at   time.Duration
+		want int
+	}{
+		{0, 0},
+		{49 * time.Millisecond, 0},
+		{50 * time.Millisecond, 1},
+		{175 * time.Millisecond, 1},
+		{250 * time.Millisecond, 2},
+		{time.Hour, 2},
+	} {
+		if got := anim.FrameAt(tt.at); got.Image != anim.Frames[tt.want].Image {
+			t.Errorf("FrameAt(%v) isn't frame %d", tt.at, tt.want)
+		}
+	}
+
+	var empty Animation
+	if got := empty.FrameAt(time.Second); got != (Frame{}) {
+		t.Errorf("FrameAt() of an empty animation = %v, want the zero Frame", got)
+	}
+}
+
+func TestPlayStream(t *testing.T) {
+	anim, err := DecodeGIF(bytes.NewReader(testGIF(t)))
+	if err != nil {
+		t.Fatalf("DecodeGIF() unexpected error: %v", err)
+	}
+	frames := make(chan image.Image, len(anim.Frames))
+	for _, f := range anim.Frames {
+		frames <- f.Image
+	}
+	close(frames)
+
+	opts := Options{Width: 4, Height: 2, NoColor: true}
+	var buf bytes.Buffer
+	if err := PlayStream(context.Background(), &buf, frames, opts); err != nil {
+		t.Fatalf("PlayStream() unexpected error: %v", err)
+	}
+	// The buffered frames are skipped, straight to the newest
+	if got, want := screen(buf.String()), Convert(anim.Frames[2].Image, opts); !slices.Equal(got, want) {
+		t.Errorf("screen after PlayStream() = %q, want the last frame %q", got, want)
+	}
+}
+
+func TestWriteStacked(t *testing.T) {
+	anim, err := DecodeGIF(bytes.NewReader(testGIF(t)))
+	if err != nil {
+		t.Fatalf("DecodeGIF() unexpected error: %v", err)
+	}
+	opts := Options{Width: 4, Height: 2, NoColor: true}
+	var buf bytes.Buffer
+	if err := WriteStacked(&buf, anim, opts); err != nil {
+		t.Fatalf("WriteStacked() unexpected error: %v", err)
+	}
+
+	var want []string
+	for i, caption := range []string{"Frame 1/3 at 0.00s", "Frame 2/3 at 0.05s", "Frame 3/3 at 0.25s"} {
+		want = append(want, caption)
+		want = append(want, Convert(anim.Frames[i].Image, opts)...)
+	}
+	if got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"); !slices.Equal(got, want) {
+		t.Errorf("WriteStacked() = %q, want %q", got, want)
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	for _, tt := range []struct {
+		desc           string
+		width          int
+		elapsed, total time.Duration
+		want           string
+	}{
+		{desc: "start", width: 20, total: 2 * time.Second, want: "⣀⣀⣀⣀⣀⣀ 0.0s/2.0s 1/4"},
+		{desc: "halfway", width: 20, elapsed: time.Second, total: 2 * time.Second, want: "⣿⣿⣿⣀⣀⣀ 1.0s/2.0s 1/4"},
+		{desc: "half a cell", width: 20, elapsed: 1250 * time.Millisecond, total: 2 * time.Second, want: "⣿⣿⣿⣇⣀⣀ 1.2s/2.0s 1/4"},
+		{desc: "too narrow for the label", width: 5, elapsed: time.Second, total: 2 * time.Second, want: " 1.0s"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got strings.Builder
+			for _, cell := range progressBar(tt.width, tt.elapsed, tt.total, 1, 4) {
+				got.WriteRune(cell.Rune)
+			}
+			if got.String() != tt.want {
+				t.Errorf("progressBar() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int { return &n }
+
+func TestPlay(t *testing.T) {
+	anim, err := DecodeGIF(bytes.NewReader(testGIF(t)))
+	if err != nil {
+		t.Fatalf("DecodeGIF() unexpected error: %v", err)
+	}
+
+	for _, tt := range []struct {
+		desc       string
+		loopCount  *int
+		pingPong   bool
+		wantFrames int
+	}{
+		{desc: "GIF loop count repeats twice", wantFrames: 9},
+		{desc: "override plays once", loopCount: intPtr(-1), wantFrames: 3},
+		{desc: "override repeats once", loopCount: intPtr(1), wantFrames: 6},
+		{desc: "ping-pong adds the middle frame backward", loopCount: intPtr(-1), pingPong: true, wantFrames: 4},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := Options{Width: 4, Height: 2, NoColor: true}
+			if err := Play(context.Background(), &buf, anim, opts, PlayOptions{LoopCount: tt.loopCount, Speed: 5, PingPong: tt.pingPong}); err != nil {
+				t.Fatalf("Play() unexpected error: %v", err)
+			}
+			// Every frame after the first moves the cursor back up over the previous one
+			if got := strings.Count(buf.String(), "\x1b[2F") + 1; got != tt.wantFrames {
+				t.Errorf("drew %d frames, want %d", got, tt.wantFrames)
+			}
+		})
+	}
+
+	t.Run("concurrent conversion keeps frames in order", func(t *testing.T) {
+		var want string
+		for _, workers := range []int{1, 2, 8} {
+			var buf bytes.Buffer
+			opts := Options{Width: 4, Height: 2, NoColor: true}
+			if err := Play(context.Background(), &buf, anim, opts, PlayOptions{Speed: 5, Workers: workers}); err != nil {
+				t.Fatalf("Play() unexpected error: %v", err)
+			}
+			if want == "" {
+				want = buf.String()
+			} else if buf.String() != want {
+				t.Errorf("Play() with %d workers = %q, want %q", workers, buf.String(), want)
+			}
+		}
+	})
+
+	t.Run("progress bar", func(t *testing.T) {
+		var buf bytes.Buffer
+		opts := Options{Width: 4, Height: 2, NoColor: true}
+		if err := Play(context.Background(), &buf, anim, opts, PlayOptions{LoopCount: intPtr(-1), Speed: 5, Progress: true}); err != nil {
+			t.Fatalf("Play() unexpected error: %v", err)
+		}
+		if got := screen(buf.String()); len(got) != 3 {
+			t.Errorf("screen after Play() = %q, want 2 lines and a progress bar", got)
+		}
+	})
+
+	t.Run("speed scales delays", func(t *testing.T) {
+		start := time.Now()
+		once := -1
+		if err := Play(context.Background(), &bytes.Buffer{}, anim, Options{Width: 4, Height: 2}, PlayOptions{LoopCount: &once, Speed: 10}); err != nil {
+			t.Fatalf("Play() unexpected error: %v", err)
+		}
+		// 350ms of delays at 10x speed
+		if got := time.Since(start); got < 35*time.Millisecond || got > 300*time.Millisecond {
+			t.Errorf("played in %v, want about 35ms", got)
+		}
+	})
+
+	t.Run("slow terminals drop frames", func(t *testing.T) {
+		slow := &slowWriter{delay: 20 * time.Millisecond}
+		anim := &Animation{LoopCount: -1}
+		for range 20 {
+			anim.Frames = append(anim.Frames, Frame{Image: image.NewGray(image.Rect(0, 0, 8, 8)), Delay: 5 * time.Millisecond})
+		}
+		// Alternate the frames so every one changes the screen
+		white := image.NewGray(image.Rect(0, 0, 8, 8))
+		for i := range white.Pix {
+			white.Pix[i] = 255
+		}
+		for i := 0; i < len(anim.Frames); i += 2 {
+			anim.Frames[i].Image = white
+		}
+
+		start := time.Now()
+		if err := Play(context.Background(), slow, anim, Options{Width: 4, Height: 2}, PlayOptions{}); err != nil {
+			t.Fatalf("Play() unexpected error: %v", err)
+		}
+		// Drawing every frame would take 400ms; playback should stay near 100ms
+		if got := time.Since(start); got > 250*time.Millisecond {
+			t.Errorf("played in %v, want about 100ms", got)
+		}
+		if slow.writes >= 20 {
+			t.Errorf("drew all %d frames, want some dropped", slow.writes)
+		}
+	})
+
+	t.Run("cancellation stops playback", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := Play(ctx, &bytes.Buffer{}, anim, Options{Width: 4, Height: 2}, PlayOptions{}); err != context.Canceled {
+			t.Errorf("Play() = %v, want context.Canceled", err)
+		}
+	})
+}
+
+func TestRenderDiff(t *testing.T) {
+	blank := func() Grid {
+		g := make(Grid, 2)
+		for row := range g {
+			g[row] = []Cell{{Rune: 0x2800}, {Rune: 0x2800}, {Rune: 0x2800}}
+		}
+		return g
+	}
+	full := Cell{Rune: 0x28ff, Pattern: 0xff}
+
+	for _, tt := range []struct {
+		desc   string
+		change func(Grid)
+		opts   Options
+		want   string
+	}{{
+		desc:   "unchanged",
+		change: func(Grid) {},
+		want:   "",
+	}, {
+		desc:   "single cell",
+		change: func(g Grid) { g[1][2] = full },
+		want:   "\x1b[2F\x1b[1E\x1b[3G⣿\x1b[1E",
+	}, {
+		desc:   "run of cells",
+		change: func(g Grid) { g[0][0], g[0][1] = full, full },
+		want:   "\x1b[2F\x1b[1G⣿⣿\x1b[2E",
+	}, {
+		desc:   "separate runs",
+		change: func(g Grid) { g[0][0], g[0][2] = full, full },
+		want:   "\x1b[2F\x1b[1G⣿\x1b[3G⣿\x1b[2E",
+	}, {
+		desc:   "framed",
+		change: func(g Grid) { g[0][0] = full },
+		opts:   Options{Frame: true},
+		want:   "\x1b[4F\x1b[1E\x1b[2G⣿\x1b[3E",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			opts := tt.opts
+			opts.NoColor = true
+			height := 2
+			if opts.Frame {
+				height = 4
+			}
+			next := blank()
+			tt.change(next)
+			if got := next.renderDiff(blank(), height, opts); got != tt.want {
+				t.Errorf("renderDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// slowWriter simulates a slow terminal, taking delay to write each frame.
+type slowWriter struct {
+	delay  time.Duration
+	writes int // Writes of frames, not of cursor escape codes
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	if len(p) > len("\x1b[?25l") {
+		w.writes++
+		time.Sleep(w.delay)
+	}
+	return len(p), nil
+}
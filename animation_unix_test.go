@@ -0,0 +1,40 @@
+//go:build unix
+
+package dots
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestPlayResize(t *testing.T) {
+	anim, err := DecodeGIF(bytes.NewReader(testGIF(t)))
+	if err != nil {
+		t.Fatalf("DecodeGIF() unexpected error: %v", err)
+	}
+	anim.Frames[0].Delay = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_ = syscall.Kill(syscall.Getpid(), syscall.SIGWINCH)
+	}()
+
+	var buf bytes.Buffer
+	if err := Play(ctx, &buf, anim, Options{Width: 4, Height: 2, NoColor: true}, PlayOptions{}); err != context.DeadlineExceeded {
+		t.Fatalf("Play() = %v, want context.DeadlineExceeded", err)
+	}
+	// The first frame is drawn, then cleared and redrawn in full on resize
+	out := buf.String()
+	if !strings.Contains(out, "\x1b[H\x1b[2J") {
+		t.Errorf("output %q doesn't clear the screen on resize", out)
+	}
+	if got := strings.Count(out, "\n"); got != 4 {
+		t.Errorf("drew %d lines, want 4", got)
+	}
+}
@@ -2,6 +2,7 @@ package dots
 
 import (
 	"fmt"
+	"image/color"
 	"image/png"
 	"os"
 	"testing"
@@ -205,3 +206,160 @@ func TestBackgroundColorNil(t *testing.T) {
 		}
 	}
 }
+
+func TestSampleBackground(t *testing.T) {
+	// Dark red is below the default threshold, so every dot is off and
+	// the sampled background should carry the image's color.
+	f, err := os.Open("testdata/dark_red.png")
+	if err != nil {
+		t.Fatalf("failed to open test image: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	decoded, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode test image: %v", err)
+	}
+
+	lines := Convert(decoded, Options{
+		Width:            2,
+		Height:           2,
+		SampleBackground: true,
+	})
+
+	for i, line := range lines {
+		if !containsSubstring(line, ";48;5;") {
+			t.Errorf("line %d: should contain background color code ;48;5;", i)
+		}
+		if containsSubstring(line, ";48;5;16m") {
+			t.Errorf("line %d: background should be sampled from the image, not black", i)
+		}
+	}
+}
+
+func TestBlockToBackgroundANSI(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	darkBlue := color.RGBA{0, 0, 95, 255}
+	for _, tt := range []struct {
+		desc  string
+		block [8]color.Color
+		want  uint8
+	}{
+		{
+			desc:  "averages only the dark pixels",
+			block: [8]color.Color{red, red, red, red, darkBlue, darkBlue, darkBlue, darkBlue},
+			want:  17, // 16 + 36*0 + 6*0 + 1
+		},
+		{
+			desc:  "all lit falls back to block average",
+			block: [8]color.Color{red, red, red, red, red, red, red, red},
+			want:  196,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			lit := make([]bool, len(tt.block))
+			for i, c := range tt.block {
+				lit[i] = luminance(c) > 20
+			}
+			got := blockToBackgroundANSI(tt.block[:], lit)
+			if got != tt.want {
+				t.Errorf("blockToBackgroundANSI() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHyperlink(t *testing.T) {
+	f, err := os.Open("testdata/red.png")
+	if err != nil {
+		t.Fatalf("failed to open test image: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	decoded, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode test image: %v", err)
+	}
+
+	const url = "https://example.com/red.png"
+	for _, tt := range []struct {
+		desc     string
+		opts     Options
+		wantLink bool
+	}{
+		{desc: "color", opts: Options{Width: 2, Height: 2, Hyperlink: url}, wantLink: true},
+		{desc: "frame", opts: Options{Width: 4, Height: 4, Frame: true, Hyperlink: url}, wantLink: true},
+		{desc: "no color", opts: Options{Width: 2, Height: 2, NoColor: true, Hyperlink: url}, wantLink: false},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			for i, line := range Convert(decoded, tt.opts) {
+				hasLink := containsSubstring(line, "\x1b]8;;"+url+"\x1b\\")
+				if hasLink != tt.wantLink {
+					t.Errorf("line %d: has hyperlink = %v, want %v", i, hasLink, tt.wantLink)
+				}
+			}
+		})
+	}
+}
+
+func TestVisibleWidth(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		s    string
+		want int
+	}{
+		{desc: "plain", s: "⣿⣿⣿", want: 3},
+		{desc: "color codes", s: ansiFgColor(196) + "⣿⣿" + ansiReset(), want: 2},
+		{desc: "hyperlink", s: hyperlink("file:///tmp/image.png", ansiFgColor(21)+"⣿"+ansiReset()), want: 1},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := visibleWidth(tt.s); got != tt.want {
+				t.Errorf("visibleWidth(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrameColor(t *testing.T) {
+	grid := Grid{{{Rune: '⣿', Fg: color.RGBA{255, 0, 0, 255}}}}
+
+	white := grid.Render(Options{Frame: true})
+	if !containsSubstring(white[0], ansiFgColor(15)+"┌") {
+		t.Errorf("default frame should be white, got %q", white[0])
+	}
+
+	black := uint8(16)
+	lines := grid.Render(Options{Frame: true, FrameColor: &black})
+	for i, line := range lines {
+		if !containsSubstring(line, ansiFgColor(16)) {
+			t.Errorf("line %d: frame should be black, got %q", i, line)
+		}
+	}
+}
+
+func TestColorEnvironment(t *testing.T) {
+	grid := Grid{{{Rune: '⣿', Fg: color.RGBA{255, 0, 0, 255}}}}
+	for _, tt := range []struct {
+		desc      string
+		env       map[string]string
+		noColor   bool
+		ignoreEnv bool
+		wantColor bool
+	}{
+		{desc: "default", wantColor: true},
+		{desc: "NO_COLOR", env: map[string]string{"NO_COLOR": "1"}},
+		{desc: "FORCE_COLOR", env: map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"}, wantColor: true},
+		{desc: "CLICOLOR_FORCE", env: map[string]string{"NO_COLOR": "1", "CLICOLOR_FORCE": "1"}, wantColor: true},
+		{desc: "FORCE_COLOR=0", env: map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "0"}},
+		{desc: "NoColor option wins", env: map[string]string{"FORCE_COLOR": "1"}, noColor: true},
+		{desc: "IgnoreColorEnv", env: map[string]string{"NO_COLOR": "1"}, ignoreEnv: true, wantColor: true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			for _, env := range []string{"NO_COLOR", "FORCE_COLOR", "CLICOLOR_FORCE"} {
+				t.Setenv(env, tt.env[env])
+			}
+			got := grid.Render(Options{NoColor: tt.noColor, IgnoreColorEnv: tt.ignoreEnv})[0]
+			if hasColor := containsSubstring(got, "\x1b["); hasColor != tt.wantColor {
+				t.Errorf("Render() = %q, want color %v", got, tt.wantColor)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package dots
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryBackgroundColor asks the terminal for its background color using the OSC 11 escape sequence.
+// It returns an error if there is no terminal, or if the terminal doesn't answer within timeout.
+func QueryBackgroundColor(timeout time.Duration) (color.RGBA, error) {
+	resp, err := queryTerminal("\x1b]11;?\x1b\\", 1, timeout)
+	if err != nil {
+		return color.RGBA{}, err
+	}
+	return parseOSCColor(resp)
+}
+
+// QuerySystemColors asks the terminal for the colors it shows for ANSI codes 0-15,
+// using the OSC 4 escape sequence, e.g. for Options.SystemColors.
+// It returns an error if there is no terminal, or if the terminal doesn't answer within timeout.
+func QuerySystemColors(timeout time.Duration) (*[16]color.RGBA, error) {
+	var query strings.Builder
+	for i := range 16 {
+		fmt.Fprintf(&query, "\x1b]4;%d;?\x1b\\", i)
+	}
+	resp, err := queryTerminal(query.String(), 16, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return parseSystemColors(resp)
+}
+
+// parseSystemColors parses a terminal's responses to OSC 4 queries for colors 0-15,
+// such as "\x1b]4;1;rgb:cdcd/0000/0000\x1b\\".
+func parseSystemColors(resp string) (*[16]color.RGBA, error) {
+	var colors [16]color.RGBA
+	var found [16]bool
+	for _, part := range strings.Split(resp, "\x1b]4;")[1:] {
+		index, rest, ok := strings.Cut(part, ";")
+		i, err := strconv.Atoi(index)
+		if !ok || err != nil || i < 0 || i >= 16 {
+			return nil, fmt.Errorf("unrecognized color response %q", part)
+		}
+		if colors[i], err = parseOSCColor(rest); err != nil {
+			return nil, err
+		}
+		found[i] = true
+	}
+	for i, ok := range found {
+		if !ok {
+			return nil, fmt.Errorf("terminal did not report color %d", i)
+		}
+	}
+	return &colors, nil
+}
+
+// IsLight reports whether a color is light, i.e. its perceived luminance is above mid-gray.
+func IsLight(c color.Color) bool {
+	return luminance(c) > 127
+}
+
+// parseOSCColor parses a color from a terminal's OSC color query response,
+// such as "\x1b]11;rgb:ffff/ffff/ffff\x1b\\". Each channel has 1 to 4 hex digits.
+func parseOSCColor(resp string) (color.RGBA, error) {
+	i := strings.Index(resp, "rgb:")
+	if i < 0 {
+		return color.RGBA{}, fmt.Errorf("unrecognized color response %q", resp)
+	}
+	spec := strings.TrimRight(resp[i+len("rgb:"):], "\x1b\\\a")
+
+	parts := strings.Split(spec, "/")
+	if len(parts) != 3 {
+		return color.RGBA{}, fmt.Errorf("unrecognized color response %q", resp)
+	}
+	var channels [3]uint8
+	for j, part := range parts {
+		if len(part) < 1 || len(part) > 4 {
+			return color.RGBA{}, fmt.Errorf("unrecognized color response %q", resp)
+		}
+		v, err := strconv.ParseUint(part, 16, 16)
+		if err != nil {
+			return color.RGBA{}, fmt.Errorf("unrecognized color response %q: %w", resp, err)
+		}
+		// Scale from n hex digits to 8 bits
+		maxValue := uint64(1)<<(4*len(part)) - 1
+		channels[j] = uint8(v * 255 / maxValue)
+	}
+	return color.RGBA{channels[0], channels[1], channels[2], 255}, nil
+}
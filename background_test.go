@@ -0,0 +1,83 @@
+package dots
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestParseOSCColor(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		resp    string
+		want    color.RGBA
+		wantErr bool
+	}{
+		{
+			desc: "16-bit channels, ST terminated",
+			resp: "\x1b]11;rgb:ffff/ffff/ffff\x1b\\",
+			want: color.RGBA{255, 255, 255, 255},
+		},
+		{
+			desc: "16-bit channels, BEL terminated",
+			resp: "\x1b]11;rgb:1e1e/1e1e/2e2e\a",
+			want: color.RGBA{30, 30, 46, 255},
+		},
+		{
+			desc: "8-bit channels",
+			resp: "\x1b]11;rgb:fd/f6/e3\x1b\\",
+			want: color.RGBA{253, 246, 227, 255},
+		},
+		{
+			desc:    "not a color",
+			resp:    "\x1b[?1;2c",
+			wantErr: true,
+		},
+		{
+			desc:    "missing channel",
+			resp:    "\x1b]11;rgb:ffff/ffff\x1b\\",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := parseOSCColor(tt.resp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOSCColor(%q) error = %v, wantErr %v", tt.resp, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseOSCColor(%q) = %v, want %v", tt.resp, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsLight(t *testing.T) {
+	if !IsLight(color.RGBA{253, 246, 227, 255}) {
+		t.Error("solarized light background should be light")
+	}
+	if IsLight(color.RGBA{0, 43, 54, 255}) {
+		t.Error("solarized dark background should be dark")
+	}
+}
+
+func TestParseSystemColors(t *testing.T) {
+	var resp strings.Builder
+	for i := range 16 {
+		fmt.Fprintf(&resp, "\x1b]4;%d;rgb:%02x%02x/0000/0000\x1b\\", i, i*16, i*16)
+	}
+
+	colors, err := parseSystemColors(resp.String())
+	if err != nil {
+		t.Fatalf("parseSystemColors() unexpected error: %v", err)
+	}
+	for i, c := range colors {
+		if want := (color.RGBA{uint8(i * 16), 0, 0, 255}); c != want {
+			t.Errorf("color %d = %v, want %v", i, c, want)
+		}
+	}
+
+	if _, err := parseSystemColors("\x1b]4;0;rgb:0000/0000/0000\x1b\\"); err == nil {
+		t.Error("parseSystemColors() with one color expected error, got nil")
+	}
+}
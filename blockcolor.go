@@ -0,0 +1,60 @@
+package dots
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// BlockColorMode selects how a braille cell's color is chosen from its pixels.
+type BlockColorMode string
+
+// Supported block color modes.
+const (
+	// BlockColorAverage quantizes the average color of the pixels.
+	BlockColorAverage BlockColorMode = ""
+	// BlockColorMajority quantizes every pixel and picks the most frequent color.
+	// Averaging produces muddy in-between colors on high-contrast blocks, like text
+	// on a colored background.
+	BlockColorMajority BlockColorMode = "majority"
+)
+
+// BlockColorModes lists the names of all supported block color modes.
+var BlockColorModes = []BlockColorMode{BlockColorMajority}
+
+// ParseBlockColorMode validates a block color mode name.
+// The empty string and "average" select averaging.
+func ParseBlockColorMode(s string) (BlockColorMode, error) {
+	if s == "" || s == "average" {
+		return BlockColorAverage, nil
+	}
+	for _, mode := range BlockColorModes {
+		if BlockColorMode(s) == mode {
+			return mode, nil
+		}
+	}
+	return BlockColorAverage, fmt.Errorf("unknown block color mode %q", s)
+}
+
+// blockCode returns the ANSI 256 color code for a block's pixels.
+func (m BlockColorMode) blockCode(block []color.Color, quantize func(r, g, b uint8) uint8) uint8 {
+	if m == BlockColorMajority {
+		return majorityColor(block, quantize)
+	}
+	return quantize(averageColor(block))
+}
+
+// majorityColor quantizes each pixel of a block and returns the most frequent code.
+// Ties go to the code that reached the winning count first.
+func majorityColor(block []color.Color, quantize func(r, g, b uint8) uint8) uint8 {
+	var counts [256]int
+	best := uint8(0)
+	for i, c := range block {
+		r, g, b, _ := c.RGBA()
+		code := quantize(uint8(r>>8), uint8(g>>8), uint8(b>>8))
+		counts[code]++
+		if i == 0 || counts[code] > counts[best] {
+			best = code
+		}
+	}
+	return best
+}
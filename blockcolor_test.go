@@ -0,0 +1,84 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestParseBlockColorMode(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		s       string
+		want    BlockColorMode
+		wantErr bool
+	}{
+		{desc: "empty", s: "", want: BlockColorAverage},
+		{desc: "average", s: "average", want: BlockColorAverage},
+		{desc: "majority", s: "majority", want: BlockColorMajority},
+		{desc: "unknown", s: "median", wantErr: true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseBlockColorMode(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBlockColorMode(%q) error = %v, wantErr %t", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBlockColorMode(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockCode(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	green := color.RGBA{0, 255, 0, 255}
+	// Red text on a green background: 5 green pixels, 3 red
+	block := []color.Color{green, red, green, red, green, red, green, green}
+
+	for _, tt := range []struct {
+		desc string
+		mode BlockColorMode
+		want uint8
+	}{
+		{desc: "average mixes a muddy color", mode: BlockColorAverage, want: quantizeRGB(95, 159, 0)},
+		{desc: "majority picks green", mode: BlockColorMajority, want: 46},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := tt.mode.blockCode(block, quantizeRGB); got != tt.want {
+				t.Errorf("blockCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorLitDots(t *testing.T) {
+	// A bright yellow feature in the top half of an otherwise black block
+	img := image.NewRGBA(image.Rect(0, 0, 2, 4))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			img.SetRGBA(x, y, color.RGBA{255, 255, 0, 255})
+		}
+	}
+	for y := 2; y < 4; y++ {
+		for x := 0; x < 2; x++ {
+			img.SetRGBA(x, y, color.RGBA{0, 0, 0, 255})
+		}
+	}
+
+	for _, tt := range []struct {
+		desc         string
+		colorLitDots bool
+		want         uint8
+	}{
+		{desc: "average of all pixels is dimmed", colorLitDots: false, want: quantizeRGB(127, 127, 0)},
+		{desc: "lit dots keep their color", colorLitDots: true, want: 226},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			grid := ConvertGrid(img, Options{Width: 1, Height: 1, ColorLitDots: tt.colorLitDots})
+			if got := rgbaToANSI(grid[0][0].Fg); got != tt.want {
+				t.Errorf("Fg = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
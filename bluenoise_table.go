@@ -0,0 +1,71 @@
+// Code generated by gen_bluenoise.go; DO NOT EDIT.
+
+package dots
+
+// blueNoise is a 64×64 blue-noise threshold mask, indexed [y*64+x], with values in [0, 255].
+var blueNoise = [4096]uint8{
+	110, 26, 137, 44, 222, 11, 189, 119, 175, 7, 151, 230, 114, 169, 30, 69, 48, 236, 4, 164, 189, 57, 129, 15, 78, 142, 20, 211, 85, 185, 143, 111, 77, 173, 119, 187, 35, 89, 17, 123, 71, 33, 130, 184, 86, 34, 174, 101, 132, 38, 235, 177, 87, 5, 111, 201, 52, 187, 214, 108, 233, 197, 87, 160,
+	205, 231, 99, 190, 69, 147, 87, 26, 255, 108, 203, 37, 187, 84, 220, 195, 143, 213, 95, 135, 238, 28, 201, 252, 165, 37, 179, 105, 158, 226, 38, 193, 229, 60, 19, 100, 157, 237, 54, 148, 225, 100, 203, 14, 146, 219, 121, 17, 253, 199, 99, 124, 42, 220, 165, 255, 99, 10, 133, 34, 62, 172, 20, 41,
+	168, 54, 151, 22, 120, 235, 204, 133, 47, 80, 139, 67, 246, 2, 154, 108, 17, 181, 36, 220, 73, 110, 151, 96, 62, 194, 241, 55, 1, 125, 97, 21, 159, 133, 253, 214, 67, 196, 106, 182, 4, 161, 60, 251, 166, 70, 196, 50, 162, 63, 25, 153, 192, 134, 65, 33, 124, 222, 174, 240, 141, 102, 252, 125,
+	10, 89, 247, 201, 175, 36, 58, 158, 221, 184, 22, 165, 104, 131, 57, 242, 77, 119, 56, 153, 11, 182, 42, 215, 7, 117, 80, 138, 216, 72, 246, 53, 208, 90, 43, 145, 13, 130, 32, 248, 82, 212, 116, 42, 96, 5, 240, 144, 86, 182, 217, 76, 241, 18, 94, 196, 159, 76, 45, 91, 1, 188, 74, 214,
+	184, 132, 72, 3, 109, 84, 246, 100, 8, 120, 239, 208, 48, 227, 184, 33, 208, 166, 254, 196, 127, 240, 82, 132, 235, 154, 202, 28, 164, 189, 135, 175, 114, 6, 198, 178, 84, 220, 167, 49, 133, 23, 188, 225, 137, 181, 110, 23, 228, 117, 0, 105, 50, 174, 227, 142, 13, 245, 116, 207, 152, 226, 48, 108,
+	241, 34, 212, 164, 225, 149, 25, 173, 197, 55, 89, 148, 11, 82, 159, 100, 138, 6, 85, 103, 27, 58, 205, 173, 22, 99, 47, 255, 92, 14, 40, 218, 67, 235, 123, 55, 245, 115, 70, 201, 228, 90, 152, 65, 32, 218, 78, 205, 41, 138, 247, 163, 211, 121, 80, 41, 203, 61, 182, 28, 69, 128, 16, 157,
+	60, 144, 98, 47, 125, 64, 207, 129, 75, 231, 32, 177, 117, 200, 21, 239, 67, 220, 46, 170, 225, 147, 107, 37, 72, 223, 181, 115, 62, 233, 104, 150, 24, 165, 93, 31, 159, 0, 147, 26, 109, 172, 7, 236, 122, 158, 54, 169, 97, 185, 61, 34, 146, 10, 251, 112, 164, 96, 138, 217, 166, 234, 88, 204,
+	120, 193, 17, 253, 189, 13, 240, 44, 166, 142, 99, 245, 68, 222, 129, 39, 191, 149, 118, 204, 71, 0, 251, 157, 194, 131, 5, 149, 208, 129, 196, 85, 251, 135, 225, 185, 212, 98, 239, 191, 57, 249, 77, 104, 198, 11, 252, 130, 21, 233, 83, 201, 96, 189, 52, 216, 25, 237, 6, 54, 101, 38, 179, 27,
+	82, 227, 173, 77, 107, 156, 87, 111, 0, 219, 22, 192, 43, 151, 91, 171, 106, 15, 246, 35, 134, 188, 85, 118, 52, 244, 82, 34, 171, 51, 7, 180, 43, 73, 14, 62, 126, 43, 79, 121, 157, 38, 209, 147, 51, 94, 192, 71, 210, 150, 8, 127, 239, 68, 135, 177, 74, 154, 123, 186, 254, 147, 111, 238,
+	160, 37, 58, 140, 213, 32, 229, 179, 201, 57, 123, 160, 109, 5, 251, 55, 226, 70, 176, 92, 162, 234, 32, 203, 14, 162, 212, 107, 228, 80, 238, 119, 210, 172, 111, 202, 150, 228, 173, 20, 221, 136, 16, 178, 240, 34, 152, 114, 46, 101, 221, 170, 29, 156, 15, 92, 198, 35, 224, 82, 15, 70, 211, 2,
+	134, 105, 221, 6, 169, 52, 123, 69, 140, 254, 85, 213, 63, 180, 203, 28, 143, 208, 115, 53, 17, 127, 63, 228, 96, 126, 64, 190, 17, 139, 160, 31, 96, 141, 232, 34, 86, 8, 198, 65, 94, 188, 111, 61, 128, 222, 173, 13, 242, 181, 74, 49, 112, 210, 230, 116, 248, 58, 105, 202, 137, 167, 47, 191,
+	67, 249, 196, 121, 94, 247, 195, 15, 100, 32, 176, 13, 237, 131, 74, 118, 87, 3, 238, 183, 215, 100, 173, 146, 185, 35, 234, 152, 54, 100, 217, 66, 247, 3, 55, 185, 244, 107, 134, 255, 42, 235, 81, 212, 2, 88, 66, 203, 139, 25, 125, 254, 191, 82, 39, 167, 1, 148, 175, 21, 221, 118, 243, 95,
+	12, 145, 31, 80, 186, 26, 144, 217, 163, 226, 52, 146, 93, 36, 227, 168, 193, 153, 39, 135, 71, 244, 23, 47, 79, 206, 2, 115, 249, 185, 21, 200, 166, 88, 153, 128, 68, 168, 28, 158, 119, 169, 26, 145, 165, 251, 123, 50, 92, 215, 161, 4, 98, 147, 63, 195, 131, 80, 240, 41, 93, 61, 25, 177,
+	209, 165, 54, 236, 154, 67, 109, 47, 82, 126, 190, 109, 200, 155, 12, 48, 235, 65, 106, 205, 9, 156, 198, 112, 252, 139, 92, 175, 38, 83, 135, 110, 45, 192, 226, 19, 216, 47, 207, 79, 10, 216, 56, 197, 100, 29, 219, 151, 191, 40, 69, 227, 183, 18, 242, 102, 214, 51, 185, 126, 162, 194, 224, 124,
+	74, 102, 218, 127, 8, 207, 243, 174, 4, 238, 71, 22, 250, 80, 209, 139, 95, 24, 254, 170, 88, 122, 61, 218, 15, 163, 66, 213, 149, 228, 62, 241, 11, 122, 75, 106, 180, 91, 233, 144, 190, 98, 246, 121, 47, 186, 75, 9, 106, 245, 142, 117, 55, 207, 127, 30, 160, 16, 108, 232, 8, 82, 150, 35,
+	234, 185, 21, 94, 168, 38, 132, 97, 198, 152, 42, 171, 127, 56, 176, 115, 218, 185, 125, 49, 226, 29, 182, 94, 129, 48, 238, 25, 122, 18, 177, 144, 210, 161, 235, 36, 139, 2, 114, 61, 33, 132, 73, 6, 233, 133, 163, 227, 176, 20, 84, 172, 28, 154, 73, 222, 91, 253, 67, 207, 142, 51, 250, 111,
+	59, 141, 44, 255, 194, 78, 222, 59, 29, 111, 205, 230, 99, 1, 239, 33, 70, 8, 160, 80, 197, 138, 242, 36, 171, 200, 101, 183, 80, 206, 103, 50, 89, 26, 63, 199, 248, 160, 194, 240, 167, 225, 181, 154, 206, 61, 95, 38, 120, 201, 45, 214, 251, 109, 176, 44, 192, 138, 170, 24, 97, 199, 171, 4,
+	122, 205, 157, 62, 115, 149, 12, 182, 251, 136, 86, 19, 163, 194, 132, 88, 199, 141, 231, 37, 111, 1, 153, 78, 234, 9, 147, 59, 255, 156, 0, 187, 246, 136, 174, 117, 49, 76, 24, 99, 53, 14, 90, 41, 107, 22, 241, 144, 70, 232, 152, 99, 62, 5, 229, 123, 10, 55, 116, 227, 39, 130, 72, 216,
+	179, 16, 85, 215, 27, 240, 101, 159, 73, 214, 53, 147, 68, 222, 46, 153, 247, 58, 95, 178, 250, 69, 204, 108, 51, 122, 224, 30, 113, 44, 226, 125, 70, 216, 8, 93, 223, 132, 209, 153, 120, 213, 145, 246, 192, 169, 214, 0, 188, 93, 13, 130, 192, 145, 87, 204, 157, 236, 81, 187, 151, 244, 23, 93,
+	40, 246, 108, 169, 131, 51, 192, 35, 119, 5, 186, 244, 116, 27, 103, 180, 12, 120, 211, 18, 129, 169, 28, 220, 179, 158, 90, 208, 137, 193, 85, 170, 34, 106, 191, 156, 18, 179, 42, 253, 67, 186, 31, 63, 119, 80, 48, 126, 161, 57, 246, 173, 39, 238, 25, 64, 105, 33, 206, 13, 64, 109, 163, 225,
+	147, 71, 190, 3, 232, 74, 210, 141, 237, 171, 40, 90, 201, 170, 235, 76, 219, 44, 158, 78, 223, 55, 142, 84, 19, 248, 69, 6, 167, 62, 24, 237, 150, 55, 251, 68, 234, 88, 110, 4, 169, 94, 232, 138, 12, 157, 254, 103, 199, 33, 211, 73, 108, 208, 126, 182, 250, 166, 135, 97, 178, 210, 49, 127,
+	18, 235, 50, 140, 95, 179, 12, 87, 62, 106, 226, 134, 10, 57, 128, 23, 143, 190, 109, 198, 40, 101, 233, 196, 115, 42, 187, 102, 243, 215, 96, 118, 208, 20, 142, 124, 29, 205, 146, 218, 131, 22, 201, 103, 222, 196, 28, 69, 225, 141, 119, 17, 164, 51, 151, 83, 23, 58, 219, 40, 234, 0, 81, 200,
+	177, 113, 160, 221, 39, 117, 250, 161, 215, 17, 153, 75, 255, 160, 206, 99, 248, 67, 3, 241, 149, 182, 8, 64, 137, 218, 153, 124, 46, 140, 10, 183, 76, 172, 92, 195, 47, 168, 74, 53, 240, 79, 45, 178, 56, 90, 147, 176, 7, 84, 180, 243, 92, 220, 2, 228, 200, 124, 87, 159, 113, 143, 253, 100,
+	60, 209, 84, 20, 196, 149, 58, 33, 128, 176, 51, 192, 110, 37, 83, 179, 48, 169, 136, 90, 27, 118, 251, 170, 93, 14, 239, 29, 81, 199, 155, 248, 49, 219, 2, 230, 113, 245, 10, 183, 106, 160, 141, 249, 3, 124, 239, 44, 113, 235, 54, 145, 35, 189, 67, 105, 144, 13, 246, 189, 27, 67, 167, 31,
+	137, 9, 239, 130, 71, 228, 103, 186, 243, 95, 207, 20, 138, 216, 7, 231, 125, 20, 214, 230, 69, 155, 36, 213, 49, 198, 69, 178, 224, 107, 61, 27, 131, 104, 145, 64, 161, 84, 126, 203, 19, 229, 30, 111, 167, 215, 75, 189, 135, 202, 14, 214, 111, 133, 255, 167, 38, 179, 75, 49, 223, 123, 195, 229,
+	157, 97, 183, 45, 166, 24, 209, 6, 77, 40, 118, 237, 66, 185, 104, 148, 75, 193, 102, 47, 186, 206, 81, 110, 143, 163, 99, 132, 1, 163, 217, 88, 197, 236, 39, 187, 23, 218, 43, 149, 65, 211, 86, 196, 64, 20, 150, 29, 95, 65, 155, 77, 174, 21, 50, 89, 236, 117, 212, 102, 148, 8, 88, 43,
+	248, 66, 204, 120, 252, 83, 144, 124, 168, 219, 145, 87, 165, 43, 249, 57, 211, 35, 160, 122, 15, 132, 238, 4, 221, 26, 232, 54, 254, 37, 122, 176, 16, 153, 80, 210, 105, 139, 252, 95, 174, 118, 50, 137, 231, 103, 199, 245, 164, 221, 37, 244, 96, 207, 148, 191, 9, 60, 163, 20, 235, 174, 213, 115,
+	177, 30, 148, 2, 105, 193, 49, 239, 64, 25, 183, 0, 222, 122, 14, 175, 113, 235, 83, 253, 177, 93, 56, 187, 74, 118, 183, 82, 191, 143, 72, 229, 49, 119, 247, 6, 177, 60, 31, 194, 4, 242, 161, 12, 181, 39, 121, 56, 9, 111, 195, 126, 4, 231, 72, 121, 223, 139, 199, 85, 129, 50, 76, 21,
+	209, 91, 232, 57, 213, 34, 161, 93, 189, 111, 247, 57, 151, 83, 205, 138, 26, 151, 2, 61, 218, 33, 162, 136, 249, 43, 154, 17, 104, 210, 9, 101, 191, 66, 163, 89, 126, 231, 156, 77, 132, 40, 215, 75, 251, 88, 143, 226, 174, 86, 148, 59, 184, 45, 159, 25, 98, 43, 248, 30, 188, 242, 160, 134,
+	43, 125, 186, 78, 169, 138, 230, 8, 210, 37, 135, 98, 194, 36, 228, 64, 93, 201, 170, 112, 143, 200, 108, 20, 203, 94, 218, 125, 236, 53, 156, 245, 133, 25, 226, 44, 208, 16, 112, 237, 204, 98, 149, 114, 26, 170, 209, 72, 34, 253, 22, 225, 105, 136, 250, 201, 173, 76, 153, 120, 63, 97, 5, 229,
+	68, 157, 13, 244, 112, 24, 71, 127, 154, 81, 175, 232, 22, 161, 106, 179, 242, 39, 72, 232, 24, 52, 224, 68, 172, 6, 62, 162, 28, 180, 86, 36, 211, 178, 108, 141, 184, 70, 170, 49, 23, 178, 60, 195, 224, 52, 1, 114, 188, 128, 207, 77, 166, 27, 62, 113, 5, 229, 182, 14, 223, 146, 195, 109,
+	255, 202, 97, 39, 218, 184, 101, 252, 48, 220, 13, 65, 119, 254, 51, 15, 118, 214, 135, 185, 99, 167, 128, 239, 103, 146, 244, 197, 75, 136, 202, 117, 70, 10, 83, 255, 32, 101, 224, 125, 86, 243, 8, 131, 93, 159, 136, 238, 94, 44, 155, 9, 193, 236, 91, 216, 131, 54, 105, 202, 81, 44, 172, 28,
+	140, 52, 176, 129, 152, 53, 201, 18, 164, 112, 196, 148, 207, 81, 142, 192, 159, 86, 7, 56, 250, 15, 83, 40, 184, 26, 117, 46, 107, 252, 3, 162, 237, 146, 216, 59, 192, 158, 0, 197, 141, 216, 162, 42, 248, 66, 192, 20, 172, 63, 242, 99, 122, 48, 179, 154, 35, 242, 141, 31, 249, 126, 212, 85,
+	220, 3, 234, 73, 21, 89, 228, 140, 68, 241, 93, 34, 170, 6, 215, 63, 31, 244, 199, 114, 150, 211, 193, 137, 216, 78, 226, 188, 19, 214, 59, 98, 44, 174, 121, 16, 133, 85, 245, 65, 30, 108, 77, 198, 118, 28, 230, 83, 219, 146, 200, 33, 223, 140, 13, 82, 209, 69, 171, 95, 158, 59, 15, 114,
+	69, 159, 104, 189, 247, 167, 119, 36, 180, 3, 137, 57, 245, 90, 127, 233, 106, 140, 71, 176, 37, 94, 65, 1, 163, 55, 130, 157, 88, 172, 143, 224, 194, 30, 94, 202, 236, 46, 113, 152, 184, 51, 231, 11, 149, 180, 105, 41, 126, 5, 115, 84, 167, 65, 254, 189, 121, 22, 224, 0, 187, 230, 144, 183,
+	45, 200, 31, 139, 60, 9, 197, 76, 212, 109, 231, 184, 115, 44, 187, 18, 172, 45, 220, 17, 237, 142, 227, 114, 253, 96, 13, 242, 42, 122, 23, 74, 113, 249, 63, 164, 26, 178, 221, 15, 206, 129, 171, 97, 214, 73, 139, 202, 168, 58, 247, 188, 19, 213, 110, 45, 162, 90, 199, 115, 77, 37, 97, 246,
+	130, 90, 231, 116, 220, 149, 101, 237, 50, 150, 81, 23, 159, 220, 143, 75, 207, 93, 160, 123, 55, 189, 25, 174, 38, 199, 146, 183, 70, 198, 239, 180, 11, 156, 130, 224, 77, 142, 98, 68, 250, 85, 39, 241, 22, 52, 255, 13, 224, 80, 151, 41, 131, 95, 149, 7, 238, 139, 49, 246, 133, 206, 156, 23,
+	215, 174, 12, 80, 44, 184, 25, 131, 172, 15, 222, 193, 64, 102, 34, 241, 117, 8, 252, 78, 209, 106, 73, 132, 214, 81, 109, 32, 229, 100, 51, 137, 87, 208, 48, 4, 116, 209, 31, 164, 120, 3, 158, 134, 188, 119, 160, 96, 184, 29, 108, 204, 236, 53, 195, 219, 72, 180, 25, 166, 62, 14, 227, 73,
+	49, 146, 248, 162, 209, 91, 254, 66, 204, 98, 42, 119, 252, 1, 200, 156, 54, 192, 136, 35, 165, 5, 243, 157, 58, 9, 222, 167, 134, 2, 159, 216, 29, 186, 101, 245, 180, 57, 229, 190, 45, 234, 197, 56, 89, 211, 31, 63, 123, 239, 165, 66, 16, 177, 84, 30, 128, 105, 231, 88, 195, 103, 176, 118,
+	204, 100, 27, 59, 133, 1, 156, 35, 123, 241, 164, 140, 86, 179, 127, 72, 226, 24, 98, 183, 233, 124, 43, 96, 177, 247, 122, 53, 208, 79, 253, 116, 69, 228, 139, 81, 154, 16, 130, 90, 147, 73, 106, 225, 11, 173, 234, 140, 217, 1, 91, 143, 215, 120, 161, 249, 59, 204, 9, 154, 46, 254, 140, 7,
+	68, 183, 126, 230, 194, 112, 224, 180, 84, 8, 57, 212, 22, 233, 42, 104, 174, 147, 216, 51, 86, 148, 221, 190, 30, 140, 90, 24, 186, 106, 20, 177, 45, 162, 18, 40, 203, 104, 254, 24, 213, 174, 19, 154, 69, 113, 46, 83, 196, 54, 181, 252, 37, 101, 4, 144, 187, 41, 135, 224, 116, 29, 83, 236,
+	22, 219, 82, 38, 166, 74, 47, 143, 232, 191, 109, 175, 74, 149, 206, 10, 249, 66, 116, 11, 197, 63, 21, 116, 71, 199, 237, 159, 66, 233, 147, 200, 92, 238, 124, 187, 234, 72, 169, 60, 118, 39, 241, 126, 199, 250, 149, 21, 161, 115, 24, 130, 79, 192, 230, 71, 112, 242, 80, 177, 63, 216, 188, 161,
+	130, 155, 108, 240, 12, 99, 209, 17, 69, 131, 30, 248, 49, 113, 166, 91, 134, 37, 178, 239, 158, 106, 255, 168, 217, 49, 10, 114, 213, 32, 56, 128, 5, 210, 107, 56, 144, 1, 133, 221, 197, 85, 187, 52, 91, 7, 182, 103, 244, 208, 65, 224, 164, 53, 210, 34, 171, 17, 100, 205, 5, 145, 93, 52,
+	251, 4, 56, 198, 148, 178, 251, 116, 168, 206, 95, 145, 215, 17, 230, 59, 198, 223, 77, 128, 30, 204, 84, 0, 150, 129, 79, 181, 136, 89, 174, 248, 75, 155, 30, 86, 223, 180, 46, 98, 10, 135, 160, 29, 227, 130, 205, 72, 40, 138, 96, 185, 12, 142, 91, 127, 235, 150, 50, 162, 247, 123, 32, 203,
+	75, 179, 216, 123, 70, 23, 134, 58, 36, 234, 2, 193, 86, 130, 182, 32, 155, 3, 97, 213, 59, 140, 45, 227, 95, 194, 250, 40, 227, 15, 206, 112, 46, 188, 241, 166, 25, 109, 247, 151, 229, 59, 248, 111, 170, 61, 27, 230, 175, 6, 237, 45, 112, 243, 24, 183, 63, 211, 121, 25, 76, 182, 229, 107,
+	44, 142, 96, 36, 242, 89, 212, 187, 107, 79, 161, 60, 36, 246, 105, 79, 236, 113, 172, 19, 243, 162, 188, 123, 61, 29, 155, 103, 64, 165, 145, 27, 228, 133, 61, 120, 212, 73, 190, 31, 82, 202, 18, 78, 214, 145, 98, 157, 114, 83, 151, 203, 62, 158, 199, 105, 0, 85, 229, 194, 102, 56, 16, 165,
+	196, 236, 21, 164, 184, 47, 152, 12, 247, 137, 219, 119, 176, 151, 14, 193, 139, 40, 190, 132, 73, 108, 13, 245, 171, 219, 12, 204, 126, 244, 79, 100, 177, 8, 92, 198, 12, 132, 53, 168, 121, 178, 142, 44, 191, 1, 254, 50, 219, 188, 31, 129, 223, 80, 36, 255, 146, 169, 40, 135, 242, 152, 221, 128,
+	9, 114, 78, 222, 128, 104, 226, 70, 169, 47, 24, 201, 74, 226, 48, 215, 63, 250, 86, 222, 34, 199, 87, 40, 112, 137, 83, 181, 48, 2, 190, 58, 217, 151, 253, 43, 157, 237, 215, 94, 4, 220, 99, 240, 121, 89, 181, 131, 14, 68, 249, 100, 9, 175, 136, 55, 215, 112, 73, 11, 175, 35, 92, 68,
+	249, 154, 204, 58, 1, 197, 32, 120, 204, 89, 241, 103, 8, 136, 91, 163, 123, 6, 150, 55, 168, 237, 144, 208, 71, 233, 33, 150, 212, 117, 229, 131, 39, 113, 71, 184, 104, 78, 28, 147, 252, 67, 27, 159, 59, 222, 34, 80, 206, 167, 142, 52, 195, 118, 227, 94, 21, 183, 237, 207, 60, 117, 210, 182,
+	53, 35, 100, 136, 255, 81, 145, 232, 6, 155, 129, 188, 55, 253, 181, 21, 102, 185, 210, 114, 11, 125, 58, 162, 5, 176, 102, 254, 64, 92, 29, 156, 206, 18, 223, 139, 16, 205, 173, 114, 42, 184, 137, 208, 12, 169, 139, 241, 107, 22, 217, 84, 242, 28, 68, 198, 152, 49, 129, 90, 148, 245, 23, 137,
+	165, 230, 190, 26, 159, 180, 56, 102, 185, 64, 38, 228, 148, 113, 36, 200, 235, 76, 32, 231, 92, 192, 27, 248, 130, 50, 191, 17, 141, 173, 241, 73, 187, 91, 165, 58, 244, 128, 52, 232, 197, 87, 110, 233, 73, 103, 196, 54, 158, 124, 43, 184, 106, 143, 172, 7, 249, 107, 32, 195, 3, 170, 103, 82,
+	7, 115, 70, 220, 92, 37, 242, 18, 214, 85, 175, 19, 82, 217, 68, 141, 46, 131, 158, 66, 172, 223, 77, 107, 217, 88, 226, 116, 43, 202, 12, 110, 48, 249, 121, 33, 193, 97, 7, 76, 155, 14, 38, 176, 125, 30, 225, 9, 75, 193, 232, 2, 162, 41, 230, 124, 79, 213, 165, 235, 76, 46, 222, 201,
+	241, 143, 177, 14, 126, 208, 110, 163, 134, 234, 121, 203, 157, 0, 175, 246, 88, 207, 19, 253, 119, 41, 150, 188, 19, 165, 68, 153, 235, 79, 133, 224, 147, 0, 182, 79, 148, 225, 169, 212, 117, 249, 201, 57, 243, 148, 88, 172, 247, 96, 59, 129, 74, 212, 93, 51, 178, 13, 60, 136, 111, 185, 128, 64,
+	39, 89, 52, 246, 153, 62, 195, 76, 48, 27, 103, 57, 240, 97, 122, 26, 164, 109, 186, 54, 144, 2, 211, 60, 134, 38, 203, 3, 97, 189, 31, 170, 67, 217, 101, 233, 19, 67, 130, 35, 61, 141, 98, 162, 2, 211, 50, 133, 35, 152, 207, 178, 252, 16, 193, 140, 244, 101, 153, 206, 27, 254, 16, 155,
+	117, 218, 191, 102, 32, 229, 3, 146, 187, 249, 171, 138, 42, 190, 212, 62, 223, 7, 81, 218, 101, 238, 85, 177, 229, 105, 250, 173, 121, 57, 247, 91, 203, 42, 127, 157, 48, 204, 103, 238, 190, 17, 225, 78, 118, 190, 102, 182, 221, 13, 112, 30, 89, 156, 116, 28, 202, 42, 232, 89, 52, 164, 78, 196,
+	25, 163, 6, 135, 171, 82, 120, 218, 97, 16, 74, 223, 19, 79, 151, 36, 138, 240, 125, 158, 26, 168, 46, 116, 10, 71, 138, 47, 221, 149, 9, 115, 142, 15, 195, 86, 253, 179, 4, 156, 88, 174, 48, 138, 34, 231, 19, 76, 123, 56, 234, 145, 47, 227, 65, 171, 78, 131, 16, 183, 124, 211, 100, 236,
+	133, 72, 239, 56, 200, 253, 43, 167, 60, 205, 155, 107, 181, 254, 110, 179, 92, 194, 66, 41, 198, 129, 206, 245, 155, 196, 87, 23, 191, 77, 164, 227, 61, 243, 171, 28, 113, 134, 75, 217, 24, 124, 199, 244, 167, 65, 154, 253, 202, 167, 72, 191, 99, 180, 4, 240, 104, 219, 158, 71, 243, 0, 146, 45,
+	186, 95, 209, 116, 18, 99, 140, 11, 233, 118, 36, 198, 54, 132, 11, 233, 50, 21, 166, 251, 79, 15, 94, 64, 30, 217, 165, 234, 107, 41, 210, 29, 186, 104, 76, 222, 58, 197, 41, 141, 248, 65, 104, 6, 92, 217, 115, 42, 95, 5, 132, 245, 22, 219, 126, 148, 33, 55, 192, 108, 37, 175, 65, 227,
+	10, 152, 33, 178, 75, 161, 223, 189, 84, 137, 239, 3, 92, 168, 69, 200, 154, 119, 212, 105, 142, 234, 175, 147, 110, 51, 124, 12, 146, 255, 122, 90, 135, 46, 145, 161, 10, 236, 168, 110, 29, 181, 213, 149, 51, 193, 15, 140, 182, 225, 38, 114, 160, 50, 83, 206, 176, 252, 11, 141, 225, 96, 205, 120,
+	252, 53, 220, 136, 244, 37, 58, 110, 24, 178, 70, 150, 207, 244, 29, 102, 221, 74, 5, 181, 52, 35, 220, 0, 186, 248, 74, 179, 94, 59, 181, 7, 205, 232, 19, 208, 125, 70, 93, 228, 153, 84, 39, 238, 121, 170, 83, 240, 63, 157, 86, 211, 68, 194, 110, 18, 64, 91, 122, 201, 54, 156, 21, 77,
+	169, 112, 87, 16, 185, 124, 207, 152, 250, 50, 223, 111, 38, 120, 144, 181, 40, 135, 230, 89, 200, 127, 104, 77, 205, 134, 39, 231, 202, 28, 153, 242, 70, 170, 112, 90, 250, 33, 189, 1, 57, 203, 134, 11, 66, 230, 31, 209, 125, 20, 186, 139, 8, 250, 166, 231, 144, 211, 166, 76, 26, 245, 134, 194,
+	38, 232, 159, 61, 217, 91, 1, 78, 195, 102, 14, 173, 79, 228, 7, 87, 240, 59, 150, 26, 161, 66, 243, 164, 23, 96, 154, 6, 113, 221, 87, 41, 128, 27, 194, 54, 176, 145, 219, 128, 173, 255, 107, 163, 197, 94, 152, 53, 106, 247, 45, 226, 95, 32, 125, 46, 102, 3, 41, 231, 114, 173, 60, 98,
+	143, 11, 202, 117, 31, 171, 238, 137, 33, 163, 129, 215, 191, 61, 158, 205, 20, 193, 109, 250, 210, 10, 144, 44, 216, 60, 197, 171, 72, 132, 168, 200, 98, 151, 243, 81, 9, 109, 65, 41, 97, 18, 75, 214, 22, 136, 186, 2, 202, 168, 71, 117, 156, 189, 72, 218, 175, 246, 127, 190, 84, 218, 5, 210,
+	67, 183, 81, 251, 155, 104, 53, 213, 66, 236, 88, 53, 20, 133, 252, 101, 127, 172, 78, 43, 120, 90, 224, 180, 108, 237, 122, 47, 251, 17, 56, 238, 4, 213, 45, 138, 228, 203, 166, 243, 195, 155, 233, 51, 115, 245, 62, 225, 81, 146, 14, 209, 55, 239, 141, 23, 85, 150, 67, 18, 152, 46, 127, 243,
+}
@@ -5,6 +5,8 @@ import (
 	"image"
 	"image/color"
 	"os"
+	"strings"
+	"unicode/utf8"
 
 	"golang.org/x/image/draw"
 	"golang.org/x/term"
@@ -27,10 +29,215 @@ func getTerminalSize() (int, int) {
 type Options struct {
 	Width           int    // Width in braille characters
 	Height          int    // Height in braille characters
-	Threshold       uint8  // Brightness threshold (0-255), default 20
+	Threshold       uint8  // Brightness threshold (0-255), default 20 (235 inverted, 128 when dithering, 48 for edges)
 	NoColor         bool   // Disable ANSI color output
 	BackgroundColor *uint8 // Background color for ANSI output (nil = no background)
-	Frame           bool   // Draw a white ASCII frame around the picture
+	Frame           bool   // Draw a frame around the picture
+	FrameColor      *uint8 // ANSI color of the frame (nil = white)
+	// FrameStyle selects the frame's characters, and FrameTitle is written into
+	// its top border, e.g. the image's file name.
+	FrameStyle FrameStyle
+	FrameTitle string
+	// IgnoreColorEnv leaves color to NoColor alone, ignoring NO_COLOR, FORCE_COLOR,
+	// and CLICOLOR_FORCE, e.g. when rendering for a remote client, whose
+	// environment isn't the process's.
+	IgnoreColorEnv bool
+	// Align indents lines with spaces to center or right-align them within the
+	// terminal's width, or 80 columns if stdout isn't a terminal.
+	Align Alignment
+	// SampleBackground colors each cell's background with the average of its
+	// unlit pixels, so dark regions render as colored blocks instead of
+	// empty black. Takes precedence over BackgroundColor.
+	SampleBackground bool
+	// Hyperlink wraps each output line in an OSC 8 hyperlink escape pointing at this URL,
+	// so supporting terminals let you click through to the original image.
+	// Ignored when NoColor is set.
+	Hyperlink string
+	// SixDot renders with 6-dot braille (2×3 dots per cell, U+2800–U+283F only)
+	// for fonts and terminals where 8-dot patterns render inconsistently.
+	SixDot bool
+	// DitherAlgorithm selects an error-diffusion kernel for brightness quantization,
+	// trading solid regions for patterns that preserve gradients. Default is no dithering.
+	DitherAlgorithm DitherAlgorithm
+	// Serpentine alternates the scan direction on each row during error diffusion,
+	// eliminating the diagonal "worm" artifacts of left-to-right scanning.
+	Serpentine bool
+	// Edges renders only the edges of the image as lit dots, which makes line art,
+	// diagrams, and screenshots more legible. The threshold applies to the
+	// gradient magnitude for EdgeSobel. Takes precedence over dithering.
+	Edges EdgeDetector
+	// CannyLow and CannyHigh are the hysteresis thresholds of the Canny edge detector
+	// (0-255, defaults 24 and 64). Weak edges above CannyLow are kept only if they
+	// connect to strong edges above CannyHigh.
+	CannyLow, CannyHigh uint8
+	// Invert flips brightness quantization so dark pixels become lit dots, for
+	// terminals with light backgrounds where the default renders photos as negatives.
+	// Edge detection is unaffected, since its lit dots already draw dark lines there.
+	Invert bool
+	// AutoLevels stretches the luminance histogram to the full range before conversion,
+	// clipping the darkest and brightest 1% of pixels. It rescues low-contrast images
+	// that otherwise render as nearly all-on or all-off dots.
+	AutoLevels bool
+	// Brightness and Contrast adjust the image before conversion, to fix under- or
+	// over-exposed images. Brightness is added to every channel and Contrast scales
+	// each channel's distance from mid-gray by 1+Contrast. Both range over [-1, 1]; 0 is unchanged.
+	Brightness, Contrast float64
+	// Sharpen applies an unsharp mask of this amount (e.g. 1.0) before conversion,
+	// recovering detail lost in the downscale to 2×4 dots per character. 0 disables it.
+	Sharpen float64
+	// Blur applies a Gaussian blur with this radius (sigma, in dots; e.g. 0.8) before
+	// conversion, suppressing sensor noise that otherwise turns into speckled dots,
+	// especially when dithering. 0 disables it.
+	Blur float64
+	// ThresholdPercentile, if set (0-100, exclusive), overrides Threshold with this
+	// percentile of the image's luminance, e.g. 50 lights the brighter half of the
+	// image, so one value behaves sensibly for both dark and bright photos.
+	// It doesn't apply to edge detection.
+	ThresholdPercentile float64
+	// Despeckle removes isolated single lit or unlit dots from the dot matrix before
+	// it is packed into characters, reducing salt-and-pepper noise in thresholded photos.
+	Despeckle bool
+	// Posterize reduces each channel to this many levels (2 or more) before quantization,
+	// for a deliberate reduced-color look with longer runs of identical colors. 0 disables it.
+	Posterize int
+	// CellAspect is the width/height ratio of a terminal character cell, used to keep
+	// the image's proportions on screen. 0 detects it from the terminal's pixel size,
+	// falling back to square dots (0.5 for 8-dot braille).
+	CellAspect float64
+	// Matte is the color that translucent pixels are composited over before conversion,
+	// usually the terminal's background color. nil is black.
+	Matte color.Color
+	// Transparent renders cells whose pixels are all fully transparent as blank,
+	// uncolored cells, so the terminal background shows through.
+	Transparent bool
+	// Luma selects the weights used to convert colors to brightness. The zero value is Rec.601;
+	// LumaRec709 suits screenshots and video frames.
+	Luma Luma
+	// PaletteMode builds a palette of PaletteSize colors (default 16) from the image,
+	// and quantizes every block to it instead of independently, which keeps related
+	// tones consistent across the image.
+	PaletteMode PaletteMode
+	PaletteSize int
+	// Palette restricts block colors to these entries, each shown as its nearest ANSI 256
+	// color, for CGA- or Game Boy-styled renders or matching a TUI's theme.
+	// It takes precedence over PaletteMode.
+	Palette []color.RGBA
+	// ColorDither diffuses the color quantization error of each cell over its neighbors,
+	// reducing color banding in gradients as dithering does for brightness. It uses
+	// DitherAlgorithm's kernel (Floyd–Steinberg if none) and each block's average color.
+	ColorDither bool
+	// BlockColor selects how each cell's colors are chosen from its pixels: their
+	// average (the default) or the most frequent quantized color.
+	BlockColor BlockColorMode
+	// ColorLitDots colors each cell from only the pixels whose dots are lit, so dark
+	// unlit pixels don't dim the foreground of bright features.
+	ColorLitDots bool
+	// SystemColors are the colors the terminal actually shows for ANSI codes 0-15.
+	// If set, blocks are quantized against them too, instead of avoiding codes
+	// whose colors vary between themes, so the output matches the user's theme.
+	SystemColors *[16]color.RGBA
+	// Grayscale keeps ANSI coloring but restricts it to the 232-255 grayscale ramp,
+	// for muted dashboards and terminals with garish color cubes. It takes precedence
+	// over the palette options.
+	Grayscale bool
+	// Tint maps each block's luminance onto a gradient from Tint[0] (dark) to Tint[1]
+	// (light), for duotone or sepia renders that blend into themed TUIs. It takes
+	// precedence over the palette options.
+	Tint *[2]color.RGBA
+	// Caption is a label drawn in a small bitmap font, centered along the bottom of
+	// the picture as lit dots on a cleared box, e.g. for titles.
+	Caption string
+
+	quantize func(r, g, b uint8) uint8 // resolved by prepare
+}
+
+// frameColor returns the ANSI color of the frame.
+func (o Options) frameColor() uint8 {
+	if o.FrameColor != nil {
+		return *o.FrameColor
+	}
+	return 15 // White
+}
+
+// cellSize returns the number of dots per braille cell horizontally and vertically.
+func (o Options) cellSize() (int, int) {
+	if o.SixDot {
+		return 2, 3
+	}
+	return 2, 4
+}
+
+// quantizer returns the function mapping a block's average color to an ANSI 256 color code.
+func (o Options) quantizer() func(r, g, b uint8) uint8 {
+	if o.quantize != nil {
+		return o.quantize
+	}
+	if o.Grayscale {
+		luma := o.luma()
+		return func(r, g, b uint8) uint8 {
+			return quantizeGray(luma.Y(color.RGBA{r, g, b, 255}))
+		}
+	}
+	if o.Tint != nil {
+		luma, tint := o.luma(), o.Tint
+		quantize := Options{SystemColors: o.SystemColors}.quantizer()
+		return func(r, g, b uint8) uint8 {
+			c := lerpColor(tint[0], tint[1], float64(luma.Y(color.RGBA{r, g, b, 255}))/255)
+			return quantize(c.R, c.G, c.B)
+		}
+	}
+	if o.SystemColors != nil {
+		return systemQuantizer(o.SystemColors)
+	}
+	return quantizeRGB
+}
+
+// codeColor returns the color the terminal shows for an ANSI 256 color code.
+func (o Options) codeColor(code uint8) color.RGBA {
+	if code < 16 && o.SystemColors != nil {
+		return o.SystemColors[code]
+	}
+	return ansiToRGBA(code)
+}
+
+// ansiCode returns the ANSI 256 color code for a cell color, the inverse of codeColor.
+func (o Options) ansiCode(c color.RGBA) uint8 {
+	if o.SystemColors != nil {
+		for i, sc := range o.SystemColors {
+			if sc == c {
+				return uint8(i)
+			}
+		}
+	}
+	return rgbaToANSI(c)
+}
+
+// paletteSize returns the number of colors in an adaptive palette, defaulting to 16.
+func (o Options) paletteSize() int {
+	if o.PaletteSize > 0 {
+		return o.PaletteSize
+	}
+	return 16
+}
+
+// luma returns the luma coefficients, defaulting to Rec.601.
+func (o Options) luma() Luma {
+	if o.Luma == (Luma{}) {
+		return LumaRec601
+	}
+	return o.Luma
+}
+
+// cellAspect returns the width/height ratio of a terminal character cell.
+func (o Options) cellAspect() float64 {
+	if o.CellAspect > 0 {
+		return o.CellAspect
+	}
+	if aspect := terminalCellAspect(); aspect > 0 {
+		return aspect
+	}
+	cellWidth, cellHeight := o.cellSize()
+	return float64(cellWidth) / float64(cellHeight)
 }
 
 // CalculateDimensions calculates output dimensions maintaining aspect ratio.
@@ -41,6 +248,20 @@ type Options struct {
 //
 // The calculation accounts for braille characters being 2 pixels wide × 4 pixels tall.
 func CalculateDimensions(imgWidth, imgHeight, width, height, maxWidth, maxHeight int) (int, int) {
+	return CalculateCellDimensions(imgWidth, imgHeight, width, height, maxWidth, maxHeight, 2, 4)
+}
+
+// CalculateCellDimensions is like CalculateDimensions, for braille characters
+// that are cellWidth pixels wide × cellHeight pixels tall (2×3 for 6-dot braille).
+func CalculateCellDimensions(imgWidth, imgHeight, width, height, maxWidth, maxHeight, cellWidth, cellHeight int) (int, int) {
+	return CalculateAspectDimensions(imgWidth, imgHeight, width, height, maxWidth, maxHeight, float64(cellWidth)/float64(cellHeight))
+}
+
+// CalculateAspectDimensions is like CalculateDimensions, for terminal character
+// cells whose on-screen width/height ratio is cellRatio. CalculateDimensions assumes
+// 0.5, which distorts output on fonts whose glyphs aren't exactly twice as tall as wide.
+func CalculateAspectDimensions(imgWidth, imgHeight, width, height, maxWidth, maxHeight int, cellRatio float64) (int, int) {
+
 	if width > 0 && height > 0 {
 		// Both specified, use as-is
 		return width, height
@@ -48,10 +269,9 @@ func CalculateDimensions(imgWidth, imgHeight, width, height, maxWidth, maxHeight
 
 	if width > 0 && height == 0 {
 		// Only width specified, calculate height to maintain aspect ratio
-		// width chars = width*2 pixels wide
-		// To maintain aspect: height pixels = width*2 * (imgHeight/imgWidth)
-		// height chars = height pixels / 4 = width*2*(imgHeight/imgWidth)/4 = width*imgHeight/imgWidth/2
-		height = int(float64(width) * float64(imgHeight) / float64(imgWidth) / 2.0)
+		// width chars are width*cellRatio units wide on screen, and each row is 1 unit tall
+		// To maintain aspect: height chars = width*cellRatio * (imgHeight/imgWidth)
+		height = int(float64(width) * float64(imgHeight) / float64(imgWidth) * cellRatio)
 		if height == 0 {
 			height = 1
 		}
@@ -60,10 +280,9 @@ func CalculateDimensions(imgWidth, imgHeight, width, height, maxWidth, maxHeight
 
 	if height > 0 && width == 0 {
 		// Only height specified, calculate width to maintain aspect ratio
-		// height chars = height*4 pixels tall
-		// To maintain aspect: width pixels = height*4 * (imgWidth/imgHeight)
-		// width chars = width pixels / 2 = height*4*(imgWidth/imgHeight)/2 = height*imgWidth/imgHeight*2
-		width = int(float64(height) * float64(imgWidth) / float64(imgHeight) * 2.0)
+		// height chars are height units tall on screen, and each column is cellRatio units wide
+		// To maintain aspect: width chars = height * (imgWidth/imgHeight) / cellRatio
+		width = int(float64(height) * float64(imgWidth) / float64(imgHeight) / cellRatio)
 		if width == 0 {
 			width = 1
 		}
@@ -74,11 +293,11 @@ func CalculateDimensions(imgWidth, imgHeight, width, height, maxWidth, maxHeight
 	if maxWidth > 0 && maxHeight > 0 {
 		// Calculate what dimensions would be if we used maxWidth
 		widthConstrained := maxWidth
-		heightForWidth := int(float64(widthConstrained) * float64(imgHeight) / float64(imgWidth) / 2.0)
+		heightForWidth := int(float64(widthConstrained) * float64(imgHeight) / float64(imgWidth) * cellRatio)
 
 		// Calculate what dimensions would be if we used maxHeight
 		heightConstrained := maxHeight
-		widthForHeight := int(float64(heightConstrained) * float64(imgWidth) / float64(imgHeight) * 2.0)
+		widthForHeight := int(float64(heightConstrained) * float64(imgWidth) / float64(imgHeight) / cellRatio)
 
 		// Use whichever fits within both constraints
 		if heightForWidth <= maxHeight {
@@ -96,13 +315,205 @@ func CalculateDimensions(imgWidth, imgHeight, width, height, maxWidth, maxHeight
 // Convert converts an image to braille representation.
 // Returns a slice of strings, one per line of output.
 func Convert(img image.Image, opts Options) []string {
+	return ConvertGrid(img, opts).Render(opts)
+}
+
+// ConvertToDots converts an image to its raw dot matrix, before the dots are packed
+// into braille characters. The matrix is indexed as dots[y][x], with 2 dots per
+// character horizontally and 4 vertically (3 for 6-dot braille).
+func ConvertToDots(img image.Image, opts Options) [][]bool {
+	resized, _, opts := prepare(img, opts)
+	return dotMatrix(resized, opts)
+}
+
+// dotMatrix performs brightness quantization of every pixel of the resized image.
+func dotMatrix(resized *image.RGBA, opts Options) [][]bool {
+	var dots [][]bool
+	switch opts.Edges {
+	case EdgeSobel:
+		dots = sobelEdges(luminanceMatrix(resized, opts.luma()), opts.Threshold)
+	case EdgeCanny:
+		dots = cannyEdges(luminanceMatrix(resized, opts.luma()), opts.CannyLow, opts.CannyHigh)
+	default:
+		dots = brightnessDots(resized, opts)
+		if opts.Invert {
+			for y := range dots {
+				for x := range dots[y] {
+					dots[y][x] = !dots[y][x]
+				}
+			}
+		}
+	}
+
+	if opts.Despeckle {
+		dots = despeckle(dots)
+	}
+	if opts.Caption != "" {
+		text, box := captionLayout(resized.Bounds().Dx(), resized.Bounds().Dy(), opts.Caption)
+		for y := box.Min.Y; y < box.Max.Y; y++ {
+			for x := box.Min.X; x < box.Max.X; x++ {
+				dots[y][x] = text.Get(x, y)
+			}
+		}
+	}
+	return dots
+}
+
+// despeckle removes salt-and-pepper noise from a dot matrix: lit dots with no lit
+// neighbors are turned off, and unlit dots with no unlit neighbors are turned on.
+func despeckle(dots [][]bool) [][]bool {
+	out := make([][]bool, len(dots))
+	for y := range dots {
+		out[y] = make([]bool, len(dots[y]))
+		for x, on := range dots[y] {
+			out[y][x] = on
+
+			// Look for a neighbor, among the 8 surrounding dots, in the same state
+			isolated, neighbors := true, 0
+			for dy := -1; dy <= 1 && isolated; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					ny, nx := y+dy, x+dx
+					if (dx == 0 && dy == 0) || ny < 0 || ny >= len(dots) || nx < 0 || nx >= len(dots[ny]) {
+						continue
+					}
+					neighbors++
+					if dots[ny][nx] == on {
+						isolated = false
+						break
+					}
+				}
+			}
+			if isolated && neighbors > 0 {
+				out[y][x] = !on
+			}
+		}
+	}
+	return out
+}
+
+// brightnessDots lights the bright pixels of the resized image, by thresholding or dithering.
+func brightnessDots(resized *image.RGBA, opts Options) [][]bool {
+	// Step 2: Brightness quantization - one dot per pixel
+	if opts.DitherAlgorithm == DitherBlueNoise {
+		return applyBlueNoise(luminanceMatrix(resized, opts.luma()), opts.Threshold)
+	}
+	if kernel, ok := ditherKernels[opts.DitherAlgorithm]; ok {
+		return applyDithering(luminanceMatrix(resized, opts.luma()), opts.Threshold, kernel, opts.Serpentine)
+	}
+
+	luma := opts.luma()
+	bounds := resized.Bounds()
+	dots := make([][]bool, bounds.Dy())
+	for y := range dots {
+		dots[y] = make([]bool, bounds.Dx())
+		for x := range dots[y] {
+			// Apply threshold: bright pixels turn on dots
+			dots[y][x] = luma.Y(resized.At(bounds.Min.X+x, bounds.Min.Y+y)) > opts.Threshold
+		}
+	}
+	return dots
+}
+
+// luminanceMatrix returns the luminance of every pixel of an image, indexed [y][x].
+func luminanceMatrix(img *image.RGBA, luma Luma) [][]float64 {
+	bounds := img.Bounds()
+	lum := make([][]float64, bounds.Dy())
+	for y := range lum {
+		lum[y] = make([]float64, bounds.Dx())
+		for x := range lum[y] {
+			lum[y][x] = float64(luma.Y(img.At(bounds.Min.X+x, bounds.Min.Y+y)))
+		}
+	}
+	return lum
+}
+
+// ConvertFunc converts an image to braille representation, calling fn with each line
+// of output as soon as it is computed rather than buffering the whole result.
+// Rows are numbered from 0 and include the frame borders if opts.Frame is set.
+// If fn returns an error, conversion stops and the error is returned.
+func ConvertFunc(img image.Image, opts Options, fn func(row int, line string) error) error {
+	resized, mask, opts := prepare(img, opts)
+	dots := dotMatrix(resized, opts)
+	dither := newCellDither(opts)
+
+	var top, left, right, bottom string
+	width := opts.Width
+	if opts.Frame {
+		top, left, right, bottom = frameParts(opts.Width, opts)
+		width += 2
+	}
+	padding := alignPadding(width, opts)
+
+	row := 0
+	emit := func(line string) error {
+		if opts.Hyperlink != "" && !opts.NoColor {
+			line = hyperlink(opts.Hyperlink, line)
+		}
+		err := fn(row, padding+line)
+		row++
+		return err
+	}
+
+	if opts.Frame {
+		if err := emit(top); err != nil {
+			return err
+		}
+	}
+	for y := 0; y < opts.Height; y++ {
+		if err := emit(left + renderRow(convertRow(resized, mask, dots, dither, y, opts), opts) + right); err != nil {
+			return err
+		}
+	}
+	if opts.Frame {
+		return emit(bottom)
+	}
+	return nil
+}
+
+// OutputSize returns the size in characters, inside any frame, that Convert draws
+// img at, with zero dimensions calculated from the image's aspect ratio and the
+// terminal size.
+func (o Options) OutputSize(img image.Image) (width, height int) {
+	o = resolveOptions(img, o)
+	return o.Width, o.Height
+}
+
+// EffectiveThreshold returns the brightness threshold Convert uses: Threshold, or
+// if it's 0, the default suited to the dithering, inversion, and edge options.
+// It doesn't account for ThresholdPercentile, which depends on the image.
+func (o Options) EffectiveThreshold() uint8 {
+	if o.Threshold != 0 {
+		return o.Threshold
+	}
+	if o.Edges != EdgeNone {
+		// Ignore the faint gradients of noise and soft shading
+		return 48
+	}
+	threshold := uint8(20)
+	if o.DitherAlgorithm != DitherNone {
+		// Error diffusion works best around mid-gray
+		threshold = 128
+	}
+	if o.Invert {
+		// Mirror the default so only the brightest pixels stay unlit
+		threshold = 255 - threshold
+	}
+	return threshold
+}
+
+// resolveOptions fills in defaults and calculates output dimensions for an image.
+func resolveOptions(img image.Image, opts Options) Options {
 	// Set defaults
-	if opts.Threshold == 0 {
-		opts.Threshold = 20
+	opts.Threshold = opts.EffectiveThreshold()
+	if opts.CannyHigh == 0 {
+		opts.CannyHigh = 64
+	}
+	if opts.CannyLow == 0 {
+		opts.CannyLow = min(24, opts.CannyHigh)
 	}
 
-	// Respect NO_COLOR environment variable
-	if os.Getenv("NO_COLOR") != "" {
+	// Respect the NO_COLOR environment variable, unless color is forced
+	if envNoColor(opts) {
 		opts.NoColor = true
 	}
 
@@ -125,7 +536,7 @@ func Convert(img image.Image, opts Options) []string {
 		// - Both zero: uses terminal as constraint with aspect ratio
 		// - Only width: calculates height from aspect
 		// - Only height: calculates width from aspect
-		opts.Width, opts.Height = CalculateDimensions(imgWidth, imgHeight, opts.Width, opts.Height, termWidth, termHeight)
+		opts.Width, opts.Height = CalculateAspectDimensions(imgWidth, imgHeight, opts.Width, opts.Height, termWidth, termHeight, opts.cellAspect())
 	} else if opts.Frame {
 		// If dimensions were explicitly specified, reduce them for the frame
 		opts.Width -= 2
@@ -138,53 +549,28 @@ func Convert(img image.Image, opts Options) []string {
 		}
 	}
 
-	// Step 1: Spatial quantization - resize to target dimensions
-	// Each braille char is 2 pixels wide × 4 pixels tall
-	targetWidth := opts.Width * 2
-	targetHeight := opts.Height * 4
-	resized := resize(img, targetWidth, targetHeight)
-
-	// Step 2 & 3: Brightness and color quantization
-	brailleLines := make([]string, opts.Height)
-
-	for row := 0; row < opts.Height; row++ {
-		line := ""
-		for col := 0; col < opts.Width; col++ {
-			// Extract 2×4 pixel block
-			x0, y0 := col*2, row*4
-			block := extractBlock(resized, x0, y0)
-
-			// Brightness quantization: convert to braille character
-			char := blockToBraille(block, opts.Threshold)
-
-			// Color quantization: get ANSI color codes
-			if !opts.NoColor {
-				fgColor := blockToANSI(block)
-				if opts.BackgroundColor != nil {
-					line += ansiFgBgColor(fgColor, *opts.BackgroundColor) + string(char) + ansiReset()
-				} else {
-					line += ansiFgColor(fgColor) + string(char) + ansiReset()
-				}
-			} else {
-				line += string(char)
-			}
-		}
-		brailleLines[row] = line
-	}
-
-	// Add frame if requested
-	if opts.Frame {
-		return addFrame(brailleLines, opts.NoColor)
-	}
-
-	return brailleLines
+	return opts
 }
 
 // resize scales an image to the target dimensions using high-quality interpolation.
+// Scaling happens in linear light: interpolating gamma-encoded sRGB values darkens
+// fine detail and skews the average colors of the blocks.
 func resize(img image.Image, width, height int) *image.RGBA {
-	dst := image.NewRGBA(image.Rect(0, 0, width, height))
-	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
-	return dst
+	linear := toLinear(img)
+	scaled := image.NewRGBA64(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(scaled, scaled.Bounds(), linear, linear.Bounds(), draw.Src, nil)
+	return toSRGB(scaled)
+}
+
+// dotOffsets holds the (x, y) offset within a cell of each braille pattern bit.
+// Standard braille dot numbering:
+// 0 3    (pixels at x0, x0+1)
+// 1 4    (rows y0, y0+1, y0+2, y0+3)
+// 2 5
+// 6 7
+var dotOffsets = [8][2]int{
+	{0, 0}, {0, 1}, {0, 2}, {1, 0},
+	{1, 1}, {1, 2}, {0, 3}, {1, 3},
 }
 
 // extractBlock extracts a 2×4 pixel block from an image at the given position.
@@ -192,19 +578,8 @@ func extractBlock(img *image.RGBA, x0, y0 int) [8]color.Color {
 	var block [8]color.Color
 	bounds := img.Bounds()
 
-	// Standard braille dot numbering:
-	// 0 3    (pixels at x0, x0+1)
-	// 1 4    (rows y0, y0+1, y0+2, y0+3)
-	// 2 5
-	// 6 7
-
-	positions := [][2]int{
-		{x0, y0}, {x0, y0 + 1}, {x0, y0 + 2}, {x0 + 1, y0},
-		{x0, y0 + 3}, {x0 + 1, y0 + 1}, {x0 + 1, y0 + 2}, {x0 + 1, y0 + 3},
-	}
-
-	for i, pos := range positions {
-		x, y := pos[0], pos[1]
+	for i, off := range dotOffsets {
+		x, y := x0+off[0], y0+off[1]
 		if x < bounds.Max.X && y < bounds.Max.Y {
 			block[i] = img.At(x, y)
 		} else {
@@ -218,17 +593,19 @@ func extractBlock(img *image.RGBA, x0, y0 int) [8]color.Color {
 // blockToBraille converts a 2×4 pixel block to a braille character.
 // Each pixel's brightness is compared to the threshold to determine if the dot is on.
 func blockToBraille(block [8]color.Color, threshold uint8) rune {
-	var pattern uint8
-
+	var lit [8]bool
 	for i, c := range block {
-		// Convert to grayscale using perceived luminance
-		r, g, b, _ := c.RGBA()
-		// RGBA() returns values in [0, 65535], convert to [0, 255]
-		r8, g8, b8 := uint8(r>>8), uint8(g>>8), uint8(b>>8)
-		luminance := uint8(0.299*float64(r8) + 0.587*float64(g8) + 0.114*float64(b8))
-
 		// Apply threshold: bright pixels turn on dots
-		if luminance > threshold {
+		lit[i] = luminance(c) > threshold
+	}
+	return packBraille(lit)
+}
+
+// packBraille converts the lit state of a cell's dots, in braille dot order, to a braille character.
+func packBraille(lit [8]bool) rune {
+	var pattern uint8
+	for i, on := range lit {
+		if on {
 			pattern |= (1 << i)
 		}
 	}
@@ -237,9 +614,31 @@ func blockToBraille(block [8]color.Color, threshold uint8) rune {
 	return rune(0x2800 + int(pattern))
 }
 
-// blockToANSI determines the dominant color of a block and returns the nearest ANSI 256 color code.
-func blockToANSI(block [8]color.Color) uint8 {
-	// Calculate average color of the block
+// cellDots gathers the lit state of the dots of the cell whose top-left dot is at (x0, y0).
+// Dots outside the matrix are unlit.
+func cellDots(dots [][]bool, x0, y0 int) [8]bool {
+	var lit [8]bool
+	for i, off := range dotOffsets {
+		x, y := x0+off[0], y0+off[1]
+		if y < len(dots) && x < len(dots[y]) {
+			lit[i] = dots[y][x]
+		}
+	}
+	return lit
+}
+
+// luminance converts a color to grayscale using perceived (Rec.601) luminance.
+func luminance(c color.Color) uint8 {
+	return LumaRec601.Y(c)
+}
+
+// blockToANSI determines the dominant color of a block's pixels and returns the nearest ANSI 256 color code.
+func blockToANSI(block []color.Color) uint8 {
+	return quantizeRGB(averageColor(block))
+}
+
+// averageColor returns the average 8-bit RGB color of a block's pixels.
+func averageColor(block []color.Color) (uint8, uint8, uint8) {
 	var rSum, gSum, bSum uint32
 	for _, c := range block {
 		r, g, b, _ := c.RGBA()
@@ -249,11 +648,67 @@ func blockToANSI(block [8]color.Color) uint8 {
 	}
 
 	// Average and convert to 8-bit
-	r := uint8((rSum / 8) >> 8)
-	g := uint8((gSum / 8) >> 8)
-	b := uint8((bSum / 8) >> 8)
+	n := uint32(len(block))
+	r := uint8((rSum / n) >> 8)
+	g := uint8((gSum / n) >> 8)
+	b := uint8((bSum / n) >> 8)
+	return r, g, b
+}
+
+// blockToBackgroundANSI averages the pixels of a block whose dots are off
+// and returns the nearest ANSI 256 color code.
+// If every dot in the block is lit, the whole block is averaged instead.
+func blockToBackgroundANSI(block []color.Color, lit []bool) uint8 {
+	return blockToANSI(backgroundPixels(block, lit))
+}
+
+// foregroundPixels returns the pixels of a block whose dots are on,
+// or the whole block if no dot is lit.
+func foregroundPixels(block []color.Color, lit []bool) []color.Color {
+	var on []color.Color
+	for i, c := range block {
+		if lit[i] {
+			on = append(on, c)
+		}
+	}
+	if len(on) == 0 {
+		return block
+	}
+	return on
+}
+
+// backgroundPixels returns the pixels of a block whose dots are off,
+// or the whole block if every dot is lit.
+func backgroundPixels(block []color.Color, lit []bool) []color.Color {
+	var unlit []color.Color
+	for i, c := range block {
+		if !lit[i] {
+			unlit = append(unlit, c)
+		}
+	}
+	if len(unlit) == 0 {
+		return block
+	}
+	return unlit
+}
+
+// envNoColor reports whether the process's environment disables color, as
+// EnvNoColor, unless opts ignore it.
+func envNoColor(opts Options) bool {
+	return !opts.IgnoreColorEnv && EnvNoColor(os.Getenv)
+}
 
-	return quantizeRGB(r, g, b)
+// EnvNoColor reports whether an environment, whose variables getenv returns,
+// disables color: NO_COLOR is set, and neither FORCE_COLOR nor CLICOLOR_FORCE
+// forces it back on, e.g. for output piped into less -R or a CI log viewer.
+// Options.NoColor overrides them all.
+func EnvNoColor(getenv func(string) string) bool {
+	for _, env := range []string{"FORCE_COLOR", "CLICOLOR_FORCE"} {
+		if v := getenv(env); v != "" && v != "0" && v != "false" {
+			return false
+		}
+	}
+	return getenv("NO_COLOR") != ""
 }
 
 // ansiFgColor returns the ANSI escape sequence to set foreground color.
@@ -271,63 +726,44 @@ func ansiReset() string {
 	return "\x1b[0m"
 }
 
-// addFrame wraps the braille lines with a white ASCII frame.
-func addFrame(lines []string, noColor bool) []string {
-	if len(lines) == 0 {
-		return lines
-	}
-
-	// Calculate the width of the content (without ANSI codes if present)
-	width := 0
-	if !noColor {
-		// Count visible characters by stripping ANSI codes
-		width = visibleWidth(lines[0])
-	} else {
-		width = len(lines[0])
-	}
-
-	// White color code (for frame)
-	whiteColor := "\x1b[38;5;15m"
-	reset := ""
-	if !noColor {
-		reset = ansiReset()
-	} else {
-		whiteColor = ""
-	}
-
-	// Build the frame
-	result := make([]string, len(lines)+2)
-
-	// Top border
-	result[0] = whiteColor + "┌" + repeatString("─", width) + "┐" + reset
-
-	// Content with side borders
-	for i, line := range lines {
-		result[i+1] = whiteColor + "│" + reset + line + whiteColor + "│" + reset
-	}
-
-	// Bottom border
-	result[len(result)-1] = whiteColor + "└" + repeatString("─", width) + "┘" + reset
-
-	return result
+// hyperlink wraps text in an OSC 8 hyperlink escape sequence pointing at url.
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
 }
 
 // visibleWidth counts the visible characters in a string, ignoring ANSI escape codes.
+// Both CSI sequences (ESC [ ... m) and OSC sequences (ESC ] ... ESC \) are skipped.
 func visibleWidth(s string) int {
-	width := 0
-	inEscape := false
+	return utf8.RuneCountInString(stripANSI(s))
+}
+
+// stripANSI removes ANSI escape codes from a string: CSI sequences (ESC [ ... m)
+// and OSC sequences (ESC ] ... ESC \).
+func stripANSI(s string) string {
+	var sb strings.Builder
+	inEscape, inOSC := false, false
+	prev := rune(0)
 	for _, r := range s {
-		if r == '\x1b' {
-			inEscape = true
-		} else if inEscape {
-			if r == 'm' {
+		switch {
+		case inOSC:
+			// OSC sequences end with the string terminator ESC \ (or BEL)
+			if (prev == '\x1b' && r == '\\') || r == '\a' {
+				inOSC = false
+			}
+		case inEscape:
+			if r == ']' && prev == '\x1b' {
+				inEscape, inOSC = false, true
+			} else if r == 'm' {
 				inEscape = false
 			}
-		} else {
-			width++
+		case r == '\x1b':
+			inEscape = true
+		default:
+			sb.WriteRune(r)
 		}
+		prev = r
 	}
-	return width
+	return sb.String()
 }
 
 // repeatString repeats a string n times.
@@ -1,9 +1,11 @@
 package dots
 
 import (
+	"errors"
 	"image"
 	"image/color"
 	"image/png"
+	"math"
 	"os"
 	"testing"
 )
@@ -208,3 +210,281 @@ func TestResize(t *testing.T) {
 		t.Errorf("resized height = %d, want 10", resized.Bounds().Dy())
 	}
 }
+
+func TestConvertFunc(t *testing.T) {
+	f, err := os.Open("testdata/gradient.png")
+	if err != nil {
+		t.Fatalf("failed to open test image: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode test image: %v", err)
+	}
+
+	for _, tt := range []struct {
+		desc string
+		opts Options
+	}{
+		{desc: "color", opts: Options{Width: 8, Height: 4}},
+		{desc: "no color", opts: Options{Width: 8, Height: 4, NoColor: true}},
+		{desc: "frame", opts: Options{Width: 8, Height: 4, Frame: true}},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			want := Convert(img, tt.opts)
+
+			var got []string
+			err := ConvertFunc(img, tt.opts, func(row int, line string) error {
+				if row != len(got) {
+					t.Errorf("got row %d, want %d", row, len(got))
+				}
+				got = append(got, line)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("ConvertFunc() error: %v", err)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("got %d lines, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("line %d: got %q, want %q", i, got[i], want[i])
+				}
+			}
+		})
+	}
+
+	t.Run("stops on error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		calls := 0
+		err := ConvertFunc(img, Options{Width: 8, Height: 4}, func(int, string) error {
+			calls++
+			return errStop
+		})
+		if !errors.Is(err, errStop) {
+			t.Errorf("ConvertFunc() error = %v, want %v", err, errStop)
+		}
+		if calls != 1 {
+			t.Errorf("fn called %d times, want 1", calls)
+		}
+	})
+}
+
+func TestExtractBlockDotOrder(t *testing.T) {
+	// Lighting a single pixel must light the matching Unicode braille dot
+	for _, tt := range []struct {
+		x, y int
+		want rune
+	}{
+		{0, 0, '⠁'}, {0, 1, '⠂'}, {0, 2, '⠄'}, {1, 0, '⠈'},
+		{1, 1, '⠐'}, {1, 2, '⠠'}, {0, 3, '⡀'}, {1, 3, '⢀'},
+	} {
+		img := image.NewRGBA(image.Rect(0, 0, 2, 4))
+		img.Set(tt.x, tt.y, color.White)
+		if got := blockToBraille(extractBlock(img, 0, 0), 128); got != tt.want {
+			t.Errorf("pixel (%d, %d): got %c, want %c", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestSixDot(t *testing.T) {
+	f, err := os.Open("testdata/checkerboard.png")
+	if err != nil {
+		t.Fatalf("failed to open test image: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode test image: %v", err)
+	}
+
+	lines := Convert(img, Options{Width: 8, SixDot: true, NoColor: true})
+	// Square image, 8 wide: 8 * 2/3 = 5 rows
+	if len(lines) != 5 {
+		t.Errorf("got %d lines, want 5", len(lines))
+	}
+	lit := false
+	for i, line := range lines {
+		for _, r := range line {
+			if r < 0x2800 || r > 0x283f {
+				t.Errorf("line %d: %U is outside the 6-dot braille range", i, r)
+			}
+			if r != 0x2800 {
+				lit = true
+			}
+		}
+	}
+	if !lit {
+		t.Error("expected some lit dots")
+	}
+}
+
+func TestConvertToDots(t *testing.T) {
+	f, err := os.Open("testdata/checkerboard.png")
+	if err != nil {
+		t.Fatalf("failed to open test image: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode test image: %v", err)
+	}
+
+	opts := Options{Width: 4, Height: 4, Threshold: 128, NoColor: true}
+	dots := ConvertToDots(img, opts)
+	if len(dots) != 16 {
+		t.Fatalf("got %d rows of dots, want 16", len(dots))
+	}
+	for y, row := range dots {
+		if len(row) != 8 {
+			t.Errorf("row %d: got %d dots, want 8", y, len(row))
+		}
+	}
+
+	// Packing the matrix by hand must reproduce Convert's output
+	lines := Convert(img, opts)
+	for row, line := range lines {
+		for col, r := range []rune(line) {
+			if got := packBraille(cellDots(dots, col*2, row*4)); got != r {
+				t.Errorf("cell (%d, %d): packed %c, Convert produced %c", row, col, got, r)
+			}
+		}
+	}
+}
+
+func TestInvert(t *testing.T) {
+	for _, tt := range []struct {
+		imgPath string
+		want    rune
+	}{
+		{imgPath: "testdata/white.png", want: '⠀'},
+		{imgPath: "testdata/black.png", want: '⣿'},
+	} {
+		t.Run(tt.imgPath, func(t *testing.T) {
+			f, err := os.Open(tt.imgPath)
+			if err != nil {
+				t.Fatalf("failed to open test image: %v", err)
+			}
+			defer func() { _ = f.Close() }()
+			img, err := png.Decode(f)
+			if err != nil {
+				t.Fatalf("failed to decode test image: %v", err)
+			}
+
+			for i, line := range Convert(img, Options{Width: 4, Height: 2, NoColor: true, Invert: true}) {
+				for _, r := range line {
+					if r != tt.want {
+						t.Errorf("line %d: got %c, want %c", i, r, tt.want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDespeckle(t *testing.T) {
+	parse := func(rows ...string) [][]bool {
+		dots := make([][]bool, len(rows))
+		for y, row := range rows {
+			for _, c := range row {
+				dots[y] = append(dots[y], c == '#')
+			}
+		}
+		return dots
+	}
+
+	for _, tt := range []struct {
+		desc string
+		in   [][]bool
+		want [][]bool
+	}{
+		{
+			desc: "isolated lit dot is removed",
+			in:   parse("....", ".#..", "...."),
+			want: parse("....", "....", "...."),
+		},
+		{
+			desc: "isolated unlit dot is filled",
+			in:   parse("####", "##.#", "####"),
+			want: parse("####", "####", "####"),
+		},
+		{
+			desc: "diagonal line is kept",
+			in:   parse("#...", ".#..", "..#."),
+			want: parse("#...", ".#..", "..#."),
+		},
+		{
+			desc: "isolated corner dot is removed",
+			in:   parse("#...", "....", "...."),
+			want: parse("....", "....", "...."),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := despeckle(tt.in)
+			for y := range tt.want {
+				for x := range tt.want[y] {
+					if got[y][x] != tt.want[y][x] {
+						t.Errorf("dot (%d, %d) = %t, want %t", x, y, got[y][x], tt.want[y][x])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestThresholdPercentile(t *testing.T) {
+	// A horizontal gradient from black to white
+	img := image.NewRGBA(image.Rect(0, 0, 256, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 256; x++ {
+			img.SetRGBA(x, y, color.RGBA{uint8(x), uint8(x), uint8(x), 255})
+		}
+	}
+
+	for _, tt := range []struct {
+		desc       string
+		percentile float64
+		wantLit    float64
+	}{
+		{desc: "p50 lights the brighter half", percentile: 50, wantLit: 0.5},
+		{desc: "p90 lights the brightest tenth", percentile: 90, wantLit: 0.1},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			dots := ConvertToDots(img, Options{Width: 32, Height: 1, ThresholdPercentile: tt.percentile})
+			lit, total := 0, 0
+			for _, row := range dots {
+				for _, on := range row {
+					total++
+					if on {
+						lit++
+					}
+				}
+			}
+			if got := float64(lit) / float64(total); math.Abs(got-tt.wantLit) > 0.05 {
+				t.Errorf("lit fraction = %.2f, want %.2f", got, tt.wantLit)
+			}
+		})
+	}
+}
+
+func TestEffectiveThreshold(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		opts Options
+		want uint8
+	}{
+		{desc: "default", opts: Options{}, want: 20},
+		{desc: "explicit", opts: Options{Threshold: 90, DitherAlgorithm: DitherSierra}, want: 90},
+		{desc: "dithering", opts: Options{DitherAlgorithm: DitherFloydSteinberg}, want: 128},
+		{desc: "inverted", opts: Options{Invert: true}, want: 235},
+		{desc: "inverted dithering", opts: Options{Invert: true, DitherAlgorithm: DitherJJN}, want: 127},
+		{desc: "edges", opts: Options{Edges: EdgeSobel, Invert: true}, want: 48},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := tt.opts.EffectiveThreshold(); got != tt.want {
+				t.Errorf("EffectiveThreshold() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
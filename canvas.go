@@ -0,0 +1,484 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Canvas is a drawing surface of braille dots for plots, diagrams, and other vector
+// graphics. Coordinates are in dots, with (0, 0) at the top left; each character cell
+// holds 2×4 dots. Drawing outside the canvas is ignored.
+type Canvas struct {
+	width, height int
+	dots          [][]bool // Lit dots, indexed [y][x]
+
+	// ColorResolution decides the color of a cell whose dots have different colors.
+	ColorResolution ColorResolution
+
+	pen    color.RGBA     // Color of the dots lit by drawing, unless its alpha is zero
+	colors [][]color.RGBA // Colors of the dots, indexed [y][x]; nil until one is colored
+	last   [][]color.RGBA // Color last lit in each cell, indexed [row][col]
+}
+
+// NewCanvas creates a blank canvas of width×height dots.
+func NewCanvas(width, height int) *Canvas {
+	dots := make([][]bool, height)
+	for y := range dots {
+		dots[y] = make([]bool, width)
+	}
+	return &Canvas{width: width, height: height, dots: dots}
+}
+
+// Bounds returns the canvas's extent in dots.
+func (c *Canvas) Bounds() image.Rectangle {
+	return image.Rect(0, 0, c.width, c.height)
+}
+
+// Set lights the dot at (x, y), in the pen's color if there is one.
+func (c *Canvas) Set(x, y int) {
+	if c.inBounds(x, y) {
+		c.dots[y][x] = true
+		c.color(x, y, c.pen)
+	}
+}
+
+// Unset clears the dot at (x, y).
+func (c *Canvas) Unset(x, y int) {
+	if c.inBounds(x, y) {
+		c.dots[y][x] = false
+		c.color(x, y, color.RGBA{})
+	}
+}
+
+// Get reports whether the dot at (x, y) is lit.
+func (c *Canvas) Get(x, y int) bool {
+	return c.inBounds(x, y) && c.dots[y][x]
+}
+
+// Clear clears every dot.
+func (c *Canvas) Clear() {
+	for _, row := range c.dots {
+		clear(row)
+	}
+	c.colors, c.last = nil, nil
+}
+
+// inBounds reports whether (x, y) is on the canvas.
+func (c *Canvas) inBounds(x, y int) bool {
+	return x >= 0 && y >= 0 && x < c.width && y < c.height
+}
+
+// Grid packs the canvas's dots into braille cells, colored as resolved from the
+// colors of their dots.
+func (c *Canvas) Grid() Grid {
+	grid := make(Grid, (c.height+3)/4)
+	for row := range grid {
+		grid[row] = make([]Cell, (c.width+1)/2)
+		for col := range grid[row] {
+			char := packBraille(cellDots(c.dots, col*2, row*4))
+			grid[row][col] = Cell{Rune: char, Pattern: uint8(char - 0x2800), Fg: c.cellColor(col, row)}
+		}
+	}
+	return grid
+}
+
+// String returns the canvas as lines of braille characters.
+func (c *Canvas) String() string {
+	return strings.Join(c.Grid().Render(Options{NoColor: true}), "\n")
+}
+
+// Line draws a line from (x0, y0) to (x1, y1), including both ends, with
+// Bresenham's algorithm.
+func (c *Canvas) Line(x0, y0, x1, y1 int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	e := dx + dy
+	for {
+		c.Set(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// LineAA draws an anti-aliased line between points with fractional coordinates,
+// where (0.5, 0.5) is the center of the top-left dot. Along the line, each of the two
+// dots straddling it is lit if the line covers at least a third of it, so diagonal
+// lines get smooth, evenly thick steps instead of Bresenham's jagged ones.
+func (c *Canvas) LineAA(x0, y0, x1, y1 float64) {
+	steep := math.Abs(y1-y0) > math.Abs(x1-x0)
+	if steep {
+		x0, y0, x1, y1 = y0, x0, y1, x1
+	}
+	if x0 > x1 {
+		x0, y0, x1, y1 = x1, y1, x0, y0
+	}
+	set := func(x, y int) {
+		if steep {
+			x, y = y, x
+		}
+		c.Set(x, y)
+	}
+
+	gradient := 0.0
+	if x1 != x0 {
+		gradient = (y1 - y0) / (x1 - x0)
+	}
+	const minCoverage = 1.0 / 3
+	for x := int(math.Floor(x0)); x <= int(math.Floor(x1)); x++ {
+		// The line's center at the middle of this column, relative to dot centers
+		y := y0 + gradient*(float64(x)+0.5-x0) - 0.5
+		base := math.Floor(y)
+		frac := y - base
+		if 1-frac >= minCoverage {
+			set(x, int(base))
+		}
+		if frac >= minCoverage {
+			set(x, int(base)+1)
+		}
+	}
+}
+
+// Rect draws the outline of the rectangle with corners (x0, y0) and (x1, y1),
+// including both.
+func (c *Canvas) Rect(x0, y0, x1, y1 int) {
+	c.Polygon(image.Pt(x0, y0), image.Pt(x1, y0), image.Pt(x1, y1), image.Pt(x0, y1))
+}
+
+// FillRect draws a solid rectangle with corners (x0, y0) and (x1, y1), including
+// both, e.g. for the bars of a bar chart.
+func (c *Canvas) FillRect(x0, y0, x1, y1 int) {
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	if y0 > y1 {
+		y0, y1 = y1, y0
+	}
+	for y := max(y0, 0); y <= min(y1, c.height-1); y++ {
+		for x := max(x0, 0); x <= min(x1, c.width-1); x++ {
+			c.Set(x, y)
+		}
+	}
+}
+
+// Polygon draws the outline of the closed polygon through pts, joining the last
+// point back to the first.
+func (c *Canvas) Polygon(pts ...image.Point) {
+	for i, p := range pts {
+		q := pts[(i+1)%len(pts)]
+		c.Line(p.X, p.Y, q.X, q.Y)
+	}
+}
+
+// FillPolygon draws the solid closed polygon through pts with a scanline fill, using
+// the even-odd rule for self-intersecting outlines. Dots on the outline are lit too,
+// so the fill covers exactly what Polygon would outline.
+func (c *Canvas) FillPolygon(pts ...image.Point) {
+	if len(pts) == 0 {
+		return
+	}
+	minY, maxY := pts[0].Y, pts[0].Y
+	for _, p := range pts {
+		minY, maxY = min(minY, p.Y), max(maxY, p.Y)
+	}
+
+	var xs []float64
+	for y := max(minY, 0); y <= min(maxY, c.height-1); y++ {
+		// Find where the scanline crosses each edge, counting each edge's lower
+		// end but not its upper so shared vertices aren't counted twice
+		xs = xs[:0]
+		for i, p := range pts {
+			q := pts[(i+1)%len(pts)]
+			if (p.Y <= y && y < q.Y) || (q.Y <= y && y < p.Y) {
+				t := float64(y-p.Y) / float64(q.Y-p.Y)
+				xs = append(xs, float64(p.X)+t*float64(q.X-p.X))
+			}
+		}
+		slices.Sort(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			for x := int(math.Ceil(xs[i])); x <= int(math.Floor(xs[i+1])); x++ {
+				c.Set(x, y)
+			}
+		}
+	}
+	c.Polygon(pts...)
+}
+
+// Polyline draws connected line segments through pts, without closing the path.
+func (c *Canvas) Polyline(pts ...image.Point) {
+	if len(pts) == 1 {
+		c.Set(pts[0].X, pts[0].Y)
+	}
+	for i := 1; i < len(pts); i++ {
+		c.Line(pts[i-1].X, pts[i-1].Y, pts[i].X, pts[i].Y)
+	}
+}
+
+// QuadBezier draws the quadratic Bézier curve from p0 to p2 with control point p1.
+func (c *Canvas) QuadBezier(p0, p1, p2 image.Point) {
+	c.curve(func(t float64) (float64, float64) {
+		u := 1 - t
+		a, b, d := u*u, 2*u*t, t*t
+		return a*float64(p0.X) + b*float64(p1.X) + d*float64(p2.X),
+			a*float64(p0.Y) + b*float64(p1.Y) + d*float64(p2.Y)
+	}, p0, p1, p2)
+}
+
+// CubicBezier draws the cubic Bézier curve from p0 to p3 with control points p1
+// and p2.
+func (c *Canvas) CubicBezier(p0, p1, p2, p3 image.Point) {
+	c.curve(func(t float64) (float64, float64) {
+		u := 1 - t
+		a, b, d, e := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+		return a*float64(p0.X) + b*float64(p1.X) + d*float64(p2.X) + e*float64(p3.X),
+			a*float64(p0.Y) + b*float64(p1.Y) + d*float64(p2.Y) + e*float64(p3.Y)
+	}, p0, p1, p2, p3)
+}
+
+// SmoothPolyline draws a smooth curve through every point of pts, e.g. for chart
+// lines. Each segment is a Catmull-Rom spline, drawn as the equivalent cubic Bézier,
+// so the curve passes through the points without sharp corners.
+func (c *Canvas) SmoothPolyline(pts ...image.Point) {
+	if len(pts) < 3 {
+		c.Polyline(pts...)
+		return
+	}
+	for i := 0; i+1 < len(pts); i++ {
+		// The neighbors beyond each end are the end points themselves
+		prev, next := pts[max(i-1, 0)], pts[min(i+2, len(pts)-1)]
+		p1, p2 := pts[i], pts[i+1]
+		c1 := image.Pt(p1.X+divRound(p2.X-prev.X, 6), p1.Y+divRound(p2.Y-prev.Y, 6))
+		c2 := image.Pt(p2.X-divRound(next.X-p1.X, 6), p2.Y-divRound(next.Y-p1.Y, 6))
+		c.CubicBezier(p1, c1, c2, p2)
+	}
+}
+
+// Fill lights the region of unlit dots connected to (x, y), like a paint bucket.
+// Dots connect only horizontally and vertically, so the diagonal steps of lines and
+// curves hold the fill in. Fill does nothing if (x, y) is already lit.
+func (c *Canvas) Fill(x, y int) {
+	if !c.inBounds(x, y) || c.dots[y][x] {
+		return
+	}
+	c.Set(x, y)
+	stack := [][2]int{{x, y}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, d := range [4][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			nx, ny := p[0]+d[0], p[1]+d[1]
+			if c.inBounds(nx, ny) && !c.dots[ny][nx] {
+				c.Set(nx, ny)
+				stack = append(stack, [2]int{nx, ny})
+			}
+		}
+	}
+}
+
+// DrawImage converts img to dots as Convert would with opts, by thresholding,
+// dithering, or edge detection, and lights its lit dots on the canvas with the
+// image's top left corner at the dot at. Dots the image leaves unlit are left as
+// they were, so images and drawings combine. opts.Width and opts.Height size the
+// image in characters; if either is 0, it's sized to fit the canvas right of and
+// below at, keeping its aspect ratio. Options that only affect rendering, such as
+// colors and the frame, are ignored.
+func (c *Canvas) DrawImage(img image.Image, at image.Point, opts Options) {
+	opts.Frame = false
+	if opts.Width == 0 || opts.Height == 0 {
+		cw, ch := opts.cellSize()
+		maxWidth := (c.width - at.X + cw - 1) / cw
+		maxHeight := (c.height - at.Y + ch - 1) / ch
+		if maxWidth < 1 || maxHeight < 1 {
+			return
+		}
+		b := img.Bounds()
+		w, h := CalculateAspectDimensions(b.Dx(), b.Dy(), opts.Width, opts.Height, maxWidth, maxHeight, opts.cellAspect())
+		opts.Width, opts.Height = max(w, 1), max(h, 1)
+	}
+	for y, row := range ConvertToDots(img, opts) {
+		for x, lit := range row {
+			if lit {
+				c.Set(at.X+x, at.Y+y)
+			}
+		}
+	}
+}
+
+// curve draws the parametric curve at for t from 0 to 1 as line segments, with
+// enough segments that each spans a few dots given the length of the control
+// polygon through ctrl.
+func (c *Canvas) curve(at func(t float64) (x, y float64), ctrl ...image.Point) {
+	length := 0.0
+	for i := 1; i < len(ctrl); i++ {
+		length += math.Hypot(float64(ctrl[i].X-ctrl[i-1].X), float64(ctrl[i].Y-ctrl[i-1].Y))
+	}
+	steps := max(int(length/2), 1)
+
+	x0, y0 := ctrl[0].X, ctrl[0].Y
+	for i := 1; i <= steps; i++ {
+		x, y := at(float64(i) / float64(steps))
+		x1, y1 := int(math.Round(x)), int(math.Round(y))
+		c.Line(x0, y0, x1, y1)
+		x0, y0 = x1, y1
+	}
+}
+
+// divRound returns n/d rounded to the nearest integer, for positive d.
+func divRound(n, d int) int {
+	return int(math.Round(float64(n) / float64(d)))
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// sign returns -1, 0, or 1 for negative, zero, or positive n.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// Circle draws a circle of radius r centered at (cx, cy).
+func (c *Canvas) Circle(cx, cy, r int) {
+	c.Ellipse(cx, cy, r, r)
+}
+
+// FillCircle draws a solid disc of radius r centered at (cx, cy).
+func (c *Canvas) FillCircle(cx, cy, r int) {
+	c.FillEllipse(cx, cy, r, r)
+}
+
+// Ellipse draws an axis-aligned ellipse with radii rx and ry centered at (cx, cy).
+func (c *Canvas) Ellipse(cx, cy, rx, ry int) {
+	ellipsePoints(rx, ry, func(x, y int) {
+		c.Set(cx+x, cy+y)
+		c.Set(cx-x, cy+y)
+		c.Set(cx+x, cy-y)
+		c.Set(cx-x, cy-y)
+	})
+}
+
+// FillEllipse draws a solid axis-aligned ellipse with radii rx and ry centered at (cx, cy).
+func (c *Canvas) FillEllipse(cx, cy, rx, ry int) {
+	ellipsePoints(rx, ry, func(x, y int) {
+		c.Line(cx-x, cy+y, cx+x, cy+y)
+		c.Line(cx-x, cy-y, cx+x, cy-y)
+	})
+}
+
+// Arc draws the part of an ellipse with radii rx and ry centered at (cx, cy) from
+// angle start to end, in radians counterclockwise from the positive x axis as on a
+// dial, e.g. for gauges.
+func (c *Canvas) Arc(cx, cy, rx, ry int, start, end float64) {
+	ellipsePoints(rx, ry, func(x, y int) {
+		for _, p := range [4]image.Point{{x, y}, {-x, y}, {x, -y}, {-x, -y}} {
+			if angleBetween(p.X, p.Y, start, end) {
+				c.Set(cx+p.X, cy+p.Y)
+			}
+		}
+	})
+}
+
+// FillArc draws a solid pie slice of an ellipse with radii rx and ry centered at
+// (cx, cy) from angle start to end, measured as for Arc.
+func (c *Canvas) FillArc(cx, cy, rx, ry int, start, end float64) {
+	ellipsePoints(rx, ry, func(x, y int) {
+		for _, dy := range [2]int{y, -y} {
+			for dx := -x; dx <= x; dx++ {
+				if angleBetween(dx, dy, start, end) {
+					c.Set(cx+dx, cy+dy)
+				}
+			}
+		}
+	})
+}
+
+// angleBetween reports whether the point at offset (x, y) from a center lies at an
+// angle from start to end, counterclockwise. The center itself is always included.
+func angleBetween(x, y int, start, end float64) bool {
+	if x == 0 && y == 0 {
+		return true
+	}
+	// Screen y grows downward, so flip it to measure counterclockwise
+	a := math.Atan2(float64(-y), float64(x))
+	span := math.Mod(end-start, 2*math.Pi)
+	if span < 0 || (span == 0 && end != start) {
+		span += 2 * math.Pi
+	}
+	offset := math.Mod(a-start, 2*math.Pi)
+	if offset < 0 {
+		offset += 2 * math.Pi
+	}
+	return offset <= span
+}
+
+// ellipsePoints calls plot with each point of the first quadrant of an ellipse with
+// radii rx and ry centered at the origin, using the midpoint ellipse algorithm.
+// Callers mirror the points into the other quadrants.
+func ellipsePoints(rx, ry int, plot func(x, y int)) {
+	if rx < 0 || ry < 0 {
+		return
+	}
+	if ry == 0 {
+		for x := 0; x <= rx; x++ {
+			plot(x, 0)
+		}
+		return
+	}
+
+	rx2, ry2 := float64(rx*rx), float64(ry*ry)
+	x, y := 0, ry
+	dx, dy := 0.0, 2*rx2*float64(y)
+
+	// Region 1, where the slope is shallower than -1: step along x
+	d := ry2 - rx2*float64(ry) + rx2/4
+	for dx < dy {
+		plot(x, y)
+		x++
+		dx += 2 * ry2
+		if d < 0 {
+			d += dx + ry2
+		} else {
+			y--
+			dy -= 2 * rx2
+			d += dx - dy + ry2
+		}
+	}
+
+	// Region 2, where the slope is steeper: step along y
+	fx, fy := float64(x)+0.5, float64(y-1)
+	d = ry2*fx*fx + rx2*fy*fy - rx2*ry2
+	for y >= 0 {
+		plot(x, y)
+		y--
+		dy -= 2 * rx2
+		if d > 0 {
+			d += rx2 - dy
+		} else {
+			x++
+			dx += 2 * ry2
+			d += dx - dy + rx2
+		}
+	}
+}
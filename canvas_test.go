@@ -0,0 +1,320 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"slices"
+	"testing"
+)
+
+// litDots returns the lit dots of a canvas in row order.
+func litDots(c *Canvas) []image.Point {
+	var pts []image.Point
+	b := c.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if c.Get(x, y) {
+				pts = append(pts, image.Pt(x, y))
+			}
+		}
+	}
+	return pts
+}
+
+func TestCanvas(t *testing.T) {
+	c := NewCanvas(5, 6)
+	if got, want := c.Bounds(), image.Rect(0, 0, 5, 6); got != want {
+		t.Errorf("Bounds() = %v, want %v", got, want)
+	}
+
+	c.Set(0, 0)
+	c.Set(4, 5)
+	c.Set(-1, 0) // Ignored
+	c.Set(5, 6)  // Ignored
+	if !c.Get(0, 0) || !c.Get(4, 5) || c.Get(1, 0) || c.Get(-1, 0) {
+		t.Errorf("Get() doesn't match the dots set: %v", litDots(c))
+	}
+
+	// 5×6 dots need 3×2 cells; (4, 5) is the second dot of the left column of the bottom-right cell
+	if got, want := c.String(), "⠁⠀⠀\n⠀⠀⠂"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := c.Grid()[0][0].Pattern; got != 0x01 {
+		t.Errorf("Grid() pattern = %08b, want 00000001", got)
+	}
+
+	c.Unset(0, 0)
+	if c.Get(0, 0) {
+		t.Error("Unset() left the dot lit")
+	}
+	c.Clear()
+	if got := litDots(c); len(got) != 0 {
+		t.Errorf("Clear() left dots lit: %v", got)
+	}
+}
+
+func TestLine(t *testing.T) {
+	for _, tt := range []struct {
+		desc           string
+		x0, y0, x1, y1 int
+		want           []image.Point
+	}{
+		{desc: "point", x0: 1, y0: 1, x1: 1, y1: 1, want: []image.Point{{1, 1}}},
+		{desc: "horizontal", x0: 0, y0: 1, x1: 3, y1: 1, want: []image.Point{{0, 1}, {1, 1}, {2, 1}, {3, 1}}},
+		{desc: "vertical upward", x0: 2, y0: 3, x1: 2, y1: 0, want: []image.Point{{2, 0}, {2, 1}, {2, 2}, {2, 3}}},
+		{desc: "diagonal", x0: 0, y0: 0, x1: 3, y1: 3, want: []image.Point{{0, 0}, {1, 1}, {2, 2}, {3, 3}}},
+		{desc: "shallow", x0: 0, y0: 0, x1: 4, y1: 2, want: []image.Point{{0, 0}, {1, 1}, {2, 1}, {3, 2}, {4, 2}}},
+		{desc: "steep backward", x0: 1, y0: 4, x1: 0, y1: 0, want: []image.Point{{0, 0}, {0, 1}, {0, 2}, {1, 3}, {1, 4}}},
+		{desc: "clipped", x0: -2, y0: 0, x1: 1, y1: 0, want: []image.Point{{0, 0}, {1, 0}}},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := NewCanvas(6, 6)
+			c.Line(tt.x0, tt.y0, tt.x1, tt.y1)
+			if got := litDots(c); !slices.Equal(got, tt.want) {
+				t.Errorf("Line() lit %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineAA(t *testing.T) {
+	for _, tt := range []struct {
+		desc           string
+		x0, y0, x1, y1 float64
+		want           []image.Point
+	}{{
+		desc: "centered on dots",
+		x0:   0.5, y0: 1.5, x1: 3.5, y1: 1.5,
+		want: []image.Point{{0, 1}, {1, 1}, {2, 1}, {3, 1}},
+	}, {
+		desc: "between rows lights both",
+		x0:   0.5, y0: 2, x1: 2.5, y1: 2,
+		want: []image.Point{{0, 1}, {1, 1}, {2, 1}, {0, 2}, {1, 2}, {2, 2}},
+	}, {
+		desc: "gentle slope",
+		x0:   0.5, y0: 0.5, x1: 4.5, y1: 1.5,
+		want: []image.Point{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {3, 1}, {4, 1}},
+	}, {
+		desc: "steep",
+		x0:   1.5, y0: 0.5, x1: 1.5, y1: 3.5,
+		want: []image.Point{{1, 0}, {1, 1}, {1, 2}, {1, 3}},
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := NewCanvas(6, 6)
+			c.LineAA(tt.x0, tt.y0, tt.x1, tt.y1)
+			if got := litDots(c); !slices.Equal(got, tt.want) {
+				t.Errorf("LineAA() lit %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEllipse(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		draw func(c *Canvas)
+		want []image.Point
+	}{{
+		desc: "circle",
+		draw: func(c *Canvas) { c.Circle(3, 3, 3) },
+		want: []image.Point{{2, 0}, {3, 0}, {4, 0}, {1, 1}, {5, 1}, {0, 2}, {6, 2}, {0, 3}, {6, 3}, {0, 4}, {6, 4}, {1, 5}, {5, 5}, {2, 6}, {3, 6}, {4, 6}},
+	}, {
+		desc: "filled circle",
+		draw: func(c *Canvas) { c.FillCircle(3, 3, 1) },
+		want: []image.Point{{3, 2}, {2, 3}, {3, 3}, {4, 3}, {3, 4}},
+	}, {
+		desc: "ellipse",
+		draw: func(c *Canvas) { c.Ellipse(4, 2, 4, 2) },
+		want: []image.Point{{2, 0}, {3, 0}, {4, 0}, {5, 0}, {6, 0}, {1, 1}, {7, 1}, {0, 2}, {8, 2}, {1, 3}, {7, 3}, {2, 4}, {3, 4}, {4, 4}, {5, 4}, {6, 4}},
+	}, {
+		desc: "flat ellipse",
+		draw: func(c *Canvas) { c.Ellipse(4, 2, 2, 0) },
+		want: []image.Point{{2, 2}, {3, 2}, {4, 2}, {5, 2}, {6, 2}},
+	}, {
+		desc: "quarter arc",
+		draw: func(c *Canvas) { c.Arc(3, 3, 3, 3, 0, math.Pi/2) },
+		want: []image.Point{{3, 0}, {4, 0}, {5, 1}, {6, 2}, {6, 3}},
+	}, {
+		desc: "quarter pie slice",
+		draw: func(c *Canvas) { c.FillArc(3, 3, 2, 2, 0, math.Pi/2) },
+		want: []image.Point{{3, 1}, {4, 1}, {3, 2}, {4, 2}, {5, 2}, {3, 3}, {4, 3}, {5, 3}},
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := NewCanvas(9, 7)
+			tt.draw(c)
+			if got := litDots(c); !slices.Equal(got, tt.want) {
+				t.Errorf("lit %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A full-turn arc is the whole circle
+	full, circle := NewCanvas(7, 7), NewCanvas(7, 7)
+	full.Arc(3, 3, 3, 3, 0, 2*math.Pi)
+	circle.Circle(3, 3, 3)
+	if got, want := full.String(), circle.String(); got != want {
+		t.Errorf("Arc() over a full turn = %q, want %q", got, want)
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	triangle := []image.Point{{3, 0}, {6, 6}, {0, 6}}
+	for _, tt := range []struct {
+		desc string
+		draw func(c *Canvas)
+		want []image.Point
+	}{{
+		desc: "rect",
+		draw: func(c *Canvas) { c.Rect(1, 1, 4, 3) },
+		want: []image.Point{{1, 1}, {2, 1}, {3, 1}, {4, 1}, {1, 2}, {4, 2}, {1, 3}, {2, 3}, {3, 3}, {4, 3}},
+	}, {
+		desc: "filled rect with swapped corners",
+		draw: func(c *Canvas) { c.FillRect(4, 3, 1, 1) },
+		want: []image.Point{{1, 1}, {2, 1}, {3, 1}, {4, 1}, {1, 2}, {2, 2}, {3, 2}, {4, 2}, {1, 3}, {2, 3}, {3, 3}, {4, 3}},
+	}, {
+		desc: "clipped filled rect",
+		draw: func(c *Canvas) { c.FillRect(-3, 5, 1, 9) },
+		want: []image.Point{{0, 5}, {1, 5}, {0, 6}, {1, 6}},
+	}, {
+		desc: "filled triangle",
+		draw: func(c *Canvas) { c.FillPolygon(triangle...) },
+		want: []image.Point{
+			{3, 0},
+			{3, 1}, {4, 1},
+			{2, 2}, {3, 2}, {4, 2},
+			{2, 3}, {3, 3}, {4, 3}, {5, 3},
+			{1, 4}, {2, 4}, {3, 4}, {4, 4}, {5, 4},
+			{1, 5}, {2, 5}, {3, 5}, {4, 5}, {5, 5}, {6, 5},
+			{0, 6}, {1, 6}, {2, 6}, {3, 6}, {4, 6}, {5, 6}, {6, 6},
+		},
+	}, {
+		desc: "bow tie",
+		draw: func(c *Canvas) { c.FillPolygon(image.Pt(0, 0), image.Pt(4, 4), image.Pt(4, 0), image.Pt(0, 4)) },
+		want: []image.Point{
+			{0, 0}, {4, 0},
+			{0, 1}, {1, 1}, {3, 1}, {4, 1},
+			{0, 2}, {1, 2}, {2, 2}, {3, 2}, {4, 2},
+			{0, 3}, {1, 3}, {3, 3}, {4, 3},
+			{0, 4}, {4, 4},
+		},
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := NewCanvas(7, 7)
+			tt.draw(c)
+			if got := litDots(c); !slices.Equal(got, tt.want) {
+				t.Errorf("lit %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The fill covers the outline
+	outline, fill := NewCanvas(7, 7), NewCanvas(7, 7)
+	outline.Polygon(triangle...)
+	fill.FillPolygon(triangle...)
+	for _, p := range litDots(outline) {
+		if !fill.Get(p.X, p.Y) {
+			t.Errorf("FillPolygon() left outline dot %v unlit", p)
+		}
+	}
+}
+
+func TestCurves(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		draw func(c *Canvas)
+		want []image.Point
+	}{{
+		desc: "polyline",
+		draw: func(c *Canvas) { c.Polyline(image.Pt(0, 0), image.Pt(2, 0), image.Pt(2, 2)) },
+		want: []image.Point{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 2}},
+	}, {
+		desc: "straight quadratic",
+		draw: func(c *Canvas) { c.QuadBezier(image.Pt(0, 2), image.Pt(4, 2), image.Pt(8, 2)) },
+		want: []image.Point{{0, 2}, {1, 2}, {2, 2}, {3, 2}, {4, 2}, {5, 2}, {6, 2}, {7, 2}, {8, 2}},
+	}, {
+		desc: "cubic arch",
+		draw: func(c *Canvas) { c.CubicBezier(image.Pt(0, 4), image.Pt(0, 0), image.Pt(8, 0), image.Pt(8, 4)) },
+		want: []image.Point{{2, 1}, {3, 1}, {4, 1}, {5, 1}, {1, 2}, {6, 2}, {7, 2}, {0, 3}, {8, 3}, {0, 4}, {8, 4}},
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := NewCanvas(9, 5)
+			tt.draw(c)
+			if got := litDots(c); !slices.Equal(got, tt.want) {
+				t.Errorf("lit %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A smooth polyline passes through each of its points, without gaps
+	pts := []image.Point{{0, 7}, {6, 1}, {12, 6}, {19, 0}}
+	c := NewCanvas(20, 8)
+	c.SmoothPolyline(pts...)
+	for _, p := range pts {
+		if !c.Get(p.X, p.Y) {
+			t.Errorf("SmoothPolyline() left %v unlit", p)
+		}
+	}
+	for x := range 20 {
+		lit := false
+		for y := range 8 {
+			lit = lit || c.Get(x, y)
+		}
+		if !lit {
+			t.Errorf("SmoothPolyline() left a gap at column %d:\n%s", x, c)
+		}
+	}
+}
+
+func TestFill(t *testing.T) {
+	c := NewCanvas(7, 7)
+	c.Circle(3, 3, 3)
+	c.Fill(3, 3)
+
+	want := NewCanvas(7, 7)
+	want.FillCircle(3, 3, 3)
+	if got, want := c.String(), want.String(); got != want {
+		t.Errorf("Fill() inside a circle = %q, want %q", got, want)
+	}
+
+	// The circle cuts the corners off from each other; filling a lit dot does nothing
+	c.Fill(0, 0)
+	c.Fill(3, 3)
+	if got, want := litDots(c)[:4], []image.Point{{0, 0}, {1, 0}, {2, 0}, {3, 0}}; !slices.Equal(got, want) {
+		t.Errorf("Fill() in a corner lit %v..., want %v...", got, want)
+	}
+	if c.Get(6, 0) {
+		t.Error("Fill() leaked across the circle into another corner")
+	}
+	c.Fill(-1, 0) // Ignored
+}
+
+func TestDrawImage(t *testing.T) {
+	// A white left column beside a black one
+	img := image.NewRGBA(image.Rect(0, 0, 2, 4))
+	for y := range 4 {
+		img.Set(0, y, color.White)
+		img.Set(1, y, color.Black)
+	}
+	c := NewCanvas(6, 4)
+	c.Line(0, 3, 5, 3)
+	c.DrawImage(img, image.Pt(3, 0), Options{Width: 1, Height: 1})
+	want := []image.Point{{3, 0}, {3, 1}, {3, 2}, {0, 3}, {1, 3}, {2, 3}, {3, 3}, {4, 3}, {5, 3}}
+	if got := litDots(c); !slices.Equal(got, want) {
+		t.Errorf("DrawImage() lit %v, want %v, keeping the line under the image's black dots", got, want)
+	}
+
+	// Without a size, the image fits the rest of the canvas
+	img = image.NewRGBA(image.Rect(0, 0, 4, 8))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	c = NewCanvas(6, 8)
+	c.DrawImage(img, image.Pt(2, 0), Options{})
+	for y := range 8 {
+		for x := range 6 {
+			if got, want := c.Get(x, y), x >= 2; got != want {
+				t.Errorf("DrawImage() to fit: Get(%d, %d) = %t, want %t", x, y, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package dots
+
+import (
+	"image/color"
+	"slices"
+)
+
+// ColorResolution decides the color of a canvas cell whose lit dots have
+// different colors, since a terminal can only give each character one color.
+type ColorResolution int
+
+const (
+	// ColorMajority colors a cell with the color of most of its colored dots,
+	// breaking ties in favor of the color lit last. It suits plots, where a cell
+	// belongs to the series with the most dots in it.
+	ColorMajority ColorResolution = iota
+	// ColorLastWrite colors a cell with the color lit last in it, so what's drawn
+	// later is drawn on top.
+	ColorLastWrite
+)
+
+// SetPen sets the color of the dots lit from now on by Set and the drawing
+// methods; nil leaves them uncolored.
+func (c *Canvas) SetPen(col color.Color) {
+	c.pen = color.RGBA{}
+	if col != nil {
+		c.pen = color.RGBAModel.Convert(col).(color.RGBA)
+	}
+}
+
+// SetColor lights the dot at (x, y) in a color, regardless of the pen.
+func (c *Canvas) SetColor(x, y int, col color.Color) {
+	pen := c.pen
+	c.SetPen(col)
+	c.Set(x, y)
+	c.pen = pen
+}
+
+// color records the color of the dot at (x, y), which is on the canvas; a
+// zero-alpha color uncolors it.
+func (c *Canvas) color(x, y int, col color.RGBA) {
+	if c.colors == nil {
+		if col.A == 0 {
+			return
+		}
+		c.colors = make([][]color.RGBA, c.height)
+		for y := range c.colors {
+			c.colors[y] = make([]color.RGBA, c.width)
+		}
+		c.last = make([][]color.RGBA, (c.height+3)/4)
+		for row := range c.last {
+			c.last[row] = make([]color.RGBA, (c.width+1)/2)
+		}
+	}
+	c.colors[y][x] = col
+	if col.A != 0 {
+		c.last[y/4][x/2] = col
+	}
+}
+
+// cellColor resolves the color of the cell at (col, row) from the colors of its
+// lit dots, or returns a zero-alpha color if none are colored.
+func (c *Canvas) cellColor(col, row int) color.RGBA {
+	if c.colors == nil {
+		return color.RGBA{}
+	}
+	// The colors of the lit dots and how many of each, in dot order
+	var colors []color.RGBA
+	var counts []int
+	for _, off := range dotOffsets {
+		x, y := col*2+off[0], row*4+off[1]
+		if !c.inBounds(x, y) || !c.dots[y][x] || c.colors[y][x].A == 0 {
+			continue
+		}
+		if i := slices.Index(colors, c.colors[y][x]); i >= 0 {
+			counts[i]++
+		} else {
+			colors = append(colors, c.colors[y][x])
+			counts = append(counts, 1)
+		}
+	}
+	if len(colors) == 0 {
+		return color.RGBA{}
+	}
+
+	last := slices.Index(colors, c.last[row][col])
+	if c.ColorResolution == ColorLastWrite && last >= 0 {
+		return colors[last]
+	}
+	best := max(last, 0)
+	for i, n := range counts {
+		if n > counts[best] {
+			best = i
+		}
+	}
+	return colors[best]
+}
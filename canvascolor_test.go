@@ -0,0 +1,60 @@
+package dots
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestCanvasColors(t *testing.T) {
+	red, blue := color.RGBA{255, 0, 0, 255}, color.RGBA{0, 0, 255, 255}
+	for _, tt := range []struct {
+		desc       string
+		resolution ColorResolution
+		draw       func(c *Canvas)
+		want       color.RGBA
+	}{
+		{"uncolored", ColorMajority, func(c *Canvas) { c.Line(0, 0, 1, 3) }, color.RGBA{}},
+		{"pen", ColorMajority, func(c *Canvas) {
+			c.SetPen(red)
+			c.Line(0, 0, 0, 3)
+		}, red},
+		{"majority", ColorMajority, func(c *Canvas) {
+			c.SetPen(red)
+			c.Line(0, 0, 0, 2)
+			c.SetColor(1, 3, blue)
+		}, red},
+		{"last write", ColorLastWrite, func(c *Canvas) {
+			c.SetPen(red)
+			c.Line(0, 0, 0, 2)
+			c.SetColor(1, 3, blue)
+		}, blue},
+		{"tie goes to last", ColorMajority, func(c *Canvas) {
+			c.SetColor(0, 0, red)
+			c.SetColor(1, 0, blue)
+		}, blue},
+		{"uncolored dots don't count", ColorMajority, func(c *Canvas) {
+			c.Line(0, 0, 0, 3)
+			c.SetColor(1, 0, blue)
+		}, blue},
+		{"unset dots don't count", ColorLastWrite, func(c *Canvas) {
+			c.SetColor(0, 0, red)
+			c.SetColor(1, 0, blue)
+			c.Unset(1, 0)
+		}, red},
+		{"cleared", ColorMajority, func(c *Canvas) {
+			c.SetColor(0, 0, red)
+			c.Clear()
+			c.SetPen(nil)
+			c.Set(0, 0)
+		}, color.RGBA{}},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := NewCanvas(2, 4)
+			c.ColorResolution = tt.resolution
+			tt.draw(c)
+			if got := c.Grid()[0][0].Fg; got != tt.want {
+				t.Errorf("cell color = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
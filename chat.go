@@ -0,0 +1,50 @@
+package dots
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DiscordMessageLimit is the most characters a Discord message can have.
+const DiscordMessageLimit = 2000
+
+// ChatMessages prepares lines of output to be posted as chat messages. Escape codes
+// are stripped, and spaces are replaced with blank braille characters (U+2800),
+// padding each line to the width of the longest, since chat clients collapse and
+// trim whitespace and would misalign the picture. The lines are then grouped into
+// as few messages as fit in limit characters each, newlines included, without
+// splitting a line across messages. It fails if a line alone is over the limit.
+func ChatMessages(lines []string, limit int) ([]string, error) {
+	safe := make([]string, len(lines))
+	width := 0
+	for i, line := range lines {
+		safe[i] = strings.ReplaceAll(stripANSI(line), " ", "⠀")
+		width = max(width, utf8.RuneCountInString(safe[i]))
+	}
+	for i, line := range safe {
+		safe[i] = line + strings.Repeat("⠀", width-utf8.RuneCountInString(line))
+	}
+	if width > limit {
+		return nil, fmt.Errorf("lines of %d characters don't fit in messages of %d", width, limit)
+	}
+
+	var messages []string
+	var msg []string
+	size := 0 // Characters in msg, with the newlines between lines
+	for _, line := range safe {
+		if len(msg) > 0 && size+1+width > limit {
+			messages = append(messages, strings.Join(msg, "\n"))
+			msg, size = nil, 0
+		}
+		if len(msg) > 0 {
+			size++
+		}
+		msg = append(msg, line)
+		size += width
+	}
+	if len(msg) > 0 {
+		messages = append(messages, strings.Join(msg, "\n"))
+	}
+	return messages, nil
+}
@@ -0,0 +1,59 @@
+package dots
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestChatMessages(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		lines   []string
+		limit   int
+		want    []string
+		wantErr bool
+	}{{
+		desc:  "one message",
+		lines: []string{"⣿⣿", "⣿⣿"},
+		limit: 10,
+		want:  []string{"⣿⣿\n⣿⣿"},
+	}, {
+		desc:  "spaces and ragged lines are padded with blank braille",
+		lines: []string{" ⣿", "⣿"},
+		limit: 10,
+		want:  []string{"⠀⣿\n⣿⠀"},
+	}, {
+		desc:  "escape codes are stripped",
+		lines: []string{"\x1b[38;5;196m⣿\x1b[0m", "\x1b]8;;file:///a.png\x1b\\⣿\x1b]8;;\x1b\\"},
+		limit: 10,
+		want:  []string{"⣿\n⣿"},
+	}, {
+		desc:  "split between lines",
+		lines: []string{"⣿⣿", "⣶⣶", "⣤⣤", "⣀⣀"},
+		limit: 7, // Two lines and a newline, but not three
+		want:  []string{"⣿⣿\n⣶⣶", "⣤⣤\n⣀⣀"},
+	}, {
+		desc:  "one line per message",
+		lines: []string{"⣿⣿", "⣶⣶"},
+		limit: 2,
+		want:  []string{"⣿⣿", "⣶⣶"},
+	}, {
+		desc:    "line over the limit",
+		lines:   []string{"⣿⣿⣿"},
+		limit:   2,
+		wantErr: true,
+	}, {
+		desc:  "no lines",
+		limit: 10,
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ChatMessages(tt.lines, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ChatMessages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ChatMessages() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
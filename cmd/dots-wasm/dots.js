@@ -0,0 +1,24 @@
+// dots.js wraps the dots WebAssembly module.
+//
+// Load wasm_exec.js from $(go env GOROOT)/lib/wasm first, then:
+//
+//   const dots = await loadDots("dots.wasm");
+//   const text = dots.convert(ctx.getImageData(0, 0, w, h), { width: 80 });
+//   term.write(text.replaceAll("\n", "\r\n")); // xterm.js expects CRLF
+export async function loadDots(url) {
+  const go = new Go();
+  const { instance } = await WebAssembly.instantiateStreaming(fetch(url), go.importObject);
+  go.run(instance);
+
+  return {
+    // convert renders an ImageData as braille, returning lines joined by "\n".
+    // options may set width, height, threshold, noColor, and frame.
+    convert(imageData, options = {}) {
+      const result = globalThis.dotsConvert(imageData, options);
+      if (result instanceof Error) {
+        throw result;
+      }
+      return result;
+    },
+  };
+}
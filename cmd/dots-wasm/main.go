@@ -0,0 +1,71 @@
+//go:build js && wasm
+
+// Command dots-wasm exposes the braille converter to JavaScript.
+//
+// Build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o dots.wasm ./cmd/dots-wasm
+//
+// and load it with dots.js, which wraps the exported function.
+package main
+
+import (
+	"image"
+	"strings"
+	"syscall/js"
+
+	"github.com/imjasonh/dots"
+)
+
+func main() {
+	js.Global().Set("dotsConvert", js.FuncOf(convert))
+
+	// Keep the Go runtime alive so the exported function stays callable
+	select {}
+}
+
+// convert implements dotsConvert(imageData, options) -> string, where imageData
+// is a browser ImageData (width, height, and RGBA bytes in data) and options is
+// an optional object with width, height, threshold, noColor, and frame fields.
+func convert(_ js.Value, args []js.Value) any {
+	if len(args) < 1 {
+		return js.Global().Get("Error").New("dotsConvert: missing imageData argument")
+	}
+	imageData := args[0]
+	width, height := imageData.Get("width").Int(), imageData.Get("height").Int()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	data := imageData.Get("data")
+	js.CopyBytesToGo(img.Pix, js.Global().Get("Uint8Array").New(data.Get("buffer"), data.Get("byteOffset"), data.Get("byteLength")))
+
+	var opts dots.Options
+	if len(args) > 1 && args[1].Type() == js.TypeObject {
+		o := args[1]
+		if v := o.Get("width"); v.Type() == js.TypeNumber {
+			opts.Width = v.Int()
+		}
+		if v := o.Get("height"); v.Type() == js.TypeNumber {
+			opts.Height = v.Int()
+		}
+		if v := o.Get("threshold"); v.Type() == js.TypeNumber {
+			// Written to reject NaN too
+			if t := v.Float(); !(t >= 0 && t <= 255) {
+				return js.Global().Get("Error").New("dotsConvert: threshold must be between 0 and 255")
+			}
+			opts.Threshold = uint8(v.Int())
+		}
+		if v := o.Get("noColor"); v.Type() == js.TypeBoolean {
+			opts.NoColor = v.Bool()
+		}
+		if v := o.Get("frame"); v.Type() == js.TypeBoolean {
+			opts.Frame = v.Bool()
+		}
+	}
+
+	// There is no terminal to size against, so default to a fixed width
+	if opts.Width == 0 && opts.Height == 0 {
+		opts.Width = 80
+	}
+
+	return strings.Join(dots.Convert(img, opts), "\n")
+}
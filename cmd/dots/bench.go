@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/imjasonh/dots"
+)
+
+// benchMode is a set of options compared by "dots bench".
+type benchMode struct {
+	name string
+	opts dots.Options
+}
+
+// benchModes returns the color and dithering modes compared by "dots bench".
+func benchModes() []benchMode {
+	modes := []benchMode{
+		{"color", dots.Options{}},
+		{"no-color", dots.Options{NoColor: true}},
+		{"grayscale", dots.Options{Grayscale: true}},
+		{"color-dither", dots.Options{ColorDither: true}},
+	}
+	for _, d := range dots.DitherAlgorithms {
+		modes = append(modes, benchMode{"dither=" + string(d), dots.Options{DitherAlgorithm: d}})
+	}
+	for _, p := range dots.PaletteModes {
+		modes = append(modes, benchMode{"palette=" + string(p), dots.Options{PaletteMode: p}})
+	}
+	return modes
+}
+
+// benchMain implements "dots bench", which reports the time spent in each stage of
+// converting an image in several color modes, for tuning animation performance.
+func benchMain(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	var (
+		width  = fs.Int("width", 0, "Output width in characters (default: terminal width)")
+		height = fs.Int("height", 0, "Output height in characters (default: terminal height)")
+		n      = fs.Int("n", 10, "Conversions to average over in each mode")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s bench [flags] <image>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Reports the average time spent decoding the image, and resizing, filtering,\n")
+		fmt.Fprintf(os.Stderr, "dithering, quantizing, and encoding it in each color mode.\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	if *n < 1 {
+		fmt.Fprintf(os.Stderr, "Error: -n must be at least 1\n")
+		os.Exit(exitUsage)
+	}
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	var img image.Image
+	var format string
+	var decode time.Duration
+	for range *n {
+		start := time.Now()
+		img, format, err = dots.Decode(bytes.NewReader(data))
+		decode += time.Since(start)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", fs.Arg(0), err)
+			os.Exit(exitCode(err))
+		}
+	}
+	w, h := chartSize(*width, *height)
+	b := img.Bounds()
+	fmt.Printf("%s: %s, %dx%d pixels, decoded in %s\n", fs.Arg(0), format, b.Dx(), b.Dy(), formatDuration(decode/time.Duration(*n)))
+	fmt.Printf("%dx%d characters, average of %d conversions\n\n", w, h, *n)
+	if err := writeBench(os.Stdout, img, w, h, *n, benchModes()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+}
+
+// writeBench converts img n times in each mode at w×h characters, and writes a
+// table of the average time spent in each stage, and the frame rate it allows.
+func writeBench(out io.Writer, img image.Image, w, h, n int, modes []benchMode) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "mode\tresize\tfilter\tdither\tquantize\tencode\ttotal\tfps\t")
+	for _, mode := range modes {
+		opts := mode.opts
+		opts.Width, opts.Height = w, h
+		var sum dots.StageTimes
+		for range n {
+			_, times := dots.TimeStages(img, opts)
+			sum = sum.Add(times)
+		}
+		avg := func(d time.Duration) string { return formatDuration(d / time.Duration(n)) }
+		total := sum.Total() / time.Duration(n)
+		fps := "-"
+		if total > 0 {
+			fps = fmt.Sprintf("%.0f", float64(time.Second)/float64(total))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", mode.name,
+			avg(sum.Resize), avg(sum.Filter), avg(sum.Dither), avg(sum.Quantize), avg(sum.Encode), avg(sum.Total()), fps)
+	}
+	return tw.Flush()
+}
+
+// formatDuration formats d in milliseconds with two decimals, so the columns of
+// the bench table line up and compare at a glance.
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
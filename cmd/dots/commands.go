@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// command is a subcommand of dots.
+type command struct {
+	name string
+	args string // Synopsis of the arguments, for the usage message
+	run  func(args []string)
+}
+
+// commands returns the subcommands, in the order the usage message lists them.
+// Without one, dots runs render, so "dots image.png" renders the image.
+func commands() []command {
+	return []command{
+		{"render", "[flags] <image>...", renderMain},
+		{"play", "[flags] <animation>", playMain},
+		{"plot", "[flags] [file]", plotMain},
+		{"qr", "[flags] [text]", qrMain},
+		{"compare", "[flags] a.png b.png", compareMain},
+		{"diff", "[flags] a.png b.png", diffMain},
+		{"sheet", "[flags] [dir]", sheetMain},
+		{"convert", "[flags] -o dir <image>...", convertMain},
+		{"bench", "[flags] <image>", benchMain},
+		{"serve", "[flags]", serveMain},
+		{"ssh", "[flags] [dir]", sshMain},
+		{"completion", "bash|zsh|fish", completionMain},
+	}
+}
+
+// subcommands returns the names of the subcommands, for completion.
+func subcommands() []string {
+	var names []string
+	for _, c := range commands() {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+// writeUsage writes the synopsis of each subcommand, starting with render, which
+// runs without its name.
+func writeUsage(w io.Writer) {
+	for i, c := range commands() {
+		prefix := "       "
+		if i == 0 {
+			prefix = "Usage: "
+		}
+		name := c.name
+		if c.name == "render" {
+			name = "[render]"
+		}
+		fmt.Fprintf(w, "%s%s %s %s\n", prefix, os.Args[0], name, c.args)
+	}
+}
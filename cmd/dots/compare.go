@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/imjasonh/dots"
+)
+
+// compareMain implements "dots compare", which shows two images next to each other,
+// each fit to half the terminal, for reviewing visual changes.
+func compareMain(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	var (
+		width   = fs.Int("width", 0, "Total output width in characters (default: terminal width)")
+		height  = fs.Int("height", 0, "Output height in characters, including the labels (default: terminal height)")
+		noColor = fs.Bool("no-color", false, "Disable ANSI colors")
+		gap     = fs.Int("gap", 2, "Blank columns between the images")
+		labels  = fs.Bool("labels", true, "Name each image above it")
+		dither  = fs.String("dither", "none", "Dithering algorithm: none, floyd-steinberg, sierra, sierra-lite, jjn, or blue-noise")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s compare [flags] a.png b.png\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Shows two images side by side, each scaled to half the terminal width.\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	if *gap < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -gap must not be negative\n")
+		os.Exit(exitUsage)
+	}
+	ditherAlgorithm, err := dots.ParseDitherAlgorithm(*dither)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	w, h := chartSize(*width, *height)
+	if *labels {
+		h--
+	}
+	half := max((w-*gap)/2, 1)
+	opts := dots.Options{NoColor: *noColor, DitherAlgorithm: ditherAlgorithm}
+
+	grids := make([]dots.Grid, fs.NArg())
+	for i, path := range fs.Args() {
+		img, _, err := loadImage(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+			os.Exit(exitCode(err))
+		}
+		widget := dots.NewWidget(img, opts)
+		widget.SetRect(0, 0, half, max(h, 1))
+		grids[i] = widget.Grid()
+		if *labels {
+			grids[i] = append(dots.Grid{label(filepath.Base(path), half)}, grids[i]...)
+		}
+	}
+	for _, line := range dots.SideBySide(*gap, grids...).Render(opts) {
+		fmt.Println(line)
+	}
+}
+
+// label returns a row of width cells spelling out name, shortened with an ellipsis
+// to fit, so tiles with labels are at least width wide.
+func label(name string, width int) []dots.Cell {
+	runes := []rune(name)
+	if len(runes) > width {
+		runes = append(runes[:max(width-1, 0)], '…')
+	}
+	cells := make([]dots.Cell, max(width, len(runes)))
+	for i := range cells {
+		cells[i] = dots.Cell{Rune: ' '}
+		if i < len(runes) {
+			cells[i].Rune = runes[i]
+		}
+	}
+	return cells
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/imjasonh/dots"
+)
+
+// completedFlag is a flag as shells complete it.
+type completedFlag struct {
+	name, usage string
+	isBool      bool     // Takes no value
+	values      []string // The values it takes, if there's a fixed set
+	file        bool     // Takes a file name
+}
+
+// completionMain implements "dots completion", which prints a completion script for
+// the shell, completing subcommands, flags, and the values of flags that take one of
+// a fixed set, like -dither and -preset. The flags are render's.
+func completionMain(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s completion bash|zsh|fish\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Prints a shell completion script, e.g. for ~/.bashrc:\n\n")
+		fmt.Fprintf(os.Stderr, "  source <(dots completion bash)\n")
+		os.Exit(exitUsage)
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load config: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	renderFlags(fs, false)
+	flags := completedFlags(fs, cfg)
+
+	switch args[0] {
+	case "bash":
+		writeBashCompletion(os.Stdout, flags)
+	case "zsh":
+		writeZshCompletion(os.Stdout, flags)
+	case "fish":
+		writeFishCompletion(os.Stdout, flags)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown shell %q (expected bash, zsh, or fish)\n", args[0])
+		os.Exit(exitUsage)
+	}
+}
+
+// completedFlags describes the flags of fs, with the values of those taking one of
+// a fixed set, including the presets in cfg.
+func completedFlags(fs *flag.FlagSet, cfg *config) []completedFlag {
+	values := map[string][]string{
+		"dither":      append([]string{"none"}, names(dots.DitherAlgorithms)...),
+		"palette":     append([]string{"none"}, names(dots.PaletteModes)...),
+		"block-color": append([]string{"average"}, names(dots.BlockColorModes)...),
+		"frame-style": append([]string{"single"}, names(dots.FrameStyles)...),
+		"align":       append([]string{"left"}, names(dots.Alignments)...),
+		"transition":  names(dots.Transitions),
+		"luma":        {"rec601", "rec709"},
+		"format":      {"ans", "txt", "html", "png", "ndjson"},
+		"from":        {"ndjson"},
+		"preset":      cfg.presetNames(),
+	}
+	var flags []completedFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		b, ok := f.Value.(interface{ IsBoolFlag() bool })
+		flags = append(flags, completedFlag{
+			name:   f.Name,
+			usage:  f.Usage,
+			isBool: ok && b.IsBoolFlag(),
+			values: values[f.Name],
+			file:   fileFlags[f.Name],
+		})
+	})
+	return flags
+}
+
+// names converts a list of string-typed names to strings.
+func names[T ~string](list []T) []string {
+	out := make([]string, len(list))
+	for i, v := range list {
+		out[i] = string(v)
+	}
+	return out
+}
+
+func writeBashCompletion(w io.Writer, flags []completedFlag) {
+	var all []string
+	fmt.Fprintf(w, "# bash completion for dots\n_dots() {\n")
+	fmt.Fprintf(w, "    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	fmt.Fprintf(w, "    case \"$prev\" in\n")
+	for _, f := range flags {
+		all = append(all, "-"+f.name)
+		switch {
+		case f.values != nil:
+			fmt.Fprintf(w, "    -%s|--%s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", f.name, f.name, strings.Join(f.values, " "))
+		case f.file:
+			fmt.Fprintf(w, "    -%s|--%s) COMPREPLY=($(compgen -f -- \"$cur\")); return ;;\n", f.name, f.name)
+		case !f.isBool:
+			// Anything goes
+			fmt.Fprintf(w, "    -%s|--%s) COMPREPLY=(); return ;;\n", f.name, f.name)
+		}
+	}
+	fmt.Fprintf(w, "    esac\n")
+	fmt.Fprintf(w, "    if [[ $cur == -* ]]; then\n")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(all, " "))
+	fmt.Fprintf(w, "        return\n    fi\n")
+	fmt.Fprintf(w, "    COMPREPLY=($(compgen -f -- \"$cur\"))\n")
+	fmt.Fprintf(w, "    if [[ $COMP_CWORD -eq 1 ]]; then\n")
+	fmt.Fprintf(w, "        COMPREPLY+=($(compgen -W %q -- \"$cur\"))\n", strings.Join(subcommands(), " "))
+	fmt.Fprintf(w, "    fi\n}\ncomplete -o filenames -F _dots dots\n")
+}
+
+func writeZshCompletion(w io.Writer, flags []completedFlag) {
+	fmt.Fprintf(w, "#compdef dots\n\n_dots() {\n    _arguments \\\n")
+	for _, f := range flags {
+		spec := fmt.Sprintf("-%s[%s]", f.name, zshEscape(f.usage))
+		switch {
+		case f.values != nil:
+			spec += fmt.Sprintf(":%s:(%s)", f.name, strings.Join(f.values, " "))
+		case f.file:
+			spec += fmt.Sprintf(":%s:_files", f.name)
+		case !f.isBool:
+			spec += fmt.Sprintf(":%s: ", f.name)
+		}
+		fmt.Fprintf(w, "        '%s' \\\n", spec)
+	}
+	fmt.Fprintf(w, "        '1: :{_alternative \"subcommands:subcommand:(%s)\" \"files:image:_files\"}' \\\n", strings.Join(subcommands(), " "))
+	fmt.Fprintf(w, "        '*:image:_files'\n}\n\n_dots \"$@\"\n")
+}
+
+// zshEscape escapes a flag's usage for the description in an _arguments spec,
+// quoted in single quotes.
+func zshEscape(s string) string {
+	return strings.NewReplacer("'", `'\''`, "[", `\[`, "]", `\]`, ":", `\:`).Replace(s)
+}
+
+func writeFishCompletion(w io.Writer, flags []completedFlag) {
+	fmt.Fprintf(w, "# fish completion for dots\n")
+	fmt.Fprintf(w, "complete -c dots -n __fish_use_subcommand -a '%s'\n", strings.Join(subcommands(), " "))
+	for _, f := range flags {
+		line := fmt.Sprintf("complete -c dots -o %s -d %s", f.name, fishQuote(f.usage))
+		switch {
+		case f.values != nil:
+			line += fmt.Sprintf(" -x -a '%s'", strings.Join(f.values, " "))
+		case f.file:
+			line += " -r -F"
+		case !f.isBool:
+			line += " -x"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// fishQuote quotes s in single quotes for fish.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,17 @@
+package main
+
+import "testing"
+
+func TestFileFlagsExist(t *testing.T) {
+	fs := newRenderFlagSet()
+	for name := range fileFlags {
+		if fs.Lookup(name) == nil {
+			t.Errorf("file flag -%s isn't one of render's flags", name)
+		}
+	}
+	for _, f := range completedFlags(fs, &config{}) {
+		if f.file != fileFlags[f.name] {
+			t.Errorf("-%s completes files: %v, want %v", f.name, f.file, fileFlags[f.name])
+		}
+	}
+}
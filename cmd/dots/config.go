@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// configPaths returns the paths a config file is looked for at, in order:
+// config.toml, config.yaml, and config.yml in $XDG_CONFIG_HOME/dots, or
+// ~/.config/dots if it's unset.
+func configPaths() []string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	dir = filepath.Join(dir, "dots")
+	return []string{
+		filepath.Join(dir, "config.toml"),
+		filepath.Join(dir, "config.yaml"),
+		filepath.Join(dir, "config.yml"),
+	}
+}
+
+// setting is a flag's value from a config file or a preset.
+type setting struct {
+	name, value string
+	pos         string // Where the setting is from, for errors
+}
+
+// config is the contents of a config file: defaults for flags, and presets.
+type config struct {
+	settings []setting
+	presets  map[string][]setting
+}
+
+// loadConfig reads the first config file that exists. With no config file, the
+// config is empty.
+//
+// Each line of the file sets a flag by name, as "name = value" in TOML or
+// "name: value" in YAML, e.g. "dither = floyd-steinberg"; underscores in names
+// may stand for dashes. Settings in a "[preset.NAME]" table in TOML, or indented
+// under "preset.NAME:" in YAML, define a preset or override the settings of a
+// built-in one. Blank lines and # comments are skipped.
+func loadConfig() (*config, error) {
+	for _, path := range configPaths() {
+		f, err := os.Open(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+		return parseConfig(f, path, filepath.Ext(path) == ".toml")
+	}
+	return &config{}, nil
+}
+
+// parseConfig parses a config file in TOML, or else YAML.
+func parseConfig(r io.Reader, path string, toml bool) (*config, error) {
+	c := &config{presets: map[string][]setting{}}
+	sep := ":"
+	if toml {
+		sep = "="
+	}
+	section := ""
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		pos := fmt.Sprintf("%s:%d", path, n)
+		raw := sc.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") || line == "---" {
+			continue
+		}
+
+		indented := strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t")
+		switch {
+		case toml && strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		case !toml && !indented:
+			section = ""
+			if name, ok := strings.CutSuffix(line, ":"); ok {
+				section = strings.TrimSpace(name)
+				continue
+			}
+		}
+
+		name, value, ok := strings.Cut(line, sep)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected name %s value", pos, sep)
+		}
+		value, err := configValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", pos, err)
+		}
+		s := setting{strings.ReplaceAll(strings.TrimSpace(name), "_", "-"), value, pos}
+		if section == "" {
+			c.settings = append(c.settings, s)
+		} else if preset, ok := strings.CutPrefix(section, "preset."); ok {
+			c.presets[preset] = append(c.presets[preset], s)
+		} else {
+			return nil, fmt.Errorf("%s: unknown section %q", pos, section)
+		}
+	}
+	return c, sc.Err()
+}
+
+// configValue returns a value from a config file without its quotes, if it's a
+// quoted string, or else without any trailing comment.
+func configValue(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		end := strings.LastIndex(s, `"`)
+		if end == 0 {
+			return "", fmt.Errorf("unterminated string %s", s)
+		}
+		return strconv.Unquote(s[:end+1])
+	case strings.HasPrefix(s, "'"):
+		end := strings.LastIndex(s, "'")
+		if end == 0 {
+			return "", fmt.Errorf("unterminated string %s", s)
+		}
+		return s[1:end], nil
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return s, nil
+}
+
+// apply sets the flags of fs from the config's settings, and then from the
+// preset named by the -preset flag, if any, so a preset overrides the config's
+// defaults. Flags set on the command line override both.
+func (c *config) apply(fs *flag.FlagSet) error {
+	given := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	set := func(settings []setting) error {
+		for _, s := range settings {
+			if given[s.name] {
+				continue
+			}
+			if fs.Lookup(s.name) == nil {
+				return fmt.Errorf("%s: unknown setting %q", s.pos, s.name)
+			}
+			if err := fs.Set(s.name, s.value); err != nil {
+				return fmt.Errorf("%s: %s: %v", s.pos, s.name, err)
+			}
+		}
+		return nil
+	}
+
+	if err := set(c.settings); err != nil {
+		return err
+	}
+	name := fs.Lookup("preset").Value.String()
+	if name == "" {
+		return nil
+	}
+	builtin, ok := presets[name]
+	custom, defined := c.presets[name]
+	if !ok && !defined {
+		return fmt.Errorf("unknown preset %q (expected one of %s)", name, strings.Join(c.presetNames(), ", "))
+	}
+	if err := set(builtin); err != nil {
+		return err
+	}
+	return set(custom)
+}
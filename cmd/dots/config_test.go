@@ -0,0 +1,211 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newRenderFlagSet returns a FlagSet with render's flags.
+func newRenderFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	renderFlags(fs, false)
+	return fs
+}
+
+// settingStrings returns settings as name=value strings, for comparison.
+func settingStrings(settings []setting) []string {
+	var s []string
+	for _, setting := range settings {
+		s = append(s, setting.name+"="+setting.value)
+	}
+	return s
+}
+
+func TestParseConfig(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		toml        bool
+		text        string
+		want        []string
+		wantPresets map[string][]string
+		wantErr     string
+	}{{
+		desc: "toml",
+		toml: true,
+		text: "dither = sierra\nwidth = 60\n",
+		want: []string{"dither=sierra", "width=60"},
+	}, {
+		desc: "yaml",
+		text: "---\ndither: sierra\nwidth: 60\n",
+		want: []string{"dither=sierra", "width=60"},
+	}, {
+		desc: "comments and blank lines",
+		toml: true,
+		text: "# Defaults\n\n  # indented\ndither = sierra # for photos\ntint = #002b36,#fdf6e3\n",
+		want: []string{"dither=sierra", "tint=#002b36,#fdf6e3"},
+	}, {
+		desc: "double-quoted values with escapes",
+		toml: true,
+		text: `caption = "say \"hi\"\tthere" # comment` + "\n" + `tint = "#002b36,#fdf6e3"`,
+		want: []string{"caption=say \"hi\"\tthere", "tint=#002b36,#fdf6e3"},
+	}, {
+		desc: "single-quoted values are literal",
+		text: `caption: 'C:\dots # not a comment' # comment`,
+		want: []string{`caption=C:\dots # not a comment`},
+	}, {
+		desc: "underscores stand for dashes",
+		toml: true,
+		text: "auto_levels = true\nblock-color = majority\n",
+		want: []string{"auto-levels=true", "block-color=majority"},
+	}, {
+		desc:        "toml presets",
+		toml:        true,
+		text:        "dither = sierra\n\n[preset.comic]\nthreshold = 90\nno_color = true\n\n[preset.photo]\nsharpen = 1\n",
+		want:        []string{"dither=sierra"},
+		wantPresets: map[string][]string{"comic": {"threshold=90", "no-color=true"}, "photo": {"sharpen=1"}},
+	}, {
+		desc:        "yaml presets",
+		text:        "preset.comic:\n  threshold: 90\n\tno_color: true\ndither: sierra\npreset.photo:\n  sharpen: 1\n",
+		want:        []string{"dither=sierra"},
+		wantPresets: map[string][]string{"comic": {"threshold=90", "no-color=true"}, "photo": {"sharpen=1"}},
+	}, {
+		desc:    "unknown section",
+		toml:    true,
+		text:    "[colors]\nred = 1\n",
+		wantErr: `config.toml:2: unknown section "colors"`,
+	}, {
+		desc:    "missing separator",
+		toml:    true,
+		text:    "dither = sierra\ndither sierra\n",
+		wantErr: "config.toml:2: expected name = value",
+	}, {
+		desc:    "yaml separator in toml",
+		toml:    true,
+		text:    "dither: sierra\n",
+		wantErr: "config.toml:1: expected name = value",
+	}, {
+		desc:    "unterminated string",
+		toml:    true,
+		text:    `caption = "oops`,
+		wantErr: "config.toml:1: unterminated string",
+	}, {
+		desc:    "invalid escape",
+		toml:    true,
+		text:    `caption = "\q"`,
+		wantErr: "config.toml:1:",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c, err := parseConfig(strings.NewReader(tt.text), "config.toml", tt.toml)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseConfig() = %v", err)
+			}
+			if got := settingStrings(c.settings); !slices.Equal(got, tt.want) {
+				t.Errorf("settings = %q, want %q", got, tt.want)
+			}
+			if len(c.presets) != len(tt.wantPresets) {
+				t.Errorf("presets = %v, want %v", c.presets, tt.wantPresets)
+			}
+			for name, want := range tt.wantPresets {
+				if got := settingStrings(c.presets[name]); !slices.Equal(got, want) {
+					t.Errorf("preset %s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigApply(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		config  string // TOML
+		args    []string
+		want    map[string]string
+		wantErr string
+	}{{
+		desc:   "config sets defaults",
+		config: "dither = sierra\nwidth = 60\n",
+		want:   map[string]string{"dither": "sierra", "width": "60", "threshold": "20"},
+	}, {
+		desc:   "booleans",
+		config: "invert = true\ndetect_background = false\nno-color = 1\n",
+		want:   map[string]string{"invert": "true", "detect-background": "false", "no-color": "true"},
+	}, {
+		desc:   "flags override the config",
+		config: "dither = sierra\ninvert = true\n",
+		args:   []string{"-dither", "jjn", "-invert=false"},
+		want:   map[string]string{"dither": "jjn", "invert": "false"},
+	}, {
+		desc:   "a preset overrides the config",
+		config: "threshold = 50\ndither = sierra\n",
+		args:   []string{"-preset", "lineart"},
+		want:   map[string]string{"threshold": "128", "dither": "sierra", "invert": "true"},
+	}, {
+		desc:   "flags override a preset",
+		config: "threshold = 50\n",
+		args:   []string{"-preset", "lineart", "-threshold", "90"},
+		want:   map[string]string{"threshold": "90", "invert": "true"},
+	}, {
+		desc:   "the config overrides a built-in preset",
+		config: "[preset.photo]\nsharpen = 1\n",
+		args:   []string{"-preset", "photo"},
+		want:   map[string]string{"sharpen": "1", "dither": "floyd-steinberg"},
+	}, {
+		desc:   "presets from the config",
+		config: "[preset.comic]\nthreshold = 90\nno_color = true\n",
+		args:   []string{"-preset", "comic"},
+		want:   map[string]string{"threshold": "90", "no-color": "true"},
+	}, {
+		desc:    "unknown setting",
+		config:  "dither = sierra\nbogus = 1\n",
+		wantErr: `config.toml:2: unknown setting "bogus"`,
+	}, {
+		desc:    "unknown setting in a preset",
+		config:  "[preset.comic]\nbogus = 1\n",
+		args:    []string{"-preset", "comic"},
+		wantErr: `config.toml:2: unknown setting "bogus"`,
+	}, {
+		desc:    "invalid value",
+		config:  "width = wide\n",
+		wantErr: "config.toml:1: width:",
+	}, {
+		desc:    "unknown preset",
+		args:    []string{"-preset", "nope"},
+		wantErr: `unknown preset "nope"`,
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c, err := parseConfig(strings.NewReader(tt.config), "config.toml", true)
+			if err != nil {
+				t.Fatalf("parseConfig() = %v", err)
+			}
+			fs := newRenderFlagSet()
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err = c.apply(fs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("apply() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("apply() = %v", err)
+			}
+			for name, want := range tt.want {
+				if got := fs.Lookup(name).Value.String(); got != want {
+					t.Errorf("-%s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
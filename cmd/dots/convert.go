@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/imjasonh/dots"
+)
+
+// convertMain implements "dots convert", which converts many images to files in
+// an output directory in parallel, for generating assets in build pipelines.
+func convertMain(args []string) {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	var (
+		format    = fs.String("format", "ans", "Output format: ans, txt, html, or png")
+		outDir    = fs.String("o", "", "Directory to write the converted images to, created if needed (required)")
+		width     = fs.Int("width", 80, "Output width in characters")
+		height    = fs.Int("height", 0, "Output height in characters (default: from the width and the image's aspect ratio)")
+		noColor   = fs.Bool("no-color", false, "Disable ANSI colors")
+		dither    = fs.String("dither", "none", "Dithering algorithm: none, floyd-steinberg, sierra, sierra-lite, jjn, or blue-noise")
+		filesFrom = fs.String("files-from", "", "Also read image paths from this file, one per line, or from stdin if it's -")
+		jobs      = fs.Int("j", runtime.NumCPU(), "Number of images to convert at once")
+		verboseF  = fs.Bool("verbose", false, "Log each image as it's decoded and written")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s convert [flags] -o dir <image>...\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Converts each image to a file of the same name in dir, with the format's extension.\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	verbose = *verboseF
+
+	paths := fs.Args()
+	if *filesFrom != "" {
+		list, err := readPaths(*filesFrom)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to read files-from: %v\n", err)
+			os.Exit(exitError)
+		}
+		paths = append(paths, list...)
+	}
+	if len(paths) < 1 || *outDir == "" {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	switch *format {
+	case "ans", "txt", "html", "png":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (expected ans, txt, html, or png)\n", *format)
+		os.Exit(exitUsage)
+	}
+	if *width < 0 || *height < 0 || *jobs < 1 {
+		fmt.Fprintf(os.Stderr, "Error: -width and -height must not be negative, and -j must be positive\n")
+		os.Exit(exitUsage)
+	}
+	ditherAlgorithm, err := dots.ParseDitherAlgorithm(*dither)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	if *width == 0 && *height == 0 {
+		*width = 80
+	}
+	opts := dots.Options{
+		Width:           *width,
+		Height:          *height,
+		NoColor:         *noColor || *format == "txt",
+		DitherAlgorithm: ditherAlgorithm,
+	}
+
+	outputs, err := outputPaths(paths, *outDir, *format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	queue := make(chan string)
+	var wg sync.WaitGroup
+	var mu sync.Mutex // Guards failed, and keeps error lines whole
+	failed := 0
+	for range min(*jobs, len(paths)) {
+		wg.Go(func() {
+			for path := range queue {
+				if err := convertFile(path, outputs[path], opts, *format); err != nil {
+					mu.Lock()
+					fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+					failed++
+					mu.Unlock()
+				}
+			}
+		})
+	}
+	for _, path := range paths {
+		queue <- path
+	}
+	close(queue)
+	wg.Wait()
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d images failed to convert\n", failed, len(paths))
+		os.Exit(exitError)
+	}
+}
+
+// outputPaths maps each image path to the file in dir it's converted to: its base
+// name with the format's extension. Two images can't share a name, so no output
+// is written twice.
+func outputPaths(paths []string, dir, format string) (map[string]string, error) {
+	outputs := make(map[string]string, len(paths))
+	sources := make(map[string]string, len(paths))
+	for _, path := range paths {
+		name := filepath.Base(path)
+		out := filepath.Join(dir, strings.TrimSuffix(name, filepath.Ext(name))+"."+format)
+		if other, ok := sources[out]; ok {
+			return nil, fmt.Errorf("%s and %s would both be converted to %s", other, path, out)
+		}
+		sources[out] = path
+		outputs[path] = out
+	}
+	return outputs, nil
+}
+
+// convertFile converts the image at path, writing it to out in the given format.
+// A partly written file is removed.
+func convertFile(path, out string, opts dots.Options, format string) error {
+	img, _, err := loadImage(path)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := write(f, img, opts, format); err != nil {
+		_ = f.Close()
+		_ = os.Remove(out)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(out)
+		return err
+	}
+	logf("%s: wrote %s", path, out)
+	return nil
+}
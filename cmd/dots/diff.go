@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"image"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/imjasonh/dots"
+)
+
+// diffSummary is the machine-readable result of "dots diff -json".
+type diffSummary struct {
+	Width    int     `json:"width"`
+	Height   int     `json:"height"`
+	Changed  int     `json:"changed"`
+	Fraction float64 `json:"fraction"`
+	MaxDelta uint8   `json:"max_delta"`
+	Bounds   *[4]int `json:"bounds"` // x0, y0, x1, y1 of the changes, or null
+	Differs  bool    `json:"differs"`
+}
+
+// diffMain implements "dots diff", which shows where two images differ. Like
+// diff(1), it exits with 0 if they match, 1 if they differ, and 2 on errors.
+func diffMain(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	var (
+		width     = fs.Int("width", 0, "Output width in characters (default: terminal width)")
+		height    = fs.Int("height", 0, "Output height in characters (default: terminal height)")
+		noColor   = fs.Bool("no-color", false, "Disable ANSI colors")
+		tolerance = fs.Int("tolerance", 0, "Largest difference in any channel (0-255) that doesn't count as a change")
+		allow     = fs.String("allow", "0%", "Percentage of pixels that may change before the images differ, e.g. 0.5%")
+		heat      = fs.Bool("heat", false, "Color changes by magnitude, from blue to red to yellow")
+		crop      = fs.Bool("crop", false, "Show only the region bounding the changes")
+		jsonOut   = fs.Bool("json", false, "Print a JSON summary instead of the picture")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s diff [flags] a.png b.png\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Shows the pixels where two images differ, then a summary. Exits with 0 if they\n")
+		fmt.Fprintf(os.Stderr, "match, 1 if they differ, and 2 on errors.\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	fail := func(format string, a ...any) {
+		fmt.Fprintf(os.Stderr, "Error: "+format+"\n", a...)
+		os.Exit(2)
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if *tolerance < 0 || *tolerance > 255 {
+		fail("tolerance must be between 0 and 255")
+	}
+	allowed, err := strconv.ParseFloat(strings.TrimSuffix(*allow, "%"), 64)
+	if err != nil || allowed < 0 || allowed > 100 {
+		fail("allow must be a percentage between 0%% and 100%%")
+	}
+
+	var imgs [2]image.Image
+	for i, path := range fs.Args() {
+		if imgs[i], _, err = loadImage(path); err != nil {
+			fail("%s: %v", path, err)
+		}
+	}
+	d := dots.DiffImages(imgs[0], imgs[1], uint8(*tolerance))
+	differs := d.Fraction()*100 > allowed
+
+	if *jsonOut {
+		b := d.Delta.Bounds()
+		summary := diffSummary{
+			Width:    b.Dx(),
+			Height:   b.Dy(),
+			Changed:  d.Changed,
+			Fraction: d.Fraction(),
+			MaxDelta: d.MaxDelta,
+			Differs:  differs,
+		}
+		if r := d.Changes; !r.Empty() {
+			summary.Bounds = &[4]int{r.Min.X, r.Min.Y, r.Max.X, r.Max.Y}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(summary); err != nil {
+			fail("%v", err)
+		}
+	} else {
+		var img image.Image = d.Image(*heat)
+		if *crop && !d.Changes.Empty() {
+			img = img.(*image.RGBA).SubImage(d.Changes)
+		}
+		// Light exactly the changed pixels, in their own colors
+		opts := dots.Options{Width: *width, Height: *height, NoColor: *noColor, Threshold: 1, ColorLitDots: true}
+		for _, line := range dots.Convert(img, opts) {
+			fmt.Println(line)
+		}
+		fmt.Printf("%d of %d pixels changed (%.2f%%), max difference %d", d.Changed, d.Delta.Bounds().Dx()*d.Delta.Bounds().Dy(), d.Fraction()*100, d.MaxDelta)
+		if !d.Changes.Empty() {
+			fmt.Printf(", within %v", d.Changes)
+		}
+		fmt.Println()
+	}
+	if differs {
+		os.Exit(1)
+	}
+}
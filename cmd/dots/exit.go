@@ -0,0 +1,32 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/imjasonh/dots"
+)
+
+// Exit codes, so scripts and preview wrappers can tell failures apart. The flag
+// package also exits with exitUsage for flags it can't parse.
+const (
+	exitError             = 1 // Any other failure
+	exitUsage             = 2 // Invalid flags or arguments
+	exitUnsupportedFormat = 3 // The image's format isn't supported
+	exitDecode            = 4 // The image is corrupt or truncated
+	exitTerminalTooSmall  = 5 // The terminal has no room for the output
+)
+
+// exitCode returns the exit code for an error from rendering.
+func exitCode(err error) int {
+	var decodeErr *dots.DecodeError
+	switch {
+	case errors.Is(err, dots.ErrUnsupportedFormat):
+		return exitUnsupportedFormat
+	case errors.As(err, &decodeErr):
+		return exitDecode
+	case errors.Is(err, dots.ErrTerminalTooSmall):
+		return exitTerminalTooSmall
+	default:
+		return exitError
+	}
+}
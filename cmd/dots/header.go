@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+
+	"github.com/imjasonh/dots"
+)
+
+// imageHeader describes the image at path for -label: its file name, original
+// size and format, and the size it's rendered at in characters, like
+// "photo.jpg 1920×1080 jpeg → 80×27".
+func imageHeader(path string, img image.Image, opts dots.Options) string {
+	b := img.Bounds()
+	header := fmt.Sprintf("%s %d×%d", filepath.Base(path), b.Dx(), b.Dy())
+	if f, err := os.Open(path); err == nil {
+		if _, format, err := image.DecodeConfig(f); err == nil {
+			header += " " + format
+		}
+		_ = f.Close()
+	}
+	w, h := opts.OutputSize(img)
+	return fmt.Sprintf("%s → %d×%d", header, w, h)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+	"sync"
+)
+
+// stdinKeys returns the keys typed on stdin, in the chunks they're read in. One
+// goroutine reads them for the life of the process, so features that read keys
+// one after another, like animation playback and -confirm, share it instead of
+// each leaving a reader blocked on stdin to steal the next one's keys. The
+// channel is closed when stdin ends.
+var stdinKeys = sync.OnceValue(func() <-chan string {
+	keys := make(chan string)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 256)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				return
+			}
+			keys <- string(buf[:n])
+		}
+	}()
+	return keys
+})
@@ -1,94 +1,934 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"image"
+	"image/color"
 	_ "image/gif"
 	_ "image/jpeg"
-	_ "image/png"
+	"image/png"
+	"io"
+	"net/url"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/imjasonh/dots"
+	"golang.org/x/term"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		for _, c := range commands() {
+			if os.Args[1] == c.name {
+				c.run(os.Args[2:])
+				return
+			}
+		}
+	}
+	renderMain(os.Args[1:])
+}
+
+// renderMain implements "dots render", the default subcommand, which converts
+// images and writes them to the terminal or a file, playing animations.
+func renderMain(args []string) {
+	runRender(args, false)
+}
+
+// playMain implements "dots play", which is render for one animation or stream on
+// the terminal, failing for still images.
+func playMain(args []string) {
+	runRender(args, true)
+}
+
+// runRender parses render's flags from args and renders the images; with playOnly,
+// they must be an animation played on the terminal.
+func runRender(args []string, playOnly bool) {
+	renderFlags(flag.CommandLine, playOnly)(args)
+}
+
+// fileFlags are render's flags that take a file name, which shells complete as
+// paths.
+var fileFlags = map[string]bool{
+	"output":        true,
+	"o":             true,
+	"palette-file":  true,
+	"system-colors": true,
+	"files-from":    true,
+}
+
+// renderFlags defines render's flags on fs, and returns the function that parses
+// them from args and renders the images.
+func renderFlags(fs *flag.FlagSet, playOnly bool) func(args []string) {
 	var (
-		width      = flag.Int("width", 0, "Output width in characters (default: terminal width)")
-		height     = flag.Int("height", 0, "Output height in characters (default: terminal height)")
-		w          = flag.Int("w", 0, "Short form of -width")
-		h          = flag.Int("h", 0, "Short form of -height")
-		noColor    = flag.Bool("no-color", false, "Disable ANSI colors")
-		background = flag.String("background", "", "Background color as hex (e.g., 'ff0000' for red, enables ANSI background)")
-		threshold  = flag.Int("threshold", 20, "Brightness threshold (0-255)")
-		t          = flag.Int("t", 0, "Short form of -threshold")
-		frame      = flag.Bool("frame", false, "Draw a white ASCII frame around the picture")
+		width      = fs.Int("width", 0, "Output width in characters (default: terminal width)")
+		height     = fs.Int("height", 0, "Output height in characters (default: terminal height)")
+		w          = fs.Int("w", 0, "Short form of -width")
+		h          = fs.Int("h", 0, "Short form of -height")
+		noColor    = fs.Bool("no-color", false, "Disable ANSI colors")
+		background = fs.String("background", "", "Background color as hex (e.g., 'ff0000' for red, enables ANSI background)")
+		threshold  = fs.String("threshold", "20", "Brightness threshold (0-255), or a percentile of the image's brightness like p50")
+		t          = fs.String("t", "", "Short form of -threshold")
+		frame      = fs.Bool("frame", false, "Draw a white frame around the picture")
+		frameTitle = fs.String("frame-title", "", "Title written into the top border of the frame, e.g. the file name (implies -frame)")
+		frameStyle = fs.String("frame-style", "single", "Frame style: single, double, rounded, or ascii")
+		alignFlag  = fs.String("align", "left", "Horizontal alignment in the terminal: left, center, or right (center also centers -interactive vertically)")
+		sampleBg   = fs.Bool("sample-background", false, "Color each cell's background from the image's dark pixels")
+		output     = fs.String("output", "", "Write output to a file instead of stdout")
+		o          = fs.String("o", "", "Short form of -output")
+		sixDot     = fs.Bool("six-dot", false, "Use 6-dot braille (2×3 dots per character) for fonts where 8-dot patterns render poorly")
+		dither     = fs.String("dither", "none", "Dithering algorithm: none, floyd-steinberg, sierra, sierra-lite, jjn, or blue-noise")
+		colorDith  = fs.Bool("color-dither", false, "Diffuse color quantization error between cells to reduce color banding")
+		serpentine = fs.Bool("serpentine", false, "Alternate the scan direction per row when dithering with error diffusion")
+		edges      = fs.Bool("edges", false, "Render only edges, found with Sobel edge detection")
+		canny      = fs.Bool("canny", false, "Render only edges, found with Canny edge detection (better for photos than -edges)")
+		cannyLow   = fs.Int("canny-low", 24, "Canny weak edge threshold (0-255)")
+		cannyHigh  = fs.Int("canny-high", 64, "Canny strong edge threshold (0-255)")
+		invert     = fs.Bool("invert", false, "Light dark pixels instead of bright ones, for light-background terminals")
+		detectBg   = fs.Bool("detect-background", true, "Query the terminal's background color (OSC 11) and adapt to light themes")
+		autoLevels = fs.Bool("auto-levels", false, "Stretch the image's brightness to the full range, for low-contrast images")
+		brightness = fs.Float64("brightness", 0, "Brightness adjustment (-1 to 1)")
+		contrast   = fs.Float64("contrast", 0, "Contrast adjustment (-1 to 1)")
+		sharpenAmt = fs.Float64("sharpen", 0, "Unsharp mask amount to recover fine detail (e.g. 1.0, 0 disables)")
+		blur       = fs.Float64("blur", 0, "Gaussian blur radius in dots to suppress noise (e.g. 0.8, 0 disables)")
+		cellAspect = fs.Float64("cell-aspect", 0, "Width/height ratio of a terminal cell (default: detected from the terminal, else 0.5)")
+		matte      = fs.String("matte", "", "Color as hex to composite transparent pixels over (default: detected terminal background, else black)")
+		transp     = fs.Bool("transparent", false, "Leave fully transparent cells blank and uncolored")
+		lumaFlag   = fs.String("luma", "rec601", "Luminance formula: rec601, rec709 (screenshots, video), or r,g,b weights")
+		palette    = fs.String("palette", "none", "Adaptive palette mode: none, median-cut, or k-means (locked to the image's dominant colors)")
+		paletteN   = fs.Int("palette-size", 16, "Number of colors in an adaptive palette")
+		paletteF   = fs.String("palette-file", "", "Quantize colors to a palette file: hex colors or a GIMP .gpl palette")
+		systemF    = fs.String("system-colors", "", "Also quantize to the terminal's 16 system colors: 'query' (OSC 4) or a palette file of 16 colors")
+		blockColor = fs.String("block-color", "average", "How cell colors are chosen: average or majority (crisper for text and logos)")
+		colorLit   = fs.Bool("color-lit", false, "Color cells from only their lit dots, so dark pixels don't dim bright features")
+		grayscale  = fs.Bool("grayscale", false, "Restrict colors to the ANSI grayscale ramp")
+		tint       = fs.String("tint", "", "Map brightness onto a gradient between two hex colors (e.g. '#002b36,#fdf6e3'), or 'sepia'")
+		despeckle  = fs.Bool("despeckle", false, "Remove isolated single dots to reduce noise")
+		posterize  = fs.Int("posterize", 0, "Reduce each color channel to this many levels (e.g. 4, 0 disables)")
+		caption    = fs.String("caption", "", "Label to draw in small letters along the bottom of the picture")
+		labelF     = fs.Bool("label", false, "Print a header line with the file name, original size and format, and rendered size above the picture")
+		link       = fs.Bool("hyperlink", false, "Make the output a clickable OSC 8 link to the source image")
+		loop       = fs.Int("loop", 0, "Number of times an animated GIF repeats after playing once; 0 loops forever, -1 plays once (default: from the GIF)")
+		speed      = fs.String("speed", "1x", "Animation playback speed multiplier, e.g. 1.5x")
+		frameAt    = fs.String("frame-at", "", "Render one frame of an animation instead of playing it: an index from 0, or a position like 50%")
+		fps        = fs.Float64("fps", 10, "Frames per second decoded from rtsp:// camera streams")
+		stack      = fs.Bool("stack", false, "Print every frame of an animation one after another with captions, e.g. for logs and CI")
+		progressOn = fs.Bool("progress", false, "Show a progress bar under animations (toggle with p during playback)")
+		pingPong   = fs.Bool("pingpong", false, "Play animations forward, then backward")
+		progress   = fs.Int("progressive", 0, "Draw every Nth cell first, then refine, for slow connections (e.g. 4, 0 disables)")
+		interact   = fs.Bool("interactive", false, "View the image full screen, panning with arrow keys, hjkl, or mouse drags and zooming with +, -, or the mouse wheel")
+		tuneF      = fs.Bool("tune", false, "Tune the picture full screen: arrow keys or hjkl adjust the threshold and dithering, c switches color modes, and the flags for the result are printed on exit")
+		slideDur   = fs.Duration("slide-duration", 5*time.Second, "How long each image of a slideshow is shown")
+		transition = fs.String("transition", "cut", "Slideshow transition: cut, wipe, or dissolve")
+		watch      = fs.Bool("watch", false, "Re-render whenever the image file changes, until interrupted")
+		recursive  = fs.Bool("r", false, "Render each image in the given directories and their subdirectories in turn, captioned with its path")
+		pause      = fs.Duration("pause", 0, "With -r, how long to wait between images")
+		confirm    = fs.Bool("confirm", false, "With -r, wait for a key between images; q quits")
+		previewF   = fs.Bool("preview", false, "Render for a file manager's preview pane (lf, ranger, fzf), sized by the width and height after the file or FZF_PREVIEW_COLUMNS and FZF_PREVIEW_LINES, without querying the terminal")
+		chat       = fs.Bool("chat", false, "Write plain text for chat bots: 40 characters wide unless -width is given, spaces as blank braille, and messages separated by blank lines")
+		chatLimit  = fs.Int("chat-limit", dots.DiscordMessageLimit, "With -chat, the most characters in a message, e.g. 2000 for Discord")
+		filesFrom  = fs.String("files-from", "", "Also read image paths from this file, one per line, or from stdin if it's -, e.g. from find")
+		format     = fs.String("format", "", "Output format: ans, txt, html, png, or ndjson (default: from -output extension, else ans)")
+		from       = fs.String("from", "", "Show frames rendered by another dots process instead of images: ndjson, from the file given or stdin")
+		verboseF   = fs.Bool("verbose", false, "Log the image format and size, output size, terminal, and timings to stderr")
+		v          = fs.Bool("v", false, "Short form of -verbose")
+		versionF   = fs.Bool("version", false, "Print the version, commit, and build date, then exit")
 	)
+	// Applied along with the config file, after parsing
+	fs.String("preset", "", "Bundle of flags suited to a kind of image: photo, lineart, screenshot, pixelart, or one from the config file")
+
+	return func(args []string) {
+		_ = fs.Parse(args)
+		if *versionF {
+			fmt.Println(version())
+			return
+		}
+		cfg, err := loadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to load config: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		if err := cfg.apply(fs); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+
+		args = fs.Args()
+		if *filesFrom != "" {
+			paths, err := readPaths(*filesFrom)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to read files-from: %v\n", err)
+				os.Exit(exitError)
+			}
+			args = append(args, paths...)
+		}
+
+		// Frames from -format ndjson are already rendered, so rendering flags don't apply
+		if *from != "" {
+			if *from != "ndjson" {
+				fmt.Fprintf(os.Stderr, "Error: unknown input format %q (expected ndjson)\n", *from)
+				os.Exit(exitUsage)
+			}
+			if len(args) > 1 || *filesFrom != "" || *output != "" || *o != "" || *format != "" || *recursive || *watch || *interact || *tuneF || *chat || *previewF {
+				fmt.Fprintf(os.Stderr, "Error: -from ndjson shows one file or stdin, and can't be combined with -files-from, -output, -format, -r, -watch, -interactive, -tune, -chat, or -preview\n")
+				os.Exit(exitUsage)
+			}
+			path := ""
+			if len(args) == 1 {
+				path = args[0]
+			}
+			if err := fromNDJSON(path); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(exitError)
+			}
+			return
+		}
+
+		if len(args) < 1 {
+			writeUsage(os.Stderr)
+			fs.PrintDefaults()
+			os.Exit(exitUsage)
+		}
+
+		// File managers pass the preview pane's size after the file
+		var previewWidth, previewHeight int
+		if *previewF {
+			if *recursive || *watch || *interact || *tuneF || *chat || *output != "" || *filesFrom != "" || (*format != "" && *format != "ans" && *format != "txt") {
+				fmt.Fprintf(os.Stderr, "Error: -preview writes ans or txt to stdout, and can't be combined with -r, -watch, -interactive, -tune, -chat, -output, or -files-from\n")
+				os.Exit(exitUsage)
+			}
+			previewWidth, previewHeight, err = previewSize(args)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(exitUsage)
+			}
+			args = args[:1]
+		}
+
+		imagePath := args[0]
+
+		// Resolve short flags
+		if *w > 0 {
+			width = w
+		}
+		if *h > 0 {
+			height = h
+		}
+		if *t != "" {
+			threshold = t
+		}
+		if *o != "" {
+			output = o
+		}
+		verbose = *verboseF || *v
+		logTerminal()
+
+		// Pick the output format, inferring it from the output file extension if not given
+		if *format == "" {
+			*format = formatFromPath(*output)
+		}
+		switch *format {
+		case "ans", "txt", "html", "png", "ndjson":
+		default:
+			fmt.Fprintf(os.Stderr, "Error: unknown format %q (expected ans, txt, html, png, or ndjson)\n", *format)
+			os.Exit(exitUsage)
+		}
+
+		// Chat clients show narrow messages, and no colors
+		if *chat {
+			if *format != "ans" && *format != "txt" {
+				fmt.Fprintf(os.Stderr, "Error: chat output is text; it can't be written as %s\n", *format)
+				os.Exit(exitUsage)
+			}
+			if *interact || *tuneF || *stack || *progress > 0 {
+				fmt.Fprintf(os.Stderr, "Error: -chat can't be combined with -interactive, -tune, -stack, or -progressive\n")
+				os.Exit(exitUsage)
+			}
+			if *chatLimit < 1 {
+				fmt.Fprintf(os.Stderr, "Error: chat-limit must be positive\n")
+				os.Exit(exitUsage)
+			}
+			if *width == 0 && *height == 0 {
+				*width = 40
+			}
+			*format = "txt"
+		}
+
+		// When writing to a file, the terminal size is irrelevant: fall back to a fixed width
+		if *output != "" && *width == 0 && *height == 0 {
+			*width = 80
+		}
+
+		// Validate threshold, either a brightness level or a percentile like "p50"
+		var thresholdLevel int
+		var thresholdPercentile float64
+		if p, ok := strings.CutPrefix(*threshold, "p"); ok {
+			pct, err := strconv.ParseFloat(p, 64)
+			if err != nil || pct <= 0 || pct >= 100 {
+				fmt.Fprintf(os.Stderr, "Error: threshold percentile must be between p0 and p100, exclusive\n")
+				os.Exit(exitUsage)
+			}
+			thresholdPercentile = pct
+		} else {
+			level, err := strconv.Atoi(*threshold)
+			if err != nil || level < 0 || level > 255 {
+				fmt.Fprintf(os.Stderr, "Error: threshold must be between 0 and 255\n")
+				os.Exit(exitUsage)
+			}
+			thresholdLevel = level
+		}
+
+		if *brightness < -1 || *brightness > 1 || *contrast < -1 || *contrast > 1 {
+			fmt.Fprintf(os.Stderr, "Error: brightness and contrast must be between -1 and 1\n")
+			os.Exit(exitUsage)
+		}
+
+		if *sharpenAmt < 0 || *blur < 0 {
+			fmt.Fprintf(os.Stderr, "Error: sharpen and blur must not be negative\n")
+			os.Exit(exitUsage)
+		}
+
+		if *posterize == 1 || *posterize < 0 {
+			fmt.Fprintf(os.Stderr, "Error: posterize must be at least 2 levels\n")
+			os.Exit(exitUsage)
+		}
+
+		style, err := dots.ParseFrameStyle(*frameStyle)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		align, err := dots.ParseAlignment(*alignFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+
+		slideTransition, err := dots.ParseTransition(*transition)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		if *recursive && (*watch || *interact || *output != "") {
+			fmt.Fprintf(os.Stderr, "Error: -r can't be combined with -watch, -interactive, or -output\n")
+			os.Exit(exitUsage)
+		}
+		if len(args) > 1 && !*recursive && (*watch || *interact || *output != "" || *format != "ans" || !term.IsTerminal(int(os.Stdout.Fd()))) {
+			fmt.Fprintf(os.Stderr, "Error: multiple images are shown as a slideshow, which needs a terminal and can't be combined with -watch, -interactive, or -output\n")
+			os.Exit(exitUsage)
+		}
+
+		if isStream(imagePath) && (*watch || len(args) > 1) {
+			fmt.Fprintf(os.Stderr, "Error: streams can't be watched or shown in a slideshow\n")
+			os.Exit(exitUsage)
+		}
+		if *fps <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: fps must be positive\n")
+			os.Exit(exitUsage)
+		}
+
+		if *stack && *format != "ans" && *format != "txt" {
+			fmt.Fprintf(os.Stderr, "Error: stacked frames can only be written as ans or txt\n")
+			os.Exit(exitUsage)
+		}
+
+		if *labelF && *format != "ans" && *format != "txt" {
+			fmt.Fprintf(os.Stderr, "Error: labels can only be written as ans or txt\n")
+			os.Exit(exitUsage)
+		}
+
+		if *interact && *output != "" {
+			fmt.Fprintf(os.Stderr, "Error: interactive mode can't write to a file\n")
+			os.Exit(exitUsage)
+		}
+
+		if playOnly && (len(args) > 1 || *recursive || *output != "" || *format != "ans" || *stack || *frameAt != "" || *interact || *tuneF || !term.IsTerminal(int(os.Stdout.Fd()))) {
+			fmt.Fprintf(os.Stderr, "Error: play shows one animation on a terminal, and can't be combined with -r, -output, -format, -stack, -frame-at, -interactive, or -tune\n")
+			os.Exit(exitUsage)
+		}
+
+		if *filesFrom == "-" && (*interact || *tuneF || *confirm) {
+			fmt.Fprintf(os.Stderr, "Error: -files-from - reads stdin, which -interactive, -tune, and -confirm need for keys\n")
+			os.Exit(exitUsage)
+		}
+
+		if *tuneF && (*interact || *watch || *recursive || *output != "" || len(args) > 1) {
+			fmt.Fprintf(os.Stderr, "Error: -tune shows one image and can't be combined with -interactive, -watch, -r, or -output\n")
+			os.Exit(exitUsage)
+		}
+
+		if *progress < 0 {
+			fmt.Fprintf(os.Stderr, "Error: progressive must not be negative\n")
+			os.Exit(exitUsage)
+		}
+
+		if *cellAspect < 0 {
+			fmt.Fprintf(os.Stderr, "Error: cell-aspect must not be negative\n")
+			os.Exit(exitUsage)
+		}
+
+		luma, err := dots.ParseLuma(*lumaFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+
+		blockColorMode, err := dots.ParseBlockColorMode(*blockColor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+
+		paletteMode, err := dots.ParsePaletteMode(*palette)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		if *paletteN < 1 {
+			fmt.Fprintf(os.Stderr, "Error: palette-size must be positive\n")
+			os.Exit(exitUsage)
+		}
+
+		ditherAlgorithm, err := dots.ParseDitherAlgorithm(*dither)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		edgeDetector := dots.EdgeNone
+		if *edges {
+			edgeDetector = dots.EdgeSobel
+		}
+		if *canny {
+			edgeDetector = dots.EdgeCanny
+		}
+		if *cannyLow < 1 || *cannyHigh > 255 || *cannyLow > *cannyHigh {
+			fmt.Fprintf(os.Stderr, "Error: canny thresholds must satisfy 1 <= canny-low <= canny-high <= 255\n")
+			os.Exit(exitUsage)
+		}
+
+		// Let the library pick a threshold suited to the other options unless one was given
+		if !isFlagSet(fs, "threshold") && !isFlagSet(fs, "t") {
+			thresholdLevel = 0
+		}
+
+		playOpts := dots.PlayOptions{PingPong: *pingPong, Progress: *progressOn}
+		if isFlagSet(fs, "loop") {
+			playOpts.LoopCount = loop
+		}
+		if playOpts.Speed, err = parseSpeed(*speed); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid speed: %v\n", err)
+			os.Exit(exitUsage)
+		}
+
+		// Parse background color if provided
+		var bgColor *uint8
+		if *background != "" {
+			ansiColor, err := dots.ParseHex(*background)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid background color: %v\n", err)
+				os.Exit(exitUsage)
+			}
+			bgColor = &ansiColor
+		}
+
+		// Parse matte color if provided
+		var matteColor color.Color
+		if *matte != "" {
+			c, err := dots.ParseHexColor(*matte)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid matte color: %v\n", err)
+				os.Exit(exitUsage)
+			}
+			matteColor = c
+		}
+
+		opts := dots.Options{
+			Width:               *width,
+			Height:              *height,
+			Threshold:           uint8(thresholdLevel),
+			ThresholdPercentile: thresholdPercentile,
+			NoColor:             *noColor || *format == "txt",
+			BackgroundColor:     bgColor,
+			Frame:               *frame || *frameTitle != "",
+			FrameStyle:          style,
+			FrameTitle:          *frameTitle,
+			Align:               align,
+
+			SampleBackground: *sampleBg,
+			SixDot:           *sixDot,
+			DitherAlgorithm:  ditherAlgorithm,
+			Serpentine:       *serpentine,
+			ColorDither:      *colorDith,
+			Edges:            edgeDetector,
+			CannyLow:         uint8(*cannyLow),
+			CannyHigh:        uint8(*cannyHigh),
+			Invert:           *invert,
+			AutoLevels:       *autoLevels,
+			Brightness:       *brightness,
+			Contrast:         *contrast,
+			Sharpen:          *sharpenAmt,
+			Blur:             *blur,
+			Despeckle:        *despeckle,
+			Posterize:        *posterize,
+			CellAspect:       *cellAspect,
+			Matte:            matteColor,
+			Transparent:      *transp,
+			Luma:             luma,
+			PaletteMode:      paletteMode,
+			PaletteSize:      *paletteN,
+			BlockColor:       blockColorMode,
+			ColorLitDots:     *colorLit,
+			Grayscale:        *grayscale,
+			Caption:          *caption,
+		}
+		if *paletteF != "" {
+			pf, err := os.Open(*paletteF)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open palette: %v\n", err)
+				os.Exit(exitError)
+			}
+			opts.Palette, err = dots.ParsePalette(pf)
+			_ = pf.Close()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid palette %s: %v\n", *paletteF, err)
+				os.Exit(exitUsage)
+			}
+		}
+		if *tint != "" {
+			opts.Tint, err = parseTint(*tint)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid tint: %v\n", err)
+				os.Exit(exitUsage)
+			}
+		}
+		if *systemF != "" {
+			opts.SystemColors, err = systemColors(*systemF)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: system colors: %v\n", err)
+				os.Exit(exitError)
+			}
+		}
+		if *link {
+			opts.Hyperlink = fileURL(imagePath)
+			if isStream(imagePath) {
+				opts.Hyperlink = imagePath
+			}
+		}
+
+		// The file manager owns the terminal, so render without consulting it
+		if *previewF {
+			if err := preview(imagePath, previewWidth, previewHeight, opts, *format); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			return
+		}
+
+		// Composite transparent pixels over the terminal background, and on a light
+		// background, light the dark pixels and draw a dark frame
+		if *detectBg && *output == "" && term.IsTerminal(int(os.Stdout.Fd())) {
+			bg, err := dots.QueryBackgroundColor(100 * time.Millisecond)
+			if err != nil {
+				logf("terminal: background color unknown: %v", err)
+			} else {
+				logf("terminal: background color #%02x%02x%02x, light: %v", bg.R, bg.G, bg.B, dots.IsLight(bg))
+				if opts.Matte == nil {
+					opts.Matte = bg
+				}
+				if dots.IsLight(bg) {
+					if !isFlagSet(fs, "invert") {
+						opts.Invert = true
+					}
+					black := uint8(16)
+					opts.FrameColor = &black
+				}
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		render := func(ctx context.Context) error {
+			// Sized to the terminal, the output needs room for at least a cell in its frame
+			if *output == "" && (*width == 0 || *height == 0) {
+				minSize := 1
+				if opts.Frame {
+					minSize = 3
+				}
+				if err := dots.CheckTerminalSize(minSize, minSize); err != nil {
+					return err
+				}
+			}
+
+			var img image.Image
+			var anim *dots.Animation
+			var err error
+			if isStream(imagePath) && *format == "ndjson" && *output == "" {
+				return writeStreamNDJSON(ctx, os.Stdout, imagePath, *fps, opts)
+			}
+			if isStream(imagePath) {
+				// Play live streams on the terminal; otherwise render a snapshot
+				play := *output == "" && *format == "ans" && term.IsTerminal(int(os.Stdout.Fd()))
+				if img, err = renderStream(ctx, imagePath, *fps, opts, play); err != nil || img == nil {
+					return err
+				}
+			} else if img, anim, err = loadImage(imagePath); err != nil {
+				return err
+			}
+			if playOnly && !isStream(imagePath) && (anim == nil || len(anim.Frames) < 2) {
+				return fmt.Errorf("%s is not an animation", imagePath)
+			}
+			if *frameAt != "" {
+				if img, err = selectFrame(img, anim, *frameAt); err != nil {
+					return err
+				}
+				anim = nil
+			}
+
+			if *interact {
+				return view(ctx, img, opts)
+			}
+			if *tuneF {
+				flags, err := tune(ctx, img, opts)
+				if err != nil {
+					return err
+				}
+				fmt.Println(flags)
+				return nil
+			}
+
+			// Open the output destination
+			var out io.Writer = os.Stdout
+			if *output != "" {
+				of, err := os.Create(*output)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer func() { _ = of.Close() }()
+				out = of
+			} else if *watch && term.IsTerminal(int(os.Stdout.Fd())) {
+				// Replace the previous render
+				_, _ = io.WriteString(out, "\x1b[H\x1b[2J")
+			}
+
+			// Write the still picture as chat messages, separated by blank lines, which
+			// can't occur within one since every line is padded with blank braille
+			if *chat {
+				messages, err := dots.ChatMessages(dots.Convert(img, opts), *chatLimit)
+				if err != nil {
+					return err
+				}
+				for i, msg := range messages {
+					if i > 0 {
+						fmt.Fprintln(out)
+					}
+					if _, err := fmt.Fprintln(out, msg); err != nil {
+						return fmt.Errorf("failed to write output: %w", err)
+					}
+				}
+				return nil
+			}
+
+			if *labelF && !isStream(imagePath) {
+				fmt.Fprintln(out, imageHeader(imagePath, img, opts))
+			}
+
+			// Print every frame for logs, or play animations on the terminal; otherwise render the first frame
+			if anim != nil && *stack {
+				if err := dots.WriteStacked(out, anim, opts); err != nil {
+					return fmt.Errorf("failed to write output: %w", err)
+				}
+				return nil
+			}
+			if anim != nil && len(anim.Frames) > 1 && *format == "ndjson" {
+				if err := dots.WriteNDJSON(out, anim, opts); err != nil {
+					return fmt.Errorf("failed to write output: %w", err)
+				}
+				return nil
+			}
+			if anim != nil && len(anim.Frames) > 1 && *output == "" && *format == "ans" && term.IsTerminal(int(os.Stdout.Fd())) {
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				play := playOpts
+				if !*watch {
+					// Read keys to toggle the progress bar and stop playback
+					var restore func()
+					play.ToggleProgress, restore = playbackKeys(cancel)
+					defer restore()
+				}
+				if err := dots.Play(ctx, out, anim, opts, play); err != nil && err != context.Canceled {
+					return fmt.Errorf("failed to play animation: %w", err)
+				}
+				return nil
+			}
+
+			// Draw a coarse version first, then refine it
+			if *progress > 1 && *format == "ans" {
+				if err := dots.RenderProgressive(out, img, opts, *progress); err != nil {
+					return fmt.Errorf("failed to write output: %w", err)
+				}
+				return nil
+			}
+
+			// Convert to dots and write in the requested format
+			if verbose {
+				w, h := opts.OutputSize(img)
+				logf("output: %dx%d characters as %s", w, h, *format)
+			}
+			start := time.Now()
+			counter := &countingWriter{w: out}
+			if err := write(counter, img, opts, *format); err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+			logSince("converting and writing", start)
+			logf("output: %d bytes", counter.n)
+			return nil
+		}
+
+		if *recursive {
+			paths, err := findImages(args)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(exitError)
+			}
+			failed := false
+			for i, path := range paths {
+				if i > 0 && *confirm && !confirmNext(path) {
+					break
+				}
+				if i > 0 && *pause > 0 {
+					select {
+					case <-ctx.Done():
+					case <-time.After(*pause):
+					}
+				}
+				if ctx.Err() != nil {
+					break
+				}
+				imagePath = path
+				if *link {
+					opts.Hyperlink = fileURL(path)
+				}
+				if !*labelF {
+					// The header has the name already
+					fmt.Println(path)
+				}
+				if err := render(ctx); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+					failed = true
+				}
+			}
+			if failed {
+				os.Exit(exitError)
+			}
+			return
+		}
+		if len(args) > 1 {
+			var images []image.Image
+			for _, path := range args {
+				img, anim, err := loadImage(path)
+				if err == nil && *frameAt != "" {
+					img, err = selectFrame(img, anim, *frameAt)
+				}
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+					os.Exit(exitCode(err))
+				}
+				images = append(images, img)
+			}
+			show := dots.SlideshowOptions{Duration: *slideDur, Transition: slideTransition}
+			if *labelF {
+				for i, path := range args {
+					show.Labels = append(show.Labels, imageHeader(path, images[i], opts))
+				}
+			}
+			if err := dots.Slideshow(ctx, os.Stdout, images, opts, show); err != nil && err != context.Canceled {
+				fmt.Fprintf(os.Stderr, "Error: failed to play slideshow: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			return
+		}
+		if *watch {
+			err := watchFile(ctx, imagePath, func(ctx context.Context) {
+				if err := render(ctx); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				}
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to watch %s: %v\n", imagePath, err)
+				os.Exit(exitError)
+			}
+			return
+		}
+		if err := render(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+	}
+}
 
-	flag.Parse()
+// loadImage decodes the image at path. For GIFs, every frame is decoded too,
+// so animations can be played back.
+func loadImage(path string) (image.Image, *dots.Animation, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer func() { _ = f.Close() }()
 
-	if flag.NArg() != 1 {
-		fmt.Fprintf(os.Stderr, "Usage: %s [flags] <image>\n", os.Args[0])
-		flag.PrintDefaults()
-		os.Exit(1)
+	start := time.Now()
+	img, imageFormat, err := dots.Decode(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	logf("%s: %s, %dx%d pixels", path, imageFormat, img.Bounds().Dx(), img.Bounds().Dy())
+	logSince("decoding", start)
+	if imageFormat != "gif" {
+		return img, nil, nil
 	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return img, nil, nil
+	}
+	anim, err := dots.DecodeGIF(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return img, anim, nil
+}
 
-	imagePath := flag.Arg(0)
+// isFlagSet reports whether the named flag was given on the command line.
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
 
-	// Resolve short flags
-	if *w > 0 {
-		width = w
+// selectFrame returns the frame of an animation given by spec, either an index
+// from 0 or a position in its playing time like "50%". Still images have one frame.
+func selectFrame(img image.Image, anim *dots.Animation, spec string) (image.Image, error) {
+	if anim == nil {
+		anim = &dots.Animation{Frames: []dots.Frame{{Image: img}}}
 	}
-	if *h > 0 {
-		height = h
+	if p, ok := strings.CutSuffix(spec, "%"); ok {
+		pct, err := strconv.ParseFloat(p, 64)
+		if err != nil || pct < 0 || pct > 100 {
+			return nil, fmt.Errorf("frame position must be between 0%% and 100%%")
+		}
+		return anim.FrameAt(time.Duration(float64(anim.Duration()) * pct / 100)).Image, nil
 	}
-	if *t > 0 {
-		threshold = t
+	i, err := strconv.Atoi(spec)
+	if err != nil || i < 0 || i >= len(anim.Frames) {
+		return nil, fmt.Errorf("frame must be between 0 and %d", len(anim.Frames)-1)
 	}
+	return anim.Frames[i].Image, nil
+}
 
-	// Validate threshold
-	if *threshold < 0 || *threshold > 255 {
-		fmt.Fprintf(os.Stderr, "Error: threshold must be between 0 and 255\n")
-		os.Exit(1)
+// parseSpeed parses a positive speed multiplier like "1.5x" or "1.5".
+func parseSpeed(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "x"), 64)
+	if err != nil {
+		return 0, err
 	}
+	if v <= 0 {
+		return 0, fmt.Errorf("%s must be positive", s)
+	}
+	return v, nil
+}
+
+// parseTint parses a pair of comma-separated hex colors, or "sepia".
+func parseTint(s string) (*[2]color.RGBA, error) {
+	if s == "sepia" {
+		tint := dots.SepiaTint
+		return &tint, nil
+	}
+	dark, light, ok := strings.Cut(s, ",")
+	if !ok {
+		return nil, fmt.Errorf("expected two comma-separated colors, got %q", s)
+	}
+	var tint [2]color.RGBA
+	for i, hex := range []string{dark, light} {
+		c, err := dots.ParseHexColor(strings.TrimSpace(hex))
+		if err != nil {
+			return nil, err
+		}
+		tint[i] = c
+	}
+	return &tint, nil
+}
 
-	// Load image to get dimensions for aspect ratio calculation
-	f, err := os.Open(imagePath)
+// systemColors returns the terminal's 16 system colors, queried from the
+// terminal if source is "query" and otherwise read from a palette file.
+func systemColors(source string) (*[16]color.RGBA, error) {
+	if source == "query" {
+		return dots.QuerySystemColors(100 * time.Millisecond)
+	}
+	f, err := os.Open(source)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: failed to open image: %v\n", err)
-		os.Exit(1)
+		return nil, err
 	}
 	defer func() { _ = f.Close() }()
-
-	img, _, err := image.Decode(f)
+	colors, err := dots.ParsePalette(f)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: failed to decode image: %v\n", err)
-		os.Exit(1)
+		return nil, err
+	}
+	if len(colors) != 16 {
+		return nil, fmt.Errorf("%s has %d colors, want 16", source, len(colors))
 	}
+	return (*[16]color.RGBA)(colors), nil
+}
 
-	// Parse background color if provided
-	var bgColor *uint8
-	if *background != "" {
-		ansiColor, err := dots.ParseHex(*background)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: invalid background color: %v\n", err)
-			os.Exit(1)
-		}
-		bgColor = &ansiColor
+// fileURL returns a file:// URL for path, including the hostname as OSC 8 recommends.
+func fileURL(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
 	}
+	host, _ := os.Hostname()
+	u := url.URL{Scheme: "file", Host: host, Path: filepath.ToSlash(path)}
+	return u.String()
+}
 
-	// Convert to dots
-	lines := dots.Convert(img, dots.Options{
-		Width:           *width,
-		Height:          *height,
-		Threshold:       uint8(*threshold),
-		NoColor:         *noColor,
-		BackgroundColor: bgColor,
-		Frame:           *frame,
-	})
+// formatFromPath infers the output format from a file extension, defaulting to ans.
+func formatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".txt":
+		return "txt"
+	case ".html", ".htm":
+		return "html"
+	case ".png":
+		return "png"
+	case ".ndjson":
+		return "ndjson"
+	default:
+		return "ans"
+	}
+}
 
-	// Print output
-	for _, line := range lines {
-		fmt.Println(line)
+// write converts img and writes it to w in the given format.
+func write(w io.Writer, img image.Image, opts dots.Options, format string) error {
+	switch format {
+	case "html":
+		return dots.ConvertGrid(img, opts).WriteHTML(w, opts)
+	case "png":
+		grid := dots.ConvertGrid(img, opts)
+		if opts.SixDot {
+			return png.Encode(w, grid.ImageSixDot(2))
+		}
+		return png.Encode(w, grid.Image(2))
+	case "ndjson":
+		return dots.WriteNDJSON(w, &dots.Animation{Frames: []dots.Frame{{Image: img}}}, opts)
+	default:
+		return dots.ConvertFunc(img, opts, func(_ int, line string) error {
+			_, err := fmt.Fprintln(w, line)
+			return err
+		})
 	}
 }
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/imjasonh/dots"
+	"golang.org/x/term"
+)
+
+// fromNDJSON shows the NDJSON frames in the file at path, or stdin if path is
+// empty or -, as written by another dots process with -format ndjson.
+func fromNDJSON(path string) error {
+	var r io.Reader = os.Stdin
+	if path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	err := showNDJSON(ctx, r, os.Stdout, term.IsTerminal(int(os.Stdout.Fd())))
+	if err == context.Canceled {
+		return nil
+	}
+	return err
+}
+
+// showNDJSON writes the NDJSON frames read from r to w as they arrive, until r
+// ends or ctx is done. On a terminal, each frame is drawn over the previous one
+// and shown for its delay; otherwise the frames are written one after another,
+// for recording.
+func showNDJSON(ctx context.Context, r io.Reader, w io.Writer, terminal bool) error {
+	height := 0 // Lines drawn for the previous frame
+	if terminal {
+		_, _ = io.WriteString(w, "\x1b[?25l")
+		defer func() { _, _ = io.WriteString(w, "\x1b[?25h") }()
+	}
+	return dots.ReadNDJSON(r, func(f dots.NDJSONFrame) error {
+		var sb strings.Builder
+		if terminal && height > 0 {
+			// Move back up to the first line of the previous frame
+			fmt.Fprintf(&sb, "\x1b[%dF", height)
+		}
+		for _, line := range f.Lines {
+			sb.WriteString(line)
+			if terminal {
+				sb.WriteString("\x1b[K")
+			}
+			sb.WriteString("\n")
+		}
+		if terminal && len(f.Lines) < height {
+			// Clear what's left of the previous frame
+			sb.WriteString("\x1b[J")
+		}
+		height = len(f.Lines)
+		if _, err := io.WriteString(w, sb.String()); err != nil {
+			return err
+		}
+		if !terminal || f.DelayMS <= 0 {
+			return ctx.Err()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(f.Delay()):
+			return nil
+		}
+	})
+}
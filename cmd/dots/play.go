@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// playbackKeys puts the terminal in raw mode to read keys during animation playback:
+// p toggles the progress bar, and q, Escape, or Ctrl-C call stop. It returns the
+// progress bar toggle and a function that stops reading keys and restores the
+// terminal. If stdin isn't a terminal, no keys are read.
+func playbackKeys(stop func()) (<-chan struct{}, func()) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, func() {}
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, func() {}
+	}
+
+	toggle := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		for {
+			var in string
+			select {
+			case <-done:
+				return
+			case k, ok := <-stdinKeys():
+				if !ok {
+					return
+				}
+				in = k
+			}
+			for _, b := range []byte(in) {
+				switch b {
+				case 'p':
+					select {
+					case toggle <- struct{}{}:
+					default:
+					}
+				case 'q', 0x03, 0x1b:
+					stop()
+				}
+			}
+		}
+	}()
+	return toggle, func() {
+		close(done)
+		_ = term.Restore(fd, state)
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imjasonh/dots"
+	"github.com/imjasonh/dots/plot"
+	"golang.org/x/term"
+)
+
+// plotMain implements "dots plot", which charts columns of numbers read from a file
+// or stdin as a line chart sized to the terminal.
+func plotMain(args []string) {
+	fs := flag.NewFlagSet("plot", flag.ExitOnError)
+	var (
+		width   = fs.Int("width", 0, "Chart width in characters (default: terminal width)")
+		height  = fs.Int("height", 0, "Chart height in characters (default: terminal height)")
+		noColor = fs.Bool("no-color", false, "Disable ANSI colors")
+		minY    = fs.String("min", "", "Fix the bottom of the y axis (default: the smallest value)")
+		maxY    = fs.String("max", "", "Fix the top of the y axis (default: the largest value)")
+		follow  = fs.Bool("follow", false, "Keep reading values and redraw the chart in place, scrolling as it fills")
+		axes    = fs.Bool("axes", true, "Draw axes with tick labels")
+		grid    = fs.Bool("grid", false, "Draw gridlines at the ticks")
+		legend  = fs.Bool("legend", false, "Name each column above the chart")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s plot [flags] [file]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Plots whitespace- or comma-separated columns of numbers, one series per column,\n")
+		fmt.Fprintf(os.Stderr, "from file or stdin. A first line of non-numbers names the columns.\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	in := io.Reader(os.Stdin)
+	if fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	} else if fs.NArg() == 1 {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		defer func() { _ = f.Close() }()
+		in = f
+	}
+
+	chart := &plot.LineChart{Axes: plot.Axes{Show: *axes, Grid: *grid, Legend: *legend}}
+	var err error
+	if chart.Min, err = parseBound(*minY); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid min: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	if chart.Max, err = parseBound(*maxY); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid max: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	renderOpts := dots.Options{NoColor: *noColor}
+	resize := func() {
+		chart.Width, chart.Height = chartSize(*width, *height)
+	}
+	resize()
+
+	if *follow {
+		if !term.IsTerminal(int(os.Stdout.Fd())) {
+			fmt.Fprintf(os.Stderr, "Error: -follow needs a terminal\n")
+			os.Exit(exitUsage)
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		if err := followPlot(ctx, os.Stdout, in, chart, renderOpts, resize); err != nil && err != context.Canceled {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		return
+	}
+
+	var cols columns
+	sc := bufio.NewScanner(in)
+	for sc.Scan() {
+		cols.add(sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to read values: %v\n", err)
+		os.Exit(exitError)
+	}
+	chart.Series = cols.series
+	for _, line := range chart.Grid().Render(renderOpts) {
+		fmt.Println(line)
+	}
+}
+
+// chartSize returns the chart's size in characters, filling the terminal in each
+// dimension that isn't given.
+func chartSize(width, height int) (int, int) {
+	if width > 0 && height > 0 {
+		return width, height
+	}
+	termWidth, termHeight := 80, 24
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		termWidth, termHeight = w, h
+	}
+	if width <= 0 {
+		width = termWidth
+	}
+	if height <= 0 {
+		// Leave a line for the shell prompt
+		height = max(termHeight-1, 1)
+	}
+	return width, height
+}
+
+// followPlot reads lines of values from in until it ends or ctx is done, redrawing
+// the chart on w in place as they arrive, at most 20 times a second. Once the chart
+// is full, old values scroll off the left edge. When the terminal is resized, resize
+// is called to resize the chart, and it's redrawn on a cleared screen.
+func followPlot(ctx context.Context, w io.Writer, in io.Reader, chart *plot.LineChart, opts dots.Options, resize func()) error {
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		sc := bufio.NewScanner(in)
+		for sc.Scan() {
+			select {
+			case lines <- sc.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- sc.Err()
+	}()
+
+	resized := make(chan os.Signal, 1)
+	dots.NotifyResize(resized)
+	defer signal.Stop(resized)
+
+	_, _ = io.WriteString(w, "\x1b[?25l")
+	defer func() { _, _ = io.WriteString(w, "\x1b[?25h") }()
+
+	var cols columns
+	height := 0 // Lines drawn so far
+	draw := func() error {
+		cols.keep(chart.Width * 2)
+		chart.Series = cols.series
+		var sb strings.Builder
+		if height > 0 {
+			// Move back up to the first line of the previous chart
+			fmt.Fprintf(&sb, "\x1b[%dF", height)
+		}
+		rendered := chart.Grid().Render(opts)
+		for _, line := range rendered {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+		height = len(rendered)
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	dirty := false
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-readErr:
+			if dirty {
+				if err := draw(); err != nil {
+					return err
+				}
+			}
+			return err
+		case line := <-lines:
+			dirty = cols.add(line) || dirty
+		case <-resized:
+			resize()
+			height = 0
+			if _, err := io.WriteString(w, "\x1b[H\x1b[2J"); err != nil {
+				return err
+			}
+			dirty = true
+		case <-ticker.C:
+			if !dirty {
+				continue
+			}
+			if err := draw(); err != nil {
+				return err
+			}
+			dirty = false
+		}
+	}
+}
+
+// columns accumulates whitespace- or comma-separated columns of numbers, one series
+// per column.
+type columns struct {
+	series  []plot.Series
+	started bool // Whether a line has been added
+}
+
+// add adds a line of values, reporting whether it had any. If the first line has no
+// numbers, it names the columns. Blank lines and lines starting with # are skipped,
+// and fields that aren't numbers are gaps.
+func (c *columns) add(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return false
+	}
+	fields := splitFields(line)
+	for len(c.series) < len(fields) {
+		// Pad columns that start late with gaps
+		var values []float64
+		if len(c.series) > 0 {
+			values = make([]float64, len(c.series[0].Values))
+			for i := range values {
+				values[i] = math.NaN()
+			}
+		}
+		c.series = append(c.series, plot.Series{Name: fmt.Sprintf("column %d", len(c.series)+1), Values: values})
+	}
+
+	values := make([]float64, len(c.series))
+	numbers := 0
+	for i := range values {
+		values[i] = math.NaN()
+		if i < len(fields) {
+			if v, err := strconv.ParseFloat(fields[i], 64); err == nil {
+				values[i] = v
+				numbers++
+			}
+		}
+	}
+	first := !c.started
+	c.started = true
+	if first && numbers == 0 {
+		for i, name := range fields {
+			c.series[i].Name = name
+		}
+		return false
+	}
+	for i, v := range values {
+		c.series[i].Values = append(c.series[i].Values, v)
+	}
+	return true
+}
+
+// keep drops all but the last n values of each column.
+func (c *columns) keep(n int) {
+	for i, s := range c.series {
+		if len(s.Values) > n {
+			c.series[i].Values = s.Values[len(s.Values)-n:]
+		}
+	}
+}
+
+// parseBound parses an optional axis bound; the empty string is no bound.
+func parseBound(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// splitFields splits a line on commas, or on whitespace if it has none.
+func splitFields(line string) []string {
+	if !strings.Contains(line, ",") {
+		return strings.Fields(line)
+	}
+	fields := strings.Split(line, ",")
+	for i, f := range fields {
+		fields[i] = strings.TrimSpace(f)
+	}
+	return fields
+}
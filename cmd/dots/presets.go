@@ -0,0 +1,53 @@
+package main
+
+import (
+	"maps"
+	"slices"
+)
+
+// presets are bundles of flags suited to kinds of images, selected with -preset.
+// Flags given on the command line override them.
+var presets = map[string][]setting{
+	// Dithering keeps the gradients of photos, and auto-levels and sharpening
+	// rescue the contrast and detail lost in the downscale.
+	"photo": {
+		{name: "dither", value: "floyd-steinberg"},
+		{name: "serpentine", value: "true"},
+		{name: "auto-levels", value: "true"},
+		{name: "sharpen", value: "0.5"},
+	},
+	// Dark strokes on a light background are lit, in the terminal's own color
+	// since the strokes' is too dark to see, with stray dots cleaned up.
+	"lineart": {
+		{name: "invert", value: "true"},
+		{name: "threshold", value: "128"},
+		{name: "despeckle", value: "true"},
+		{name: "no-color", value: "true"},
+	},
+	// The edges of text and UI elements, with flat colors picked by majority.
+	"screenshot": {
+		{name: "edges", value: "true"},
+		{name: "luma", value: "rec709"},
+		{name: "block-color", value: "majority"},
+		{name: "color-lit", value: "true"},
+	},
+	// Every pixel that isn't black is lit, in crisp, unblended colors.
+	"pixelart": {
+		{name: "threshold", value: "1"},
+		{name: "block-color", value: "majority"},
+		{name: "color-lit", value: "true"},
+	},
+}
+
+// presetNames returns the names of the built-in presets and those defined in
+// the config, sorted.
+func (c *config) presetNames() []string {
+	names := slices.Collect(maps.Keys(presets))
+	for name := range c.presets {
+		if _, ok := presets[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
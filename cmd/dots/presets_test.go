@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+func TestPresets(t *testing.T) {
+	for name, settings := range presets {
+		t.Run(name, func(t *testing.T) {
+			fs := newRenderFlagSet()
+			if err := fs.Parse([]string{"-preset", name}); err != nil {
+				t.Fatal(err)
+			}
+			if err := (&config{}).apply(fs); err != nil {
+				t.Fatalf("apply() = %v", err)
+			}
+			for _, s := range settings {
+				if got := fs.Lookup(s.name).Value.String(); got != s.value {
+					t.Errorf("-%s = %q, want %q", s.name, got, s.value)
+				}
+			}
+		})
+	}
+}
+
+func TestPresetsYieldToFlags(t *testing.T) {
+	for name, settings := range presets {
+		for _, s := range settings {
+			t.Run(name+"/"+s.name, func(t *testing.T) {
+				// Give the flag its default, which the preset changes
+				fs := newRenderFlagSet()
+				def := fs.Lookup(s.name).DefValue
+				if err := fs.Parse([]string{"-preset", name, "-" + s.name + "=" + def}); err != nil {
+					t.Fatal(err)
+				}
+				if err := (&config{}).apply(fs); err != nil {
+					t.Fatalf("apply() = %v", err)
+				}
+				if got := fs.Lookup(s.name).Value.String(); got != def {
+					t.Errorf("-%s = %q, want the flag's %q", s.name, got, def)
+				}
+			})
+		}
+	}
+}
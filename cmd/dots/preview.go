@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/imjasonh/dots"
+)
+
+// previewSize returns the size of a file manager's preview pane: the width and
+// height following the file in args, as lf and ranger pass them, or else fzf's
+// FZF_PREVIEW_COLUMNS and FZF_PREVIEW_LINES, or else 80×24. Further arguments,
+// like lf's position and ranger's cache path, are ignored.
+func previewSize(args []string) (int, int, error) {
+	width, height := 80, 24
+	if len(args) == 2 {
+		return 0, 0, fmt.Errorf("preview size needs a width and a height, got only %q", args[1])
+	}
+	if len(args) >= 3 {
+		w, errW := strconv.Atoi(args[1])
+		h, errH := strconv.Atoi(args[2])
+		if errW != nil || errH != nil || w < 1 || h < 1 {
+			return 0, 0, fmt.Errorf("preview size must be positive numbers, got %q and %q", args[1], args[2])
+		}
+		return w, h, nil
+	}
+	for name, n := range map[string]*int{"FZF_PREVIEW_COLUMNS": &width, "FZF_PREVIEW_LINES": &height} {
+		if v := os.Getenv(name); v != "" {
+			size, err := strconv.Atoi(v)
+			if err != nil || size < 1 {
+				return 0, 0, fmt.Errorf("%s must be a positive number, got %q", name, v)
+			}
+			*n = size
+		}
+	}
+	return width, height, nil
+}
+
+// preview renders the image at path to fit in a width×height preview pane,
+// without consulting the terminal, which belongs to the file manager: the cell
+// aspect ratio is taken from the options or assumed, and animations show their
+// first frame. The same file and size always give the same output.
+func preview(path string, width, height int, opts dots.Options, format string) error {
+	img, _, err := loadImage(path)
+	if err != nil {
+		return err
+	}
+	aspect := opts.CellAspect
+	if aspect == 0 {
+		aspect = 0.5
+		if opts.SixDot {
+			aspect = 2.0 / 3
+		}
+	}
+	border := 0
+	if opts.Frame {
+		border = 2
+	}
+	if width <= border || height <= border {
+		return &dots.TerminalSizeError{Width: width, Height: height, MinWidth: border + 1, MinHeight: border + 1}
+	}
+	b := img.Bounds()
+	opts.Width, opts.Height = dots.CalculateAspectDimensions(b.Dx(), b.Dy(), 0, 0, width-border, height-border, aspect)
+	opts.Width, opts.Height = max(opts.Width, 1)+border, max(opts.Height, 1)+border
+	opts.CellAspect = aspect
+	opts.Align = dots.AlignLeft
+
+	w := bufio.NewWriter(os.Stdout)
+	if err := write(w, img, opts, format); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/imjasonh/dots/qr"
+)
+
+// qrMain implements "dots qr", which prints text as a QR code, e.g. to get a URL
+// from a remote shell onto a phone.
+func qrMain(args []string) {
+	fs := flag.NewFlagSet("qr", flag.ExitOnError)
+	var (
+		level      = fs.String("level", "M", "Error correction level: L, M, Q, or H")
+		halfBlocks = fs.Bool("half-blocks", false, "Draw with half-block characters, which have no gaps between modules and scan more reliably")
+		invert     = fs.Bool("invert", false, "Draw the dark modules instead of the light ones, for terminals with light backgrounds")
+		quiet      = fs.Int("quiet", qr.DefaultQuietZone, "Width of the light margin around the code, in modules")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s qr [flags] [text]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Prints text, or stdin if none is given, as a QR code.\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	text := strings.Join(fs.Args(), " ")
+	if fs.NArg() == 0 {
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to read text: %v\n", err)
+			os.Exit(exitError)
+		}
+		text = strings.TrimSuffix(string(b), "\n")
+	}
+	ecl, err := qr.ParseLevel(*level)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	if *quiet < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -quiet must not be negative\n")
+		os.Exit(exitUsage)
+	}
+
+	code, err := qr.Encode(text, ecl)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	if *halfBlocks {
+		fmt.Println(code.HalfBlocks(*quiet, *invert))
+	} else {
+		fmt.Println(code.Canvas(*quiet, *invert))
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// imageExts are the extensions of files -r renders from directories, for the
+// formats dots decodes.
+var imageExts = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true}
+
+// readPaths reads newline-separated paths from the file at name, or stdin if name
+// is "-", as tar and rsync's --files-from do, so lists longer than the argument
+// limit can be piped in. Blank lines are skipped.
+func readPaths(name string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	var paths []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if path := strings.TrimSuffix(sc.Text(), "\r"); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths, sc.Err()
+}
+
+// findImages expands directories in paths to the images in them and their
+// subdirectories, in lexical order, skipping hidden ones. Other paths are kept.
+func findImages(paths []string) ([]string, error) {
+	var images []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			images = append(images, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if p != path && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.Type().IsRegular() && imageExts[strings.ToLower(filepath.Ext(p))] {
+				images = append(images, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return images, nil
+}
+
+// confirmNext asks on stderr whether to show the next image, reading a key from
+// the terminal: any key continues, and q, Escape, or Ctrl-C stop. It returns
+// false to stop. Without a terminal, it continues.
+func confirmNext(next string) bool {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return true
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return true
+	}
+	defer func() { _ = term.Restore(fd, state) }()
+
+	fmt.Fprintf(os.Stderr, "Next: %s (any key, or q to quit)", next)
+	// Erase the prompt, leaving the cursor where it was
+	defer fmt.Fprintf(os.Stderr, "\r\x1b[K")
+	in, ok := <-stdinKeys()
+	if !ok || in == "" {
+		return false
+	}
+	switch in[0] {
+	case 'q', 0x03, 0x1b:
+		return false
+	}
+	return true
+}
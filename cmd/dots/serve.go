@@ -0,0 +1,390 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/imjasonh/dots"
+)
+
+// maxServeSize is the largest width or height in characters that "dots serve"
+// renders, so a request can't tie up the server.
+const maxServeSize = 1000
+
+// maxServePixels is the most pixels an image "dots serve" decodes may have, since
+// a small, highly compressed file can declare a huge image that would take
+// gigabytes to decode. At 16 megapixels, like 4096x4096, a decoded image takes
+// at most 128MB.
+const maxServePixels = 16 << 20
+
+// serveResult is the JSON response of "dots serve".
+type serveResult struct {
+	Width  int      `json:"width"`
+	Height int      `json:"height"`
+	Text   []string `json:"text"`           // Lines without escape codes
+	ANSI   []string `json:"ansi,omitempty"` // Lines with color escape codes, unless color is off
+}
+
+// server renders images posted to it, or fetched from ?url=, for "dots serve".
+type server struct {
+	maxBytes int64         // Largest image accepted, in bytes
+	client   *http.Client  // Fetches ?url= images, or nil if that's disabled
+	busy     chan struct{} // Holds a value for each image being decoded and converted
+}
+
+// serveMain implements "dots serve", which renders images over HTTP for chat bots
+// and dashboards.
+func serveMain(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	var (
+		listen   = fs.String("listen", ":8080", "Address to listen on")
+		maxBytes = fs.Int64("max-bytes", 10<<20, "Largest image accepted, in bytes")
+		fetch    = fs.Bool("fetch", false, "Allow ?url= to fetch images from other servers, on public addresses only")
+		timeout  = fs.Duration("fetch-timeout", 10*time.Second, "How long to wait for ?url= images")
+		verboseF = fs.Bool("verbose", false, "Log each request to stderr")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s serve [flags]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Renders images POSTed to / (as the body, or a multipart \"image\" field), or\n")
+		fmt.Fprintf(os.Stderr, "fetched with GET /?url=, as text, ANSI, HTML, or JSON. Query parameters:\n\n")
+		fmt.Fprintf(os.Stderr, "  format     txt (default), ans, html, or json\n")
+		fmt.Fprintf(os.Stderr, "  width      Width in characters (default 80)\n")
+		fmt.Fprintf(os.Stderr, "  height     Height in characters (default: from the width)\n")
+		fmt.Fprintf(os.Stderr, "  threshold  Brightness threshold (0-255)\n")
+		fmt.Fprintf(os.Stderr, "  dither     Dithering algorithm, as for -dither\n")
+		fmt.Fprintf(os.Stderr, "  invert     Light dark pixels instead of bright ones\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() > 0 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	}
+	if *maxBytes < 1 {
+		fmt.Fprintf(os.Stderr, "Error: -max-bytes must be positive\n")
+		os.Exit(exitUsage)
+	}
+	verbose = *verboseF
+
+	// Decode and convert as many images at once as there are CPUs; more would
+	// only add to the memory in use
+	s := &server{maxBytes: *maxBytes, busy: make(chan struct{}, runtime.NumCPU())}
+	if *fetch {
+		s.client = fetchClient(*timeout)
+	}
+	srv := &http.Server{
+		Addr:              *listen,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		// Leave time to fetch the image and wait for a turn to convert it
+		WriteTimeout: 30*time.Second + *timeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdown)
+	}()
+	fmt.Fprintf(os.Stderr, "Listening on %s\n", *listen)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+}
+
+// ServeHTTP renders the request's image in the requested format.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	status, err := s.serve(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+	}
+	logf("%s %s: %d in %v", r.Method, r.URL, status, time.Since(start).Round(time.Microsecond))
+}
+
+// serve handles a request, returning the response's status code, and an error
+// to respond with instead if it failed.
+func (s *server) serve(w http.ResponseWriter, r *http.Request) (int, error) {
+	if r.URL.Path != "/" {
+		return http.StatusNotFound, errors.New("not found")
+	}
+	format, opts, err := serveOptions(r.URL.Query())
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	data, status, err := s.imageData(w, r)
+	if err != nil {
+		return status, err
+	}
+
+	// Wait for a turn to decode and convert the image
+	select {
+	case s.busy <- struct{}{}:
+		defer func() { <-s.busy }()
+	case <-r.Context().Done():
+		return http.StatusServiceUnavailable, errors.New("server is busy")
+	}
+	img, _, err := dots.Decode(bytes.NewReader(data))
+	if errors.Is(err, dots.ErrUnsupportedFormat) {
+		return http.StatusUnsupportedMediaType, err
+	} else if err != nil {
+		return http.StatusBadRequest, err
+	}
+	if width, height := opts.OutputSize(img); width > maxServeSize || height > maxServeSize {
+		return http.StatusBadRequest, fmt.Errorf("output of %dx%d characters is larger than %dx%d", width, height, maxServeSize, maxServeSize)
+	}
+
+	switch format {
+	case "html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := dots.ConvertGrid(img, opts).WriteHTML(w, opts); err != nil {
+			return http.StatusInternalServerError, err
+		}
+	case "json":
+		grid := dots.ConvertGrid(img, opts)
+		result := serveResult{Height: len(grid), Text: grid.Render(dots.Options{NoColor: true})}
+		if len(grid) > 0 {
+			result.Width = len(grid[0])
+		}
+		if !opts.NoColor {
+			result.ANSI = grid.Render(opts)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			return http.StatusInternalServerError, err
+		}
+	default:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, line := range dots.Convert(img, opts) {
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return http.StatusInternalServerError, err
+			}
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// imageData reads the request's image: fetched from the url query parameter, or
+// posted as the body or a multipart "image" field. It checks the image's size
+// without decoding it, and returns the status code to respond with if that fails.
+func (s *server) imageData(w http.ResponseWriter, r *http.Request) ([]byte, int, error) {
+	var body io.Reader
+	switch {
+	case r.URL.Query().Has("url"):
+		if s.client == nil {
+			return nil, http.StatusForbidden, errors.New("fetching images from url is disabled")
+		}
+		u, err := url.Parse(r.URL.Query().Get("url"))
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		if err := checkFetchURL(u); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return nil, http.StatusBadGateway, fmt.Errorf("failed to fetch image: %w", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			return nil, http.StatusBadGateway, fmt.Errorf("failed to fetch image: %s", resp.Status)
+		}
+		body = resp.Body
+	case r.Method == http.MethodPost:
+		r.Body = http.MaxBytesReader(w, r.Body, s.maxBytes)
+		body = r.Body
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			f, _, err := r.FormFile("image")
+			if err != nil {
+				return nil, uploadStatus(err), err
+			}
+			defer func() { _ = f.Close() }()
+			body = f
+		}
+	default:
+		return nil, http.StatusBadRequest, errors.New("POST an image, or GET with ?url=")
+	}
+
+	// Read one byte past the limit, to tell a large image from one that fits
+	data, err := io.ReadAll(&io.LimitedReader{R: body, N: s.maxBytes + 1})
+	if err != nil {
+		return nil, uploadStatus(err), err
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("image is larger than %d bytes", s.maxBytes)
+	}
+
+	// Check the size the image declares before decoding it
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) {
+		return nil, http.StatusUnsupportedMediaType, dots.ErrUnsupportedFormat
+	} else if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if int64(config.Width)*int64(config.Height) > maxServePixels {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("image of %dx%d pixels is larger than %d pixels", config.Width, config.Height, maxServePixels)
+	}
+	return data, http.StatusOK, nil
+}
+
+// maxRedirects is how many redirects a ?url= fetch follows.
+const maxRedirects = 5
+
+// fetchClient returns the client that fetches ?url= images. It only connects to
+// public addresses, checked as each connection is made, after DNS resolution,
+// so hostnames that resolve to internal addresses are caught too, and it checks
+// every redirect along the way. Without that, anyone could use the server to
+// reach services on its network, like cloud metadata endpoints.
+func fetchClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addr, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublic(addr.Addr()) {
+				return fmt.Errorf("%s is not a public address", addr.Addr())
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		// No proxy: the dialer must see the destination's address
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: timeout},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return checkFetchURL(req.URL)
+		},
+	}
+}
+
+// checkFetchURL checks that a ?url= image, or a redirect to one, is an http or
+// https URL, and that its host isn't a non-public address. Hostnames are checked
+// when they are resolved, by fetchClient's dialer.
+func checkFetchURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("url must be an http or https URL")
+	}
+	if addr, err := netip.ParseAddr(strings.Trim(u.Hostname(), "[]")); err == nil && !isPublic(addr) {
+		return fmt.Errorf("%s is not a public address", addr)
+	}
+	return nil
+}
+
+// nonPublic are the address ranges, beyond those netip.Addr reports on, that
+// aren't reachable on the internet.
+var nonPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "This" network
+	netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT, and some cloud metadata
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // Benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // Reserved, and broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which can reach IPv4 addresses
+}
+
+// isPublic reports whether addr is a public unicast address: not loopback,
+// private, link-local (like the 169.254.169.254 metadata endpoint), multicast,
+// or otherwise reserved.
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+		return false
+	}
+	for _, p := range nonPublic {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// uploadStatus returns the status code for a failure to read an upload.
+func uploadStatus(err error) int {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// serveOptions parses the output format and conversion options from a request's
+// query parameters.
+func serveOptions(q url.Values) (string, dots.Options, error) {
+	format := q.Get("format")
+	switch format {
+	case "":
+		format = "txt"
+	case "txt", "ans", "html", "json":
+	default:
+		return "", dots.Options{}, fmt.Errorf("unknown format %q (expected txt, ans, html, or json)", format)
+	}
+	// The client's terminal, if any, isn't the server's: don't let the server's
+	// terminal or environment decide the cell shape or color
+	opts := dots.Options{Width: 80, NoColor: format == "txt", CellAspect: 0.5, IgnoreColorEnv: true}
+	size := func(name string, n *int) error {
+		if !q.Has(name) {
+			return nil
+		}
+		v, err := strconv.Atoi(q.Get(name))
+		if err != nil || v < 1 || v > maxServeSize {
+			return fmt.Errorf("%s must be between 1 and %d", name, maxServeSize)
+		}
+		*n = v
+		return nil
+	}
+	if q.Has("height") && !q.Has("width") {
+		opts.Width = 0
+	}
+	if err := size("width", &opts.Width); err != nil {
+		return "", dots.Options{}, err
+	}
+	if err := size("height", &opts.Height); err != nil {
+		return "", dots.Options{}, err
+	}
+	if q.Has("threshold") {
+		level, err := strconv.Atoi(q.Get("threshold"))
+		if err != nil || level < 0 || level > 255 {
+			return "", dots.Options{}, errors.New("threshold must be between 0 and 255")
+		}
+		opts.Threshold = uint8(level)
+	}
+	var err error
+	if opts.DitherAlgorithm, err = dots.ParseDitherAlgorithm(q.Get("dither")); err != nil {
+		return "", dots.Options{}, err
+	}
+	if q.Has("invert") {
+		if opts.Invert, err = strconv.ParseBool(q.Get("invert")); err != nil {
+			return "", dots.Options{}, errors.New("invert must be true or false")
+		}
+	}
+	return format, opts, nil
+}
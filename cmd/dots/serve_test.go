@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imjasonh/dots"
+)
+
+func TestIsPublic(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34":          true,
+		"2606:4700::1111":        true,
+		"127.0.0.1":              false,
+		"10.1.2.3":               false,
+		"172.16.0.1":             false,
+		"192.168.1.1":            false,
+		"169.254.169.254":        false, // Cloud metadata
+		"100.100.100.200":        false, // Carrier-grade NAT range, used for metadata too
+		"0.0.0.0":                false,
+		"255.255.255.255":        false,
+		"224.0.0.1":              false,
+		"::1":                    false,
+		"fe80::1":                false,
+		"fd00::1":                false,
+		"::ffff:127.0.0.1":       false,
+		"::ffff:169.254.169.254": false,
+		"64:ff9b::a9fe:a9fe":     false,
+	} {
+		if got := isPublic(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("isPublic(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestCheckFetchURL(t *testing.T) {
+	for raw, wantErr := range map[string]bool{
+		"https://example.com/a.png":         false,
+		"http://93.184.216.34/a.png":        false,
+		"file:///etc/passwd":                true,
+		"gopher://example.com/":             true,
+		"http://169.254.169.254/latest/":    true,
+		"http://127.0.0.1:8080/a.png":       true,
+		"http://[::1]/a.png":                true,
+		"http://[::ffff:10.0.0.1]:80/a.png": true,
+	} {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := checkFetchURL(u); (err != nil) != wantErr {
+			t.Errorf("checkFetchURL(%s) = %v, wantErr %v", raw, err, wantErr)
+		}
+	}
+}
+
+func TestFetchClientRefusesLoopback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("secret"))
+	}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A hostname, so the address is only known once it's resolved
+	u.Host = "localhost:" + u.Port()
+	resp, err := fetchClient(time.Second).Get(u.String())
+	if err == nil {
+		_ = resp.Body.Close()
+		t.Fatalf("fetching %s succeeded, want it refused", u)
+	}
+}
+
+// pngHeader returns the start of a PNG declaring a width×height image, which is
+// all image.DecodeConfig reads.
+func pngHeader(width, height uint32) []byte {
+	ihdr := binary.BigEndian.AppendUint32([]byte("IHDR"), width)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, height)
+	ihdr = append(ihdr, 8, 2, 0, 0, 0) // 8-bit RGB
+	b := []byte("\x89PNG\r\n\x1a\n")
+	b = binary.BigEndian.AppendUint32(b, uint32(len(ihdr)-4))
+	b = append(b, ihdr...)
+	return binary.BigEndian.AppendUint32(b, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestServeRejectsHugeImages(t *testing.T) {
+	s := &server{maxBytes: 1 << 20, busy: make(chan struct{}, 1)}
+	for _, tt := range []struct {
+		desc string
+		body []byte
+		want int
+	}{{
+		desc: "declared size over the limit",
+		body: pngHeader(60000, 60000),
+		want: http.StatusRequestEntityTooLarge,
+	}, {
+		desc: "more bytes than allowed",
+		body: make([]byte, 1<<20+1),
+		want: http.StatusRequestEntityTooLarge,
+	}, {
+		desc: "unknown format",
+		body: []byte("not an image"),
+		want: http.StatusUnsupportedMediaType,
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tt.body)))
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestServeWaitsWhenBusy(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/black.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &server{maxBytes: 1 << 20, busy: make(chan struct{}, 1)}
+	s.busy <- struct{}{}
+
+	// A request that gives up while another image is being converted fails
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data)).WithContext(ctx))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d: %s", w.Code, http.StatusServiceUnavailable, w.Body)
+	}
+
+	// Once it's done, the image is converted
+	<-s.busy
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data)))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if len(s.busy) != 0 {
+		t.Errorf("%d conversions still running, want 0", len(s.busy))
+	}
+}
+
+func TestServeOptionsIgnoreServerTerminal(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	format, opts, err := serveOptions(url.Values{"format": {"ans"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != "ans" || opts.NoColor || !opts.IgnoreColorEnv || opts.CellAspect != 0.5 {
+		t.Errorf("serveOptions() = %q, %+v, want ans in color, ignoring the environment, with a cell aspect of 0.5", format, opts)
+	}
+	img, _, err := loadImage("../../testdata/gradient.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := dots.Convert(img, opts); !strings.Contains(strings.Join(lines, "\n"), "\x1b[38;5;") {
+		t.Errorf("Convert() = %q, want color despite NO_COLOR on the server", lines)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+
+	"github.com/imjasonh/dots"
+)
+
+// sheetMain implements "dots sheet", which shows the images in a directory as a
+// contact sheet: a grid of labeled thumbnails.
+func sheetMain(args []string) {
+	fs := flag.NewFlagSet("sheet", flag.ExitOnError)
+	var (
+		columns   = fs.Int("columns", 4, "Thumbnails per row")
+		width     = fs.Int("width", 0, "Total output width in characters (default: terminal width)")
+		thumbRows = fs.Int("thumb-height", 0, "Thumbnail height in characters (default: fit the sheet on the screen, if it can)")
+		noColor   = fs.Bool("no-color", false, "Disable ANSI colors")
+		gap       = fs.Int("gap", 2, "Blank columns between thumbnails")
+		labelF    = fs.Bool("label", false, "Add each image's original size and format, and thumbnail size, to its label")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s sheet [flags] [dir]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Shows the images in dir, or the current directory, as a grid of labeled thumbnails.\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	dir := "."
+	if fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	} else if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+	if *columns < 1 || *gap < 0 || *thumbRows < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -columns must be positive, and -gap and -thumb-height must not be negative\n")
+		os.Exit(exitUsage)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	// Find the images by their headers first, to lay out the sheet without keeping
+	// every decoded image in memory
+	var names []string
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		f, err := os.Open(filepath.Join(dir, e.Name()))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", e.Name(), err)
+			continue
+		}
+		_, _, err = image.DecodeConfig(f)
+		_ = f.Close()
+		if errors.Is(err, image.ErrFormat) {
+			// Not an image
+			continue
+		} else if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", e.Name(), err)
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	if len(names) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no images in %s\n", dir)
+		os.Exit(exitError)
+	}
+
+	w, h := chartSize(*width, 0)
+	cols := min(*columns, len(names))
+	rows := (len(names) + cols - 1) / cols
+	thumbWidth := max((w-*gap*(cols-1))/cols, 1)
+	thumbHeight := *thumbRows
+	if thumbHeight == 0 {
+		// Each row of the sheet has a line of labels and a blank line after it
+		thumbHeight = max((h+1)/rows-2, 3)
+	}
+
+	// Decode the images a row at a time, keeping only their thumbnails
+	opts := dots.Options{NoColor: *noColor}
+	for row := range rows {
+		if row > 0 {
+			fmt.Println()
+		}
+		var tiles []dots.Grid
+		for _, name := range names[row*cols : min((row+1)*cols, len(names))] {
+			tiles = append(tiles, sheetTile(filepath.Join(dir, name), opts, thumbWidth, thumbHeight, *labelF))
+		}
+		for _, line := range dots.SideBySide(*gap, tiles...).Render(opts) {
+			fmt.Println(line)
+		}
+	}
+}
+
+// sheetTile returns the thumbnail of the image at path, fit to width×height
+// characters, under a label with its name, and its sizes and format if header is
+// set. If the image can't be decoded, the tile only has the label.
+func sheetTile(path string, opts dots.Options, width, height int, header bool) dots.Grid {
+	name := filepath.Base(path)
+	img, _, err := loadImage(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", name, err)
+		return dots.Grid{label(name, width)}
+	}
+	widget := dots.NewWidget(img, opts)
+	widget.SetRect(0, 0, width, height)
+	grid := widget.Grid()
+	if header {
+		thumb := opts
+		if len(grid) > 0 {
+			thumb.Width, thumb.Height = len(grid[0]), len(grid)
+		}
+		name = imageHeader(path, img, thumb)
+	}
+	return append(dots.Grid{label(name, width)}, grid...)
+}
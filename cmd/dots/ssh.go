@@ -0,0 +1,389 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/imjasonh/dots"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshTerminal is what a client said about its terminal when it connected.
+type sshTerminal struct {
+	term          string
+	width, height int     // In characters
+	cellAspect    float64 // Width/height ratio of a cell, or 0 if unknown
+	noColor       bool    // The client's environment disables color, or its terminal can't show it
+}
+
+const (
+	// sshHandshakeTimeout is how long a client has to finish the SSH handshake.
+	sshHandshakeTimeout = 10 * time.Second
+	// maxSSHConns is how many clients can be connected at once; more are turned away.
+	maxSSHConns = 32
+)
+
+// sshMain implements "dots ssh", an SSH server where each client browses the
+// images in a directory, rendered for its own terminal.
+func sshMain(args []string) {
+	fs := flag.NewFlagSet("ssh", flag.ExitOnError)
+	var (
+		listen   = fs.String("listen", "", "Address to listen on (default: :2222 with -authorized-keys, else 127.0.0.1:2222)")
+		hostKey  = fs.String("host-key", "", "Private key file identifying the server (default: a new key each run)")
+		authKeys = fs.String("authorized-keys", "", "Only let in clients with a key in this authorized_keys file (default: anyone, on a loopback address only)")
+		verboseF = fs.Bool("verbose", false, "Log connections to stderr")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s ssh [flags] [dir]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Serves the images in dir, or the current directory, and its subdirectories over\n")
+		fmt.Fprintf(os.Stderr, "SSH. Clients browse them with the arrow keys, rendered for their terminal.\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	dir := "."
+	if fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(exitUsage)
+	} else if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+	verbose = *verboseF
+
+	// Without authentication, only serve this machine, and say so
+	if *authKeys == "" {
+		if *listen == "" {
+			*listen = "127.0.0.1:2222"
+		} else if !isLoopback(*listen) {
+			fmt.Fprintf(os.Stderr, "Error: listening on %s lets in anyone who can reach it; give -authorized-keys, or listen on a loopback address\n", *listen)
+			os.Exit(exitUsage)
+		}
+		fmt.Fprintf(os.Stderr, "WARNING: no -authorized-keys, so anyone on this machine can connect without authenticating\n")
+	} else if *listen == "" {
+		*listen = ":2222"
+	}
+
+	config, err := sshConfig(*hostKey, *authKeys)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	ln, err := net.Listen("tcp", *listen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		_ = ln.Close()
+	}()
+
+	fmt.Fprintf(os.Stderr, "Listening on %s, serving %s\n", *listen, dir)
+	conns := make(chan struct{}, maxSSHConns)
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		select {
+		case conns <- struct{}{}:
+		default:
+			logf("%s: too many connections", conn.RemoteAddr())
+			_ = conn.Close()
+			continue
+		}
+		go func() {
+			defer func() { <-conns }()
+			serveSSH(ctx, conn, config, dir)
+		}()
+	}
+}
+
+// isLoopback reports whether a listen address only accepts connections from
+// this machine.
+func isLoopback(listen string) bool {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && addr.IsLoopback()
+}
+
+// sshConfig configures the server with the host key in the file at hostKey, or a
+// new one, letting in the clients whose keys are in the file at authKeys, or
+// anyone if it's empty.
+func sshConfig(hostKey, authKeys string) (*ssh.ServerConfig, error) {
+	config := &ssh.ServerConfig{NoClientAuth: authKeys == ""}
+	if authKeys != "" {
+		data, err := os.ReadFile(authKeys)
+		if err != nil {
+			return nil, err
+		}
+		allowed := map[string]bool{}
+		for len(data) > 0 {
+			key, _, _, rest, err := ssh.ParseAuthorizedKey(data)
+			if err != nil {
+				break
+			}
+			allowed[string(key.Marshal())] = true
+			data = rest
+		}
+		if len(allowed) == 0 {
+			return nil, fmt.Errorf("no keys in %s", authKeys)
+		}
+		config.PublicKeyCallback = func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if !allowed[string(key.Marshal())] {
+				return nil, errors.New("unknown key")
+			}
+			return nil, nil
+		}
+	}
+
+	var signer ssh.Signer
+	if hostKey != "" {
+		data, err := os.ReadFile(hostKey)
+		if err != nil {
+			return nil, err
+		}
+		if signer, err = ssh.ParsePrivateKey(data); err != nil {
+			return nil, fmt.Errorf("invalid host key: %w", err)
+		}
+	} else {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		if signer, err = ssh.NewSignerFromKey(key); err != nil {
+			return nil, err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Host key fingerprint: %s\n", ssh.FingerprintSHA256(signer.PublicKey()))
+	config.AddHostKey(signer)
+	return config, nil
+}
+
+// serveSSH runs an SSH connection, opening the image browser for each session.
+func serveSSH(ctx context.Context, conn net.Conn, config *ssh.ServerConfig, dir string) {
+	// Don't let a client that never finishes the handshake hold the connection
+	_ = conn.SetDeadline(time.Now().Add(sshHandshakeTimeout))
+	sconn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		logf("%s: handshake failed: %v", conn.RemoteAddr(), err)
+		return
+	}
+	_ = conn.SetDeadline(time.Time{})
+	defer func() { _ = sconn.Close() }()
+	logf("%s: connected as %s", sconn.RemoteAddr(), sconn.User())
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			_ = newChan.Reject(ssh.UnknownChannelType, "only sessions are supported")
+			continue
+		}
+		ch, requests, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		go sshSession(ctx, ch, requests, dir)
+	}
+	logf("%s: disconnected", sconn.RemoteAddr())
+}
+
+// sshSession handles a session's requests, starting the browser when the client
+// asks for a shell and resizing it along with the client's terminal.
+func sshSession(ctx context.Context, ch ssh.Channel, requests <-chan *ssh.Request, dir string) {
+	defer func() { _ = ch.Close() }()
+	var t sshTerminal
+	env := map[string]string{}
+	resized := make(chan image.Point, 1)
+	started := false
+	for req := range requests {
+		ok := false
+		switch req.Type {
+		case "pty-req":
+			var pty struct {
+				Term                    string
+				Columns, Rows           uint32
+				PixelWidth, PixelHeight uint32
+				Modes                   string
+			}
+			if ok = ssh.Unmarshal(req.Payload, &pty) == nil; ok {
+				t.term = pty.Term
+				t.width, t.height = int(pty.Columns), int(pty.Rows)
+				if pty.PixelWidth > 0 && pty.PixelHeight > 0 && pty.Columns > 0 && pty.Rows > 0 {
+					t.cellAspect = (float64(pty.PixelWidth) / float64(pty.Columns)) / (float64(pty.PixelHeight) / float64(pty.Rows))
+				}
+			}
+		case "env":
+			var kv struct{ Name, Value string }
+			if ok = ssh.Unmarshal(req.Payload, &kv) == nil; ok {
+				env[kv.Name] = kv.Value
+			}
+		case "window-change":
+			var size struct{ Columns, Rows, PixelWidth, PixelHeight uint32 }
+			if ok = ssh.Unmarshal(req.Payload, &size) == nil; ok {
+				// Keep only the latest size
+				select {
+				case <-resized:
+				default:
+				}
+				resized <- image.Pt(int(size.Columns), int(size.Rows))
+			}
+		case "shell":
+			ok = !started
+			if ok {
+				started = true
+				// The client's environment decides color, not the server's
+				getenv := func(name string) string { return env[name] }
+				t.noColor = dots.EnvNoColor(getenv) || t.term == "" || t.term == "dumb"
+				go func() {
+					status := uint32(0)
+					if err := browse(ctx, ch, t, resized, dir); err != nil {
+						fmt.Fprintf(ch, "Error: %v\r\n", err)
+						status = 1
+					}
+					_, _ = ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+					_ = ch.Close()
+				}()
+			}
+		}
+		if req.WantReply {
+			_ = req.Reply(ok, nil)
+		}
+	}
+}
+
+// browse shows the images in dir full screen on the client's terminal, one at a
+// time with its name and position under it. The left and right arrow keys or h
+// and l go to the previous and next image, and q or Escape quits.
+func browse(ctx context.Context, rw io.ReadWriter, t sshTerminal, resized <-chan image.Point, dir string) error {
+	if t.width < 1 || t.height < 2 {
+		return errors.New("dots needs a terminal; connect with ssh -t")
+	}
+	paths, err := findImages([]string{dir})
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no images in %s", dir)
+	}
+
+	input := make(chan string)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(input)
+		buf := make([]byte, 256)
+		for {
+			n, err := rw.Read(buf)
+			if err != nil {
+				return
+			}
+			select {
+			case input <- string(buf[:n]):
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// Use the alternate screen, so the client's terminal comes back on exit
+	_, _ = io.WriteString(rw, "\x1b[?1049h\x1b[?25l")
+	defer func() { _, _ = io.WriteString(rw, "\x1b[?25h\x1b[?1049l") }()
+
+	// Decode each image once, when it's shown, not on every redraw
+	i, loaded := 0, -1
+	var img image.Image
+	var loadErr error
+	for {
+		if i != loaded {
+			img, _, loadErr = loadImage(paths[i])
+			loaded = i
+		}
+		if err := drawBrowser(rw, t, img, loadErr, paths[i], dir, i, len(paths)); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case size := <-resized:
+			t.width, t.height = size.X, size.Y
+		case in, ok := <-input:
+			if !ok {
+				return nil
+			}
+			for _, ev := range parseInput(in) {
+				switch ev.key {
+				case keyLeft, keyUp:
+					i = (i + len(paths) - 1) % len(paths)
+				case keyRight, keyDown:
+					i = (i + 1) % len(paths)
+				case keyQuit:
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// drawBrowser draws img, loaded from path, fit to the terminal, or the error
+// loading it, above a status line with its path relative to dir and its position
+// among n images.
+func drawBrowser(w io.Writer, t sshTerminal, img image.Image, loadErr error, path, dir string, i, n int) error {
+	var sb strings.Builder
+	sb.WriteString("\x1b[H\x1b[2J")
+	opts := dots.Options{NoColor: t.noColor, IgnoreColorEnv: true, CellAspect: t.cellAspect}
+	if opts.CellAspect == 0 {
+		// Don't consult the server's own terminal
+		opts.CellAspect = 0.5
+	}
+	if loadErr != nil {
+		fmt.Fprintf(&sb, "%v\r\n", loadErr)
+	} else {
+		widget := dots.NewWidget(img, opts)
+		widget.SetRect(0, 0, t.width, max(t.height-1, 1))
+		for _, line := range widget.Grid().Render(opts) {
+			sb.WriteString(line)
+			sb.WriteString("\r\n")
+		}
+	}
+
+	name := path
+	if rel, err := filepath.Rel(dir, path); err == nil {
+		name = rel
+	}
+	status := fmt.Sprintf("%s (%d/%d)    ←→ browse  q quit", name, i+1, n)
+	if runes := []rune(status); len(runes) > t.width {
+		status = string(runes[:t.width])
+	}
+	if t.noColor {
+		fmt.Fprintf(&sb, "\x1b[%d;1H%s", t.height, status)
+	} else {
+		fmt.Fprintf(&sb, "\x1b[%d;1H\x1b[7m%s\x1b[0m", t.height, status)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsLoopback(t *testing.T) {
+	for listen, want := range map[string]bool{
+		"127.0.0.1:2222": true,
+		"[::1]:2222":     true,
+		"localhost:2222": true,
+		":2222":          false,
+		"0.0.0.0:2222":   false,
+		"10.0.0.1:2222":  false,
+		"example.com:22": false,
+		"nonsense":       false,
+	} {
+		if got := isLoopback(listen); got != want {
+			t.Errorf("isLoopback(%q) = %v, want %v", listen, got, want)
+		}
+	}
+}
+
+func TestDrawBrowserColorFromClient(t *testing.T) {
+	// The server's environment mustn't decide color for its clients
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("FORCE_COLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+	const path = "../../testdata/gradient.png"
+	img, _, err := loadImage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, noColor := range []bool{false, true} {
+		var sb strings.Builder
+		term := sshTerminal{term: "xterm-256color", width: 20, height: 6, noColor: noColor}
+		if err := drawBrowser(&sb, term, img, nil, path, "../../testdata", 0, 1); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Contains(sb.String(), "\x1b[38;5;"); got == noColor {
+			t.Errorf("drawBrowser() with noColor %v has color %v, want %v", noColor, got, !noColor)
+		}
+	}
+}
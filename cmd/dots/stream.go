@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/imjasonh/dots"
+)
+
+// isStream reports whether the input is a camera stream URL, read with ffmpeg.
+func isStream(input string) bool {
+	return strings.HasPrefix(input, "rtsp://") || strings.HasPrefix(input, "rtsps://")
+}
+
+// renderStream plays a stream on the terminal until ctx is done if play is set,
+// returning a nil image. Otherwise, it returns the stream's first frame.
+func renderStream(ctx context.Context, url string, fps float64, opts dots.Options, play bool) (image.Image, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	frames, errc := streamFrames(ctx, url, fps)
+
+	if play {
+		err := dots.PlayStream(ctx, os.Stdout, frames, opts)
+		select {
+		case err := <-errc:
+			return nil, err
+		default:
+		}
+		if err != nil && err != context.Canceled {
+			return nil, fmt.Errorf("failed to play stream: %w", err)
+		}
+		return nil, nil
+	}
+
+	select {
+	case img, ok := <-frames:
+		if !ok {
+			return nil, <-errc
+		}
+		return img, nil
+	case <-ctx.Done():
+		return nil, nil
+	}
+}
+
+// writeStreamNDJSON writes the frames of a stream to w as NDJSON frames as they
+// are decoded, until ctx is done, for another process to show live.
+func writeStreamNDJSON(ctx context.Context, w io.Writer, url string, fps float64, opts dots.Options) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	frames, errc := streamFrames(ctx, url, fps)
+	enc := json.NewEncoder(w)
+	for n := 0; ; n++ {
+		img, ok := <-frames
+		if !ok {
+			select {
+			case err := <-errc:
+				return err
+			default:
+				return nil
+			}
+		}
+		if err := enc.Encode(dots.NDJSONFrame{Frame: n, Lines: dots.Convert(img, opts)}); err != nil {
+			return err
+		}
+	}
+}
+
+// Backoff between attempts to reconnect to a stream.
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// streamFrames sends the frames of a stream, decoded by an ffmpeg subprocess at up to
+// fps frames per second, until ctx is done. If the stream ends or fails after it has
+// sent frames, as cameras and networks do, ffmpeg is restarted with an increasing
+// delay. If ffmpeg can't be started, or the first attempt gets no frames, like with
+// a wrong URL, the error is returned on errc and the channel is closed; otherwise
+// it's closed when ctx is done.
+func streamFrames(ctx context.Context, url string, fps float64) (<-chan image.Image, <-chan error) {
+	frames := make(chan image.Image)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(frames)
+		delay := minReconnectDelay
+		for first := true; ; first = false {
+			n, err := readStream(ctx, url, fps, frames)
+			if ctx.Err() != nil {
+				return
+			}
+			if errors.Is(err, exec.ErrNotFound) {
+				errc <- fmt.Errorf("streams need ffmpeg: %w", err)
+				return
+			}
+			if first && n == 0 {
+				if err == nil {
+					err = errors.New("no frames")
+				}
+				errc <- fmt.Errorf("failed to read stream: %w", err)
+				return
+			}
+			if err != nil {
+				logf("stream failed, reconnecting in %v: %v", delay, err)
+			}
+			if n > 0 {
+				// The stream worked for a while, so reconnect quickly
+				delay = minReconnectDelay
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			delay = min(2*delay, maxReconnectDelay)
+		}
+	}()
+	return frames, errc
+}
+
+// maxStderrTail is how much of the end of ffmpeg's stderr is kept for its errors.
+const maxStderrTail = 4 << 10
+
+// stderrTail keeps the end of what a subprocess writes to stderr, to explain its
+// failure.
+type stderrTail struct{ b []byte }
+
+func (t *stderrTail) Write(p []byte) (int, error) {
+	t.b = append(t.b, p...)
+	if len(t.b) > maxStderrTail {
+		t.b = t.b[len(t.b)-maxStderrTail:]
+	}
+	return len(p), nil
+}
+
+// readStream runs ffmpeg once, sending the stream's frames until it ends or ctx is done,
+// and returns the number of frames sent. ffmpeg writes the frames as consecutive PNGs.
+// If ffmpeg fails, the error includes what it wrote to stderr.
+func readStream(ctx context.Context, url string, fps float64, frames chan<- image.Image) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-loglevel", "error",
+		"-rtsp_transport", "tcp",
+		"-i", url,
+		"-an",
+		"-vf", fmt.Sprintf("fps=%g", fps),
+		"-f", "image2pipe", "-vcodec", "png",
+		"-")
+	var stderr stderrTail
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return 0, err
+	}
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	waited := false
+	defer func() {
+		if !waited {
+			// Stop ffmpeg before waiting, in case it's blocked writing frames no one reads
+			cancel()
+			_ = cmd.Wait()
+		}
+	}()
+
+	r := bufio.NewReader(stdout)
+	n := 0
+	for {
+		img, err := png.Decode(r)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			// ffmpeg closed its output, so it's exiting
+			waited = true
+			if err := cmd.Wait(); err != nil {
+				if msg := strings.TrimSpace(string(stderr.b)); msg != "" {
+					return n, fmt.Errorf("ffmpeg: %w: %s", err, msg)
+				}
+				return n, fmt.Errorf("ffmpeg: %w", err)
+			}
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+		select {
+		case frames <- img:
+			n++
+		case <-ctx.Done():
+			return n, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamFramesFirstFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake ffmpeg is a shell script")
+	}
+	// An ffmpeg that fails to open the stream, as it does for a wrong URL
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho 'rtsp://camera.local/nope: 404 Not Found' >&2\nexit 1\n"
+	if err := os.WriteFile(filepath.Join(dir, "ffmpeg"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	frames, errc := streamFrames(ctx, "rtsp://camera.local/nope", 1)
+	if _, ok := <-frames; ok {
+		t.Fatal("got a frame, want none")
+	}
+	select {
+	case err := <-errc:
+		if err == nil || !strings.Contains(err.Error(), "404 Not Found") {
+			t.Errorf("error = %v, want ffmpeg's message", err)
+		}
+	default:
+		t.Error("no error, want ffmpeg's")
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/imjasonh/dots"
+	"golang.org/x/term"
+)
+
+// colorMode is a way of coloring the output that -tune cycles through.
+type colorMode struct {
+	name  string
+	flags string // Flags that select it
+	apply func(opts *dots.Options)
+}
+
+// colorModes are the color modes -tune cycles through, in order.
+var colorModes = []colorMode{
+	{"color", "-palette none", func(opts *dots.Options) {}},
+	{"grayscale", "-grayscale", func(opts *dots.Options) { opts.Grayscale = true }},
+	{"median-cut palette", "-palette median-cut", func(opts *dots.Options) { opts.PaletteMode = dots.PaletteMedianCut }},
+	{"k-means palette", "-palette k-means", func(opts *dots.Options) { opts.PaletteMode = dots.PaletteKMeans }},
+	{"no color", "-no-color", func(opts *dots.Options) { opts.NoColor = true }},
+}
+
+// tuning is the state of -tune: the options being tuned, and the color mode
+// chosen, or -1 if it's still the one the options started with.
+type tuning struct {
+	opts  dots.Options
+	color int
+}
+
+// adjustThreshold moves the threshold up or down by steps, starting from the
+// default if it isn't set. A percentile threshold moves by 5 percent a step.
+func (t *tuning) adjustThreshold(steps int) {
+	if t.opts.ThresholdPercentile > 0 {
+		t.opts.ThresholdPercentile = min(max(t.opts.ThresholdPercentile+5*float64(steps), 1), 99)
+		return
+	}
+	t.opts.Threshold = uint8(min(max(int(t.opts.EffectiveThreshold())+8*steps, 1), 255))
+}
+
+// cycleDither switches to the next or, for a negative step, the previous
+// dithering algorithm, with none before the first.
+func (t *tuning) cycleDither(step int) {
+	algorithms := append([]dots.DitherAlgorithm{dots.DitherNone}, dots.DitherAlgorithms...)
+	i := 0
+	for j, a := range algorithms {
+		if a == t.opts.DitherAlgorithm {
+			i = j
+		}
+	}
+	i = (i + step + len(algorithms)) % len(algorithms)
+	t.opts.DitherAlgorithm = algorithms[i]
+}
+
+// cycleColor switches to the next color mode.
+func (t *tuning) cycleColor() {
+	t.color = (t.color + 1) % len(colorModes)
+	t.opts.NoColor, t.opts.Grayscale = false, false
+	t.opts.PaletteMode, t.opts.Palette, t.opts.Tint = dots.PaletteNone, nil, nil
+	colorModes[t.color].apply(&t.opts)
+}
+
+// threshold describes the threshold for the status line.
+func (t *tuning) threshold() string {
+	switch {
+	case t.opts.ThresholdPercentile > 0:
+		return fmt.Sprintf("p%g", t.opts.ThresholdPercentile)
+	case t.opts.Threshold == 0:
+		return fmt.Sprintf("%d (auto)", t.opts.EffectiveThreshold())
+	}
+	return fmt.Sprint(t.opts.Threshold)
+}
+
+// status describes the settings and the keys that change them.
+func (t *tuning) status() string {
+	dither := string(t.opts.DitherAlgorithm)
+	if dither == "" {
+		dither = "none"
+	}
+	colors := "as given"
+	if t.color >= 0 {
+		colors = colorModes[t.color].name
+	}
+	return fmt.Sprintf("threshold %s · dither %s · %s    ↑↓ threshold  ←→ dither  c colors  0 reset  q done",
+		t.threshold(), dither, colors)
+}
+
+// flags returns the command-line flags that reproduce the tuned settings.
+func (t *tuning) flags() string {
+	var flags []string
+	switch {
+	case t.opts.ThresholdPercentile > 0:
+		flags = append(flags, fmt.Sprintf("-threshold p%g", t.opts.ThresholdPercentile))
+	case t.opts.Threshold != 0:
+		flags = append(flags, fmt.Sprintf("-threshold %d", t.opts.Threshold))
+	}
+	dither := string(t.opts.DitherAlgorithm)
+	if dither == "" {
+		dither = "none"
+	}
+	flags = append(flags, "-dither "+dither)
+	if t.color >= 0 {
+		flags = append(flags, colorModes[t.color].flags)
+	}
+	return strings.Join(flags, " ")
+}
+
+// tune shows img on the full terminal screen with a status line under it, letting
+// the user raise and lower the threshold with the up and down arrow keys or k and j,
+// switch dithering algorithms with the left and right arrow keys or h and l, and
+// switch color modes with c. 0 resets the settings, and q or Escape quits,
+// returning the flags that reproduce the result.
+func tune(ctx context.Context, img image.Image, opts dots.Options) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return "", errors.New("tuning requires a terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = term.Restore(fd, state) }()
+
+	// Use the alternate screen, so the shell's contents come back on exit
+	_, _ = io.WriteString(os.Stdout, "\x1b[?1049h\x1b[?25l")
+	defer func() { _, _ = io.WriteString(os.Stdout, "\x1b[?25h\x1b[?1049l") }()
+
+	input := stdinKeys()
+
+	resized := make(chan os.Signal, 1)
+	dots.NotifyResize(resized)
+	defer signal.Stop(resized)
+
+	t := tuning{opts: opts, color: -1}
+	for {
+		if _, err := drawView(os.Stdout, img, fitAbove(img, t.opts, 1)); err != nil {
+			return "", err
+		}
+		if err := drawStatus(os.Stdout, t.status()); err != nil {
+			return "", err
+		}
+
+		select {
+		case <-ctx.Done():
+			return t.flags(), nil
+		case <-resized:
+		case in, ok := <-input:
+			if !ok {
+				return t.flags(), nil
+			}
+			for _, ev := range parseInput(in) {
+				switch ev.key {
+				case keyUp:
+					t.adjustThreshold(1)
+				case keyDown:
+					t.adjustThreshold(-1)
+				case keyLeft:
+					t.cycleDither(-1)
+				case keyRight:
+					t.cycleDither(1)
+				case keyColorMode:
+					t.cycleColor()
+				case keyReset:
+					t = tuning{opts: opts, color: -1}
+				case keyQuit:
+					return t.flags(), nil
+				}
+			}
+		}
+	}
+}
+
+// fitAbove returns opts sized to leave the bottom rows of the terminal free, if
+// they size img to the terminal and it would fill the terminal's height.
+func fitAbove(img image.Image, opts dots.Options, rows int) dots.Options {
+	if opts.Width != 0 || opts.Height != 0 {
+		return opts
+	}
+	_, termHeight, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return opts
+	}
+	border := 0 // Explicit sizes include the frame
+	if opts.Frame {
+		border = 2
+	}
+	w, h := opts.OutputSize(img)
+	free := termHeight - rows - border
+	if h <= free || free < 1 {
+		return opts
+	}
+	opts.Width = max(w*free/h, 1) + border
+	opts.Height = free + border
+	return opts
+}
+
+// drawStatus writes status in reverse video on the bottom line of the terminal,
+// cut to its width.
+func drawStatus(w io.Writer, status string) error {
+	termWidth, termHeight, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return err
+	}
+	if runes := []rune(status); len(runes) > termWidth {
+		status = string(runes[:max(termWidth, 0)])
+	}
+	_, err = fmt.Fprintf(w, "\x1b[%d;1H\x1b[7m%s\x1b[0m\x1b[K", termHeight, status)
+	return err
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// verbose enables diagnostics on stderr, for working out why output looks wrong.
+// It's set by -verbose.
+var verbose bool
+
+// logf writes a line of diagnostics to stderr if verbose is set.
+func logf(format string, args ...any) {
+	if verbose {
+		fmt.Fprintf(os.Stderr, "dots: "+format+"\n", args...)
+	}
+}
+
+// logSince logs how long a stage took since start.
+func logSince(stage string, start time.Time) {
+	logf("%s took %v", stage, time.Since(start).Round(time.Microsecond))
+}
+
+// logTerminal logs what's known about the terminal on stdout.
+func logTerminal() {
+	if !verbose {
+		return
+	}
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		logf("terminal: stdout is not a terminal")
+	} else if w, h, err := term.GetSize(fd); err == nil {
+		logf("terminal: %dx%d characters", w, h)
+	} else {
+		logf("terminal: size unknown: %v", err)
+	}
+	for _, env := range []string{"TERM", "COLORTERM", "NO_COLOR", "FORCE_COLOR", "CLICOLOR_FORCE"} {
+		if v, ok := os.LookupEnv(env); ok {
+			logf("terminal: %s=%s", env, v)
+		}
+	}
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// version describes the binary for bug reports: the module version, and the
+// commit and build date stamped by go build in a checkout, and the Go version.
+func version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "dots (unknown version)"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "dots %s", info.Main.Version)
+	settings := map[string]string{}
+	for _, s := range info.Settings {
+		settings[s.Key] = s.Value
+	}
+	if rev := settings["vcs.revision"]; rev != "" {
+		fmt.Fprintf(&sb, "\ncommit: %s", rev)
+		if settings["vcs.modified"] == "true" {
+			sb.WriteString(" (modified)")
+		}
+	}
+	if date := settings["vcs.time"]; date != "" {
+		fmt.Fprintf(&sb, "\nbuilt: %s", date)
+	}
+	fmt.Fprintf(&sb, "\ngo: %s %s/%s", info.GoVersion, runtime.GOOS, runtime.GOARCH)
+	return sb.String()
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/imjasonh/dots"
+	"golang.org/x/term"
+)
+
+// viewKey is an action in the interactive viewer.
+type viewKey int
+
+const (
+	keyUp viewKey = iota
+	keyDown
+	keyLeft
+	keyRight
+	keyZoomIn
+	keyZoomOut
+	keyReset
+	keyColorMode // Next color mode, in -tune
+	keyQuit
+	mousePress    // Left button pressed
+	mouseDrag     // Moved with the left button held
+	mouseScrollUp // Wheel scrolled up
+	mouseScrollDn // Wheel scrolled down
+)
+
+// viewEvent is an action in the interactive viewer, with the cell it happened at
+// for mouse events.
+type viewEvent struct {
+	key  viewKey
+	x, y int // Column and row, from 0
+}
+
+// viewKeys maps input sequences to viewer actions.
+var viewKeys = map[string]viewKey{
+	"\x1b[A": keyUp, "k": keyUp,
+	"\x1b[B": keyDown, "j": keyDown,
+	"\x1b[D": keyLeft, "h": keyLeft,
+	"\x1b[C": keyRight, "l": keyRight,
+	"+": keyZoomIn, "=": keyZoomIn,
+	"-": keyZoomOut, "_": keyZoomOut,
+	"0": keyReset,
+	"c": keyColorMode,
+	"q": keyQuit, "\x03": keyQuit, "\x1b": keyQuit,
+}
+
+// view shows img on the full terminal screen, letting the user pan with the arrow
+// keys or hjkl and zoom with + and -, re-rendering the part in view at higher detail.
+// 0 resets the view, and q or Escape quits. On terminals that report mouse events,
+// dragging pans and scrolling zooms in or out at the pointer.
+func view(ctx context.Context, img image.Image, opts dots.Options) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("interactive mode requires a terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = term.Restore(fd, state) }()
+
+	// Use the alternate screen, so the shell's contents come back on exit
+	// and report mouse buttons and drags in SGR format
+	_, _ = io.WriteString(os.Stdout, "\x1b[?1049h\x1b[?25l\x1b[?1002h\x1b[?1006h")
+	defer func() { _, _ = io.WriteString(os.Stdout, "\x1b[?1006l\x1b[?1002l\x1b[?25h\x1b[?1049l") }()
+
+	input := stdinKeys()
+
+	resized := make(chan os.Signal, 1)
+	dots.NotifyResize(resized)
+	defer signal.Stop(resized)
+
+	v := dots.NewViewport()
+	var drag viewEvent // Last position of a drag
+	for {
+		drawn, err := drawView(os.Stdout, v.Crop(img), opts)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-resized:
+		case in, ok := <-input:
+			if !ok {
+				return nil
+			}
+			w, h := float64(max(drawn.Dx(), 1)), float64(max(drawn.Dy(), 1))
+			for _, ev := range parseInput(in) {
+				switch ev.key {
+				case keyUp:
+					v.Pan(0, -0.1)
+				case keyDown:
+					v.Pan(0, 0.1)
+				case keyLeft:
+					v.Pan(-0.1, 0)
+				case keyRight:
+					v.Pan(0.1, 0)
+				case keyZoomIn:
+					v.ZoomBy(1.5)
+				case keyZoomOut:
+					v.ZoomBy(1 / 1.5)
+				case keyReset:
+					v = dots.NewViewport()
+				case keyQuit:
+					return nil
+				case mousePress:
+					drag = ev
+				case mouseDrag:
+					// Move the image with the pointer
+					v.Pan(float64(drag.x-ev.x)/w, float64(drag.y-ev.y)/h)
+					drag = ev
+				case mouseScrollUp, mouseScrollDn:
+					factor := 1.25
+					if ev.key == mouseScrollDn {
+						factor = 1 / factor
+					}
+					v.ZoomAt(factor, (float64(ev.x-drawn.Min.X)+0.5)/w, (float64(ev.y-drawn.Min.Y)+0.5)/h)
+				}
+			}
+		}
+	}
+}
+
+// parseInput returns the viewer actions in a chunk of terminal input, ignoring unknown keys.
+func parseInput(in string) []viewEvent {
+	var events []viewEvent
+	for len(in) > 0 {
+		if rest, ok := strings.CutPrefix(in, "\x1b[<"); ok {
+			// SGR mouse event: ESC [ < button ; column ; row, then M for press or m for release
+			end := strings.IndexAny(rest, "Mm")
+			if end < 0 {
+				break
+			}
+			if ev, ok := parseMouse(rest[:end], rest[end] == 'M'); ok {
+				events = append(events, ev)
+			}
+			in = rest[end+1:]
+			continue
+		}
+
+		n := 1
+		if strings.HasPrefix(in, "\x1b[") && len(in) >= 3 {
+			// Arrow keys; a lone Escape quits
+			n = 3
+		}
+		if key, ok := viewKeys[in[:n]]; ok {
+			events = append(events, viewEvent{key: key})
+		}
+		in = in[n:]
+	}
+	return events
+}
+
+// parseMouse parses the parameters of an SGR mouse event, returning false for
+// events the viewer doesn't use.
+func parseMouse(params string, press bool) (viewEvent, bool) {
+	var button, x, y int
+	if _, err := fmt.Sscanf(params, "%d;%d;%d", &button, &x, &y); err != nil || !press {
+		return viewEvent{}, false
+	}
+	ev := viewEvent{x: x - 1, y: y - 1}
+	switch button {
+	case 0:
+		ev.key = mousePress
+	case 32: // Motion, with the left button held
+		ev.key = mouseDrag
+	case 64:
+		ev.key = mouseScrollUp
+	case 65:
+		ev.key = mouseScrollDn
+	default:
+		return viewEvent{}, false
+	}
+	return ev, true
+}
+
+// drawView draws img over the whole screen, without clearing it first, so the
+// redraw doesn't flicker. It's drawn from the top-left corner, or aligned as
+// opts.Align says, and centered vertically too if it's centered. The terminal is
+// in raw mode, so lines are separated by explicit carriage returns. It returns
+// the cells of the image on the screen.
+func drawView(w io.Writer, img image.Image, opts dots.Options) (image.Rectangle, error) {
+	grid := dots.ConvertGrid(img, opts)
+	var size image.Point
+	if len(grid) > 0 {
+		size = image.Pt(len(grid[0]), len(grid))
+	}
+	// Align the image here, instead of in Render, to center it vertically too
+	align := opts.Align
+	opts.Align = dots.AlignLeft
+	lines := grid.Render(opts)
+	border := 0 // A frame adds a line and a column on each side
+	if opts.Frame {
+		border = 1
+	}
+	var pad image.Point
+	if termWidth, termHeight, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		pad.X = align.Offset(size.X+2*border, termWidth)
+		if align == dots.AlignCenter {
+			pad.Y = align.Offset(len(lines), termHeight)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\x1b[H")
+	for range pad.Y {
+		sb.WriteString("\x1b[K\r\n")
+	}
+	padding := strings.Repeat(" ", pad.X)
+	for i, line := range lines {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString(padding)
+		sb.WriteString(line)
+		sb.WriteString("\x1b[K") // Clear the rest of the line
+	}
+	sb.WriteString("\x1b[J") // Clear the rest of the screen
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return image.Rectangle{}, err
+	}
+	origin := pad.Add(image.Pt(border, border))
+	return image.Rectangle{origin, origin.Add(size)}, nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/imjasonh/dots"
+)
+
+// watchSettle is how long a watched file must go unchanged before it's rendered
+// again, so a save that takes several writes renders once, when it's complete.
+const watchSettle = 100 * time.Millisecond
+
+// watchFile calls render, then calls it again whenever the file at path changes
+// or the terminal is resized, until ctx is done. The file's directory is watched
+// rather than the file, so saves that replace the file by renaming another over
+// it are seen too, as many editors do. The context passed to render is canceled
+// before each re-render, so long-running renders such as animation playback
+// restart too.
+func watchFile(ctx context.Context, path string, render func(ctx context.Context)) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = watcher.Close() }()
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	changed := make(chan struct{}, 1)
+	go func() {
+		settle := time.NewTimer(0)
+		<-settle.C
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// A removed or renamed file is rendered once it reappears
+				if filepath.Clean(ev.Name) == path && ev.Has(fsnotify.Write|fsnotify.Create) {
+					settle.Reset(watchSettle)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logf("watch: %v", err)
+			case <-settle.C:
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	resized := make(chan os.Signal, 1)
+	dots.NotifyResize(resized)
+	defer signal.Stop(resized)
+
+	for {
+		renderCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			render(renderCtx)
+		}()
+
+		select {
+		case <-ctx.Done():
+		case <-changed:
+		case <-resized:
+		}
+		cancel()
+		<-done
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plot.png")
+	if err := os.WriteFile(path, []byte("v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	renders := make(chan struct{}, 10)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- watchFile(ctx, path, func(context.Context) { renders <- struct{}{} })
+	}()
+	wait := func(what string) {
+		t.Helper()
+		select {
+		case <-renders:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no render after %s", what)
+		}
+	}
+	wait("starting")
+
+	if err := os.WriteFile(path, []byte("v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wait("writing the file")
+
+	// Editors often save by renaming a new file over the old one
+	tmp := filepath.Join(dir, ".plot.png.tmp")
+	if err := os.WriteFile(tmp, []byte("v3"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	wait("renaming over the file")
+
+	// Other files in the directory don't matter
+	if err := os.WriteFile(filepath.Join(dir, "other.png"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-renders:
+		t.Error("rendered after another file changed")
+	case <-time.After(3 * watchSettle):
+	}
+
+	cancel()
+	if err := <-errc; err != nil {
+		t.Errorf("watchFile() = %v", err)
+	}
+}
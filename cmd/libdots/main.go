@@ -0,0 +1,90 @@
+// Command libdots builds the braille converter as a C shared library,
+// so non-Go tools can reuse it without spawning the CLI.
+//
+// Build it with:
+//
+//	go build -buildmode=c-shared -o libdots.so ./cmd/libdots
+//
+// which also writes libdots.h declaring:
+//
+//	char* DotsConvert(char* path, DotsOptions opts);
+//	void DotsFree(char* s);
+//
+// From Python, for example:
+//
+//	lib = ctypes.CDLL("./libdots.so")
+//	lib.DotsConvert.restype = ctypes.c_void_p
+//	ptr = lib.DotsConvert(b"image.png", DotsOptions(width=80))
+//	print(ctypes.string_at(ptr).decode())
+//	lib.DotsFree(ptr)
+package main
+
+/*
+#include <stdlib.h>
+
+// DotsOptions configures DotsConvert. Zero values select the defaults.
+typedef struct {
+	int width;     // Width in braille characters, not negative
+	int height;    // Height in braille characters, not negative
+	int threshold; // Brightness threshold (0-255)
+	int no_color;  // Nonzero disables ANSI color output
+	int frame;     // Nonzero draws a frame around the picture
+} DotsOptions;
+*/
+import "C"
+
+import (
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"strings"
+	"unsafe"
+
+	"github.com/imjasonh/dots"
+)
+
+// DotsConvert renders the image at path as braille, returning the lines joined by newlines.
+// The result must be released with DotsFree. Returns NULL if the image can't be read or decoded,
+// or an option is out of range.
+//
+//export DotsConvert
+func DotsConvert(path *C.char, opts C.DotsOptions) *C.char {
+	if opts.threshold < 0 || opts.threshold > 255 || opts.width < 0 || opts.height < 0 {
+		return nil
+	}
+	f, err := os.Open(C.GoString(path))
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil
+	}
+
+	o := dots.Options{
+		Width:     int(opts.width),
+		Height:    int(opts.height),
+		Threshold: uint8(opts.threshold),
+		NoColor:   opts.no_color != 0,
+		Frame:     opts.frame != 0,
+	}
+	// There is no terminal to size against, so default to a fixed width
+	if o.Width == 0 && o.Height == 0 {
+		o.Width = 80
+	}
+
+	return C.CString(strings.Join(dots.Convert(img, o), "\n"))
+}
+
+// DotsFree releases a string returned by DotsConvert.
+//
+//export DotsFree
+func DotsFree(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+func main() {}
@@ -1,10 +1,23 @@
 package dots
 
-import "fmt"
+import (
+	"fmt"
+	"image/color"
+	"math"
+)
 
 // ParseHex parses a hex color string (with or without #) and returns the ANSI 256 color code.
 // Supports both 3-character shorthand (e.g., "f00") and 6-character full format (e.g., "ff0000").
 func ParseHex(hex string) (uint8, error) {
+	c, err := ParseHexColor(hex)
+	if err != nil {
+		return 0, err
+	}
+	return quantizeRGB(c.R, c.G, c.B), nil
+}
+
+// ParseHexColor parses a hex color string like ParseHex, but returns the exact color.
+func ParseHexColor(hex string) (color.RGBA, error) {
 	// Remove # prefix if present
 	if len(hex) > 0 && hex[0] == '#' {
 		hex = hex[1:]
@@ -17,14 +30,25 @@ func ParseHex(hex string) (uint8, error) {
 	}
 
 	if len(hex) != 6 {
-		return 0, fmt.Errorf("invalid hex color length: %d (expected 3 or 6)", len(hex))
+		return color.RGBA{}, fmt.Errorf("invalid hex color length: %d (expected 3 or 6)", len(hex))
 	}
 
 	var r, g, b uint8
 	_, err := fmt.Sscanf(hex, "%02x%02x%02x", &r, &g, &b)
 	if err != nil {
-		return 0, fmt.Errorf("invalid hex color format: %w", err)
+		return color.RGBA{}, fmt.Errorf("invalid hex color format: %w", err)
 	}
 
-	return quantizeRGB(r, g, b), nil
+	return color.RGBA{r, g, b, 255}, nil
+}
+
+// SepiaTint is a dark brown to cream gradient for Options.Tint.
+var SepiaTint = [2]color.RGBA{{43, 29, 14, 255}, {244, 228, 193, 255}}
+
+// lerpColor interpolates between two colors, returning a at t=0 and b at t=1.
+func lerpColor(a, b color.RGBA, t float64) color.RGBA {
+	lerp := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*t))
+	}
+	return color.RGBA{lerp(a.R, b.R), lerp(a.G, b.G), lerp(a.B, b.B), 255}
 }
@@ -1,6 +1,9 @@
 package dots
 
-import "testing"
+import (
+	"image/color"
+	"testing"
+)
 
 func TestParseHex(t *testing.T) {
 	for _, tt := range []struct {
@@ -96,13 +99,13 @@ func TestParseHex(t *testing.T) {
 		{
 			desc:     "near-white (eee)",
 			hex:      "eee",
-			wantANSI: 231, // Should map to white, not black
+			wantANSI: 255, // Lightest grayscale step, not black
 			wantErr:  false,
 		},
 		{
 			desc:     "near-white (eeeeee)",
 			hex:      "eeeeee",
-			wantANSI: 231, // Should map to white, not black
+			wantANSI: 255, // Lightest grayscale step, not black
 			wantErr:  false,
 		},
 		{
@@ -202,3 +205,57 @@ func TestParseHexConsistency(t *testing.T) {
 		})
 	}
 }
+
+func TestParseHexColor(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		hex     string
+		want    color.RGBA
+		wantErr bool
+	}{
+		{desc: "6-char hex", hex: "#ff8000", want: color.RGBA{255, 128, 0, 255}},
+		{desc: "3-char shorthand", hex: "eee", want: color.RGBA{238, 238, 238, 255}},
+		{desc: "invalid", hex: "ff00gg", wantErr: true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseHexColor(tt.hex)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseHexColor(%q) expected error, got nil", tt.hex)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseHexColor(%q) unexpected error: %v", tt.hex, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseHexColor(%q) = %v, want %v", tt.hex, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTint(t *testing.T) {
+	// Solarized base03 to base3
+	tint := [2]color.RGBA{{0, 43, 54, 255}, {253, 246, 227, 255}}
+	for _, tt := range []struct {
+		desc string
+		c    color.RGBA
+		want color.RGBA
+	}{
+		{desc: "black maps to the dark end", c: color.RGBA{0, 0, 0, 255}, want: color.RGBA{0, 43, 54, 255}},
+		{desc: "white maps to the light end", c: color.RGBA{255, 255, 255, 255}, want: color.RGBA{253, 246, 227, 255}},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			quantize := Options{Tint: &tint}.quantizer()
+			want := quantizeRGB(tt.want.R, tt.want.G, tt.want.B)
+			if got := quantize(tt.c.R, tt.c.G, tt.c.B); got != want {
+				t.Errorf("quantize(%v) = %d, want %d", tt.c, got, want)
+			}
+		})
+	}
+
+	if got, want := lerpColor(tint[0], tint[1], 0.5), (color.RGBA{127, 145, 141, 255}); got != want {
+		t.Errorf("lerpColor(0.5) = %v, want %v", got, want)
+	}
+}
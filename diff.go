@@ -0,0 +1,98 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+)
+
+// ImageDiff is the per-pixel difference between two images, for visual regression
+// checks. The images are compared with their top left corners aligned, over the
+// union of their sizes.
+type ImageDiff struct {
+	// Delta holds each pixel's difference: the largest difference between its
+	// channels, including alpha. Pixels only one of the images covers differ by 255.
+	Delta *image.Gray
+	// Tolerance is the largest difference that doesn't count as a change.
+	Tolerance uint8
+	// Changed is the number of pixels that differ by more than Tolerance, and
+	// MaxDelta is the largest difference.
+	Changed  int
+	MaxDelta uint8
+	// Changes bounds the changed pixels; it's empty if there are none.
+	Changes image.Rectangle
+}
+
+// DiffImages compares a and b pixel by pixel, ignoring differences of up to
+// tolerance in each channel.
+func DiffImages(a, b image.Image, tolerance uint8) *ImageDiff {
+	ab, bb := a.Bounds(), b.Bounds()
+	bounds := image.Rect(0, 0, max(ab.Dx(), bb.Dx()), max(ab.Dy(), bb.Dy()))
+	d := &ImageDiff{Delta: image.NewGray(bounds), Tolerance: tolerance}
+	for y := range bounds.Dy() {
+		for x := range bounds.Dx() {
+			pa, pb := image.Pt(ab.Min.X+x, ab.Min.Y+y), image.Pt(bb.Min.X+x, bb.Min.Y+y)
+			delta := uint8(255)
+			if pa.In(ab) && pb.In(bb) {
+				delta = colorDelta(a.At(pa.X, pa.Y), b.At(pb.X, pb.Y))
+			}
+			d.Delta.SetGray(x, y, color.Gray{delta})
+			d.MaxDelta = max(d.MaxDelta, delta)
+			if delta > tolerance {
+				d.Changed++
+				d.Changes = d.Changes.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return d
+}
+
+// colorDelta returns the largest difference between the 8-bit channels of two colors.
+func colorDelta(a, b color.Color) uint8 {
+	ca := color.RGBAModel.Convert(a).(color.RGBA)
+	cb := color.RGBAModel.Convert(b).(color.RGBA)
+	diff := func(x, y uint8) uint8 { return max(x, y) - min(x, y) }
+	return max(diff(ca.R, cb.R), diff(ca.G, cb.G), diff(ca.B, cb.B), diff(ca.A, cb.A))
+}
+
+// Fraction returns the fraction of the pixels that changed, from 0 to 1.
+func (d *ImageDiff) Fraction() float64 {
+	b := d.Delta.Bounds()
+	if b.Empty() {
+		return 0
+	}
+	return float64(d.Changed) / float64(b.Dx()*b.Dy())
+}
+
+// heatGradient colors changes by their size, from small (blue) to large (yellow).
+var heatGradient = []color.RGBA{
+	{0x30, 0x60, 0xff, 255},
+	{0xff, 0x20, 0x20, 255},
+	{0xff, 0xff, 0x40, 255},
+}
+
+// Image returns a picture of the changes: black where the images match and white
+// where they differ, or if heat is set, colored from blue to red to yellow by how
+// much they differ. Converting it with a Threshold of 1 and ColorLitDots lights
+// exactly the changed pixels.
+func (d *ImageDiff) Image(heat bool) *image.RGBA {
+	b := d.Delta.Bounds()
+	img := image.NewRGBA(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			delta := d.Delta.GrayAt(x, y).Y
+			c := color.RGBA{A: 255}
+			switch {
+			case delta <= d.Tolerance:
+			case heat:
+				t := float64(delta-d.Tolerance) / float64(255-d.Tolerance)
+				pos := t * float64(len(heatGradient)-1)
+				i := min(int(pos), len(heatGradient)-2)
+				c = lerpColor(heatGradient[i], heatGradient[i+1], pos-float64(i))
+			default:
+				c = color.RGBA{255, 255, 255, 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
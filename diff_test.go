@@ -0,0 +1,76 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestDiffImages(t *testing.T) {
+	gray := func(w, h int, v uint8) *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(img, img.Bounds(), &image.Uniform{color.RGBA{v, v, v, 255}}, image.Point{}, draw.Src)
+		return img
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		d := DiffImages(gray(4, 4, 100), gray(4, 4, 100), 0)
+		if d.Changed != 0 || d.MaxDelta != 0 || !d.Changes.Empty() {
+			t.Errorf("got %d changed, max delta %d, changes %v, want none", d.Changed, d.MaxDelta, d.Changes)
+		}
+	})
+
+	t.Run("changed region", func(t *testing.T) {
+		b := gray(4, 4, 100)
+		b.SetRGBA(1, 2, color.RGBA{100, 140, 100, 255})
+		b.SetRGBA(2, 3, color.RGBA{105, 100, 100, 255})
+		d := DiffImages(gray(4, 4, 100), b, 10)
+		if d.Changed != 1 {
+			t.Errorf("Changed = %d, want 1", d.Changed)
+		}
+		if d.MaxDelta != 40 {
+			t.Errorf("MaxDelta = %d, want 40", d.MaxDelta)
+		}
+		if want := image.Rect(1, 2, 2, 3); d.Changes != want {
+			t.Errorf("Changes = %v, want %v", d.Changes, want)
+		}
+		if got, want := d.Fraction(), 1.0/16; got != want {
+			t.Errorf("Fraction() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("different sizes", func(t *testing.T) {
+		d := DiffImages(gray(4, 2, 0), gray(2, 4, 0), 0)
+		if got := d.Delta.Bounds(); got != image.Rect(0, 0, 4, 4) {
+			t.Errorf("Delta bounds = %v, want the union", got)
+		}
+		// Only the overlap matches
+		if d.Changed != 12 || d.MaxDelta != 255 {
+			t.Errorf("got %d changed, max delta %d, want 12 and 255", d.Changed, d.MaxDelta)
+		}
+	})
+}
+
+func TestImageDiffImage(t *testing.T) {
+	a := image.NewRGBA(image.Rect(0, 0, 3, 1))
+	b := image.NewRGBA(image.Rect(0, 0, 3, 1))
+	b.SetRGBA(1, 0, color.RGBA{10, 10, 10, 10})
+	b.SetRGBA(2, 0, color.RGBA{255, 255, 255, 255})
+	d := DiffImages(a, b, 0)
+
+	plain := d.Image(false)
+	for x, want := range []color.RGBA{{A: 255}, {255, 255, 255, 255}, {255, 255, 255, 255}} {
+		if got := plain.RGBAAt(x, 0); got != want {
+			t.Errorf("plain pixel %d = %v, want %v", x, got, want)
+		}
+	}
+
+	heat := d.Image(true)
+	if got := heat.RGBAAt(0, 0); got != (color.RGBA{A: 255}) {
+		t.Errorf("unchanged heat pixel = %v, want black", got)
+	}
+	if small, large := heat.RGBAAt(1, 0), heat.RGBAAt(2, 0); small.B <= large.B || large != heatGradient[len(heatGradient)-1] {
+		t.Errorf("heat pixels = %v and %v, want small changes blue and the largest yellow", small, large)
+	}
+}
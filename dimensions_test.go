@@ -1,6 +1,9 @@
 package dots
 
-import "testing"
+import (
+	"image"
+	"testing"
+)
 
 func TestCalculateDimensions(t *testing.T) {
 	for _, tt := range []struct {
@@ -233,3 +236,116 @@ func TestCalculateDimensionsAspectRatio(t *testing.T) {
 		t.Errorf("1:4 aspect not preserved: got %.2f, want %.2f", outputAspect, imgAspect)
 	}
 }
+
+func TestCalculateCellDimensions(t *testing.T) {
+	for _, tt := range []struct {
+		desc                  string
+		imgWidth, imgHeight   int
+		width, height         int
+		maxWidth, maxHeight   int
+		cellWidth, cellHeight int
+		wantWidth, wantHeight int
+	}{
+		{
+			desc:     "8-dot matches CalculateDimensions",
+			imgWidth: 100, imgHeight: 100,
+			width:     20,
+			cellWidth: 2, cellHeight: 4,
+			wantWidth: 20, wantHeight: 10,
+		},
+		{
+			desc:     "6-dot square image, width specified",
+			imgWidth: 100, imgHeight: 100,
+			width:     30,
+			cellWidth: 2, cellHeight: 3,
+			wantWidth: 30, wantHeight: 20, // 30 * 100/100 * 2/3 = 20
+		},
+		{
+			desc:     "6-dot square image, height specified",
+			imgWidth: 100, imgHeight: 100,
+			height:    20,
+			cellWidth: 2, cellHeight: 3,
+			wantWidth: 30, wantHeight: 20, // 20 * 100/100 * 3/2 = 30
+		},
+		{
+			desc:     "6-dot wide image, terminal constrained",
+			imgWidth: 200, imgHeight: 50,
+			maxWidth: 80, maxHeight: 24,
+			cellWidth: 2, cellHeight: 3,
+			wantWidth: 80, wantHeight: 13, // 80 * 50/200 * 2/3 = 13.3
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			gotWidth, gotHeight := CalculateCellDimensions(tt.imgWidth, tt.imgHeight, tt.width, tt.height, tt.maxWidth, tt.maxHeight, tt.cellWidth, tt.cellHeight)
+			if gotWidth != tt.wantWidth || gotHeight != tt.wantHeight {
+				t.Errorf("CalculateCellDimensions() = (%d, %d), want (%d, %d)", gotWidth, gotHeight, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestCalculateAspectDimensions(t *testing.T) {
+	for _, tt := range []struct {
+		desc                  string
+		imgWidth, imgHeight   int
+		width, height         int
+		maxWidth, maxHeight   int
+		cellRatio             float64
+		wantWidth, wantHeight int
+	}{
+		{
+			desc:     "0.5 matches CalculateDimensions",
+			imgWidth: 100, imgHeight: 100,
+			width:     20,
+			cellRatio: 0.5,
+			wantWidth: 20, wantHeight: 10,
+		},
+		{
+			desc:     "narrow font, width specified",
+			imgWidth: 100, imgHeight: 100,
+			width:     40,
+			cellRatio: 0.45,
+			wantWidth: 40, wantHeight: 18, // 40 * 100/100 * 0.45 = 18
+		},
+		{
+			desc:     "wide font, height specified",
+			imgWidth: 100, imgHeight: 100,
+			height:    12,
+			cellRatio: 0.6,
+			wantWidth: 20, wantHeight: 12, // 12 * 100/100 / 0.6 = 20
+		},
+		{
+			desc:     "narrow font, terminal constrained",
+			imgWidth: 100, imgHeight: 100,
+			maxWidth: 80, maxHeight: 24,
+			cellRatio: 0.4,
+			wantWidth: 60, wantHeight: 24, // 24 * 100/100 / 0.4 = 60
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			gotWidth, gotHeight := CalculateAspectDimensions(tt.imgWidth, tt.imgHeight, tt.width, tt.height, tt.maxWidth, tt.maxHeight, tt.cellRatio)
+			if gotWidth != tt.wantWidth || gotHeight != tt.wantHeight {
+				t.Errorf("CalculateAspectDimensions() = (%d, %d), want (%d, %d)", gotWidth, gotHeight, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestOutputSize(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	for _, tt := range []struct {
+		desc                  string
+		opts                  Options
+		wantWidth, wantHeight int
+	}{
+		{desc: "both given", opts: Options{Width: 30, Height: 7}, wantWidth: 30, wantHeight: 7},
+		{desc: "width given", opts: Options{Width: 20, CellAspect: 0.5}, wantWidth: 20, wantHeight: 10},
+		{desc: "framed", opts: Options{Width: 30, Height: 7, Frame: true}, wantWidth: 28, wantHeight: 5},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if w, h := tt.opts.OutputSize(img); w != tt.wantWidth || h != tt.wantHeight {
+				t.Errorf("OutputSize() = (%d, %d), want (%d, %d)", w, h, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
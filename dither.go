@@ -0,0 +1,197 @@
+package dots
+
+import (
+	"fmt"
+	"image/color"
+)
+
+//go:generate go run gen_bluenoise.go
+
+// DitherAlgorithm selects the error-diffusion kernel used to dither the dot matrix.
+type DitherAlgorithm string
+
+// Supported dithering algorithms.
+const (
+	DitherNone           DitherAlgorithm = ""
+	DitherFloydSteinberg DitherAlgorithm = "floyd-steinberg"
+	DitherSierra         DitherAlgorithm = "sierra"
+	DitherSierraLite     DitherAlgorithm = "sierra-lite"
+	DitherJJN            DitherAlgorithm = "jjn" // Jarvis–Judice–Ninke
+	// DitherBlueNoise thresholds against a precomputed blue-noise mask instead of diffusing error.
+	// It produces organic textures and is deterministic per pixel, so it is stable across video frames.
+	DitherBlueNoise DitherAlgorithm = "blue-noise"
+)
+
+// DitherAlgorithms lists the names of all supported dithering algorithms.
+var DitherAlgorithms = []DitherAlgorithm{DitherFloydSteinberg, DitherSierra, DitherSierraLite, DitherJJN, DitherBlueNoise}
+
+// ParseDitherAlgorithm validates a dithering algorithm name.
+// The empty string and "none" select no dithering.
+func ParseDitherAlgorithm(s string) (DitherAlgorithm, error) {
+	if s == "" || s == "none" {
+		return DitherNone, nil
+	}
+	for _, alg := range DitherAlgorithms {
+		if DitherAlgorithm(s) == alg {
+			return alg, nil
+		}
+	}
+	return DitherNone, fmt.Errorf("unknown dithering algorithm %q", s)
+}
+
+// ditherWeight distributes a share of the quantization error to the pixel at offset (dx, dy).
+type ditherWeight struct {
+	dx, dy int
+	weight float64
+}
+
+// ditherKernel is an error-diffusion kernel: each weight is divided by divisor.
+type ditherKernel struct {
+	divisor float64
+	weights []ditherWeight
+}
+
+// ditherKernels holds the error-diffusion kernel for each algorithm.
+var ditherKernels = map[DitherAlgorithm]ditherKernel{
+	//     * 7
+	//   3 5 1
+	DitherFloydSteinberg: {16, []ditherWeight{
+		{1, 0, 7},
+		{-1, 1, 3}, {0, 1, 5}, {1, 1, 1},
+	}},
+	//         * 5 3
+	//     2 4 5 4 2
+	//       2 3 2
+	DitherSierra: {32, []ditherWeight{
+		{1, 0, 5}, {2, 0, 3},
+		{-2, 1, 2}, {-1, 1, 4}, {0, 1, 5}, {1, 1, 4}, {2, 1, 2},
+		{-1, 2, 2}, {0, 2, 3}, {1, 2, 2},
+	}},
+	//     * 2
+	//   1 1
+	DitherSierraLite: {4, []ditherWeight{
+		{1, 0, 2},
+		{-1, 1, 1}, {0, 1, 1},
+	}},
+	//         * 7 5
+	//     3 5 7 5 3
+	//     1 3 5 3 1
+	DitherJJN: {48, []ditherWeight{
+		{1, 0, 7}, {2, 0, 5},
+		{-2, 1, 3}, {-1, 1, 5}, {0, 1, 7}, {1, 1, 5}, {2, 1, 3},
+		{-2, 2, 1}, {-1, 2, 3}, {0, 2, 5}, {1, 2, 3}, {2, 2, 1},
+	}},
+}
+
+// applyDithering converts a luminance matrix to dots using error diffusion:
+// each pixel is thresholded, and the difference between its luminance and the
+// chosen output (0 or 255) is spread over its unvisited neighbors by the kernel.
+// If serpentine is set, odd rows are scanned right to left with the kernel mirrored,
+// which avoids the diagonal artifacts of always scanning in one direction.
+// The luminance matrix is modified in place.
+func applyDithering(lum [][]float64, threshold uint8, kernel ditherKernel, serpentine bool) [][]bool {
+	dots := make([][]bool, len(lum))
+	for y := range lum {
+		dots[y] = make([]bool, len(lum[y]))
+		reverse := serpentine && y%2 == 1
+		for i := range lum[y] {
+			x, dir := i, 1
+			if reverse {
+				x, dir = len(lum[y])-1-i, -1
+			}
+
+			old := lum[y][x]
+			out := 0.0
+			if old > float64(threshold) {
+				out = 255
+				dots[y][x] = true
+			}
+			quantErr := old - out
+
+			for _, w := range kernel.weights {
+				nx, ny := x+w.dx*dir, y+w.dy
+				if ny < len(lum) && nx >= 0 && nx < len(lum[ny]) {
+					lum[ny][nx] += quantErr * w.weight / kernel.divisor
+				}
+			}
+		}
+	}
+	return dots
+}
+
+// cellDither diffuses the color quantization error of each cell's foreground and
+// background colors over the neighboring cells, reducing color banding in gradients.
+type cellDither struct {
+	fg, bg colorDiffuser
+}
+
+// newCellDither returns the color ditherer for a conversion, or nil if
+// opts.ColorDither is unset. It uses the DitherAlgorithm's error-diffusion
+// kernel, or Floyd–Steinberg.
+func newCellDither(opts Options) *cellDither {
+	if !opts.ColorDither {
+		return nil
+	}
+	kernel, ok := ditherKernels[opts.DitherAlgorithm]
+	if !ok {
+		kernel = ditherKernels[DitherFloydSteinberg]
+	}
+	return &cellDither{
+		fg: newColorDiffuser(opts.Width, opts.Height, kernel),
+		bg: newColorDiffuser(opts.Width, opts.Height, kernel),
+	}
+}
+
+// colorDiffuser spreads the difference between each cell's color and its
+// quantized color over the unvisited cells, which must be visited in row order.
+type colorDiffuser struct {
+	kernel ditherKernel
+	errs   [][][3]float64 // Diffused error, indexed [y][x]
+}
+
+// newColorDiffuser creates a diffuser for a width×height grid of cells.
+func newColorDiffuser(width, height int, kernel ditherKernel) colorDiffuser {
+	errs := make([][][3]float64, height)
+	for y := range errs {
+		errs[y] = make([][3]float64, width)
+	}
+	return colorDiffuser{kernel: kernel, errs: errs}
+}
+
+// quantize returns the ANSI 256 color code for the cell at (x, y), whose own color
+// is (r, g, b), after adding the error diffused to it and diffusing its own error onward.
+func (d colorDiffuser) quantize(x, y int, r, g, b uint8, quantize func(r, g, b uint8) uint8, codeColor func(uint8) color.RGBA) uint8 {
+	var want [3]float64
+	for i, v := range [3]uint8{r, g, b} {
+		want[i] = max(0, min(float64(v)+d.errs[y][x][i], 255))
+	}
+	code := quantize(uint8(want[0]+0.5), uint8(want[1]+0.5), uint8(want[2]+0.5))
+	got := codeColor(code)
+	quantErr := [3]float64{want[0] - float64(got.R), want[1] - float64(got.G), want[2] - float64(got.B)}
+
+	for _, w := range d.kernel.weights {
+		nx, ny := x+w.dx, y+w.dy
+		if ny < len(d.errs) && nx >= 0 && nx < len(d.errs[ny]) {
+			for i := range quantErr {
+				d.errs[ny][nx][i] += quantErr[i] * w.weight / d.kernel.divisor
+			}
+		}
+	}
+	return code
+}
+
+// applyBlueNoise converts a luminance matrix to dots by comparing each pixel
+// against the tiled blue-noise mask, centered on the threshold.
+func applyBlueNoise(lum [][]float64, threshold uint8) [][]bool {
+	const maskSize = 64
+	dots := make([][]bool, len(lum))
+	for y := range lum {
+		dots[y] = make([]bool, len(lum[y]))
+		for x := range lum[y] {
+			// Map the mask value to the open interval (0, 255) so black never lights and white always does
+			m := (float64(blueNoise[(y%maskSize)*maskSize+x%maskSize]) + 0.5) * 255 / 256
+			dots[y][x] = lum[y][x]-float64(threshold)+128 > m
+		}
+	}
+	return dots
+}
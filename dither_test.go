@@ -0,0 +1,153 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestParseDitherAlgorithm(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    DitherAlgorithm
+		wantErr bool
+	}{
+		{in: "", want: DitherNone},
+		{in: "none", want: DitherNone},
+		{in: "floyd-steinberg", want: DitherFloydSteinberg},
+		{in: "sierra", want: DitherSierra},
+		{in: "sierra-lite", want: DitherSierraLite},
+		{in: "jjn", want: DitherJJN},
+		{in: "blue-noise", want: DitherBlueNoise},
+		{in: "bogus", wantErr: true},
+	} {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseDitherAlgorithm(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDitherAlgorithm(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDitherAlgorithm(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDitherKernelsSumToDivisor(t *testing.T) {
+	for alg, kernel := range ditherKernels {
+		sum := 0.0
+		for _, w := range kernel.weights {
+			sum += w.weight
+			if w.dy < 0 || (w.dy == 0 && w.dx <= 0) {
+				t.Errorf("%s: weight at (%d, %d) diffuses to an already-visited pixel", alg, w.dx, w.dy)
+			}
+		}
+		if sum != kernel.divisor {
+			t.Errorf("%s: weights sum to %v, want %v", alg, sum, kernel.divisor)
+		}
+	}
+}
+
+func TestDitheringPreservesMidGray(t *testing.T) {
+	// A uniform mid-gray image is all-on or all-off when thresholded,
+	// but roughly half-lit when dithered.
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			img.Set(x, y, color.Gray{Y: 128})
+		}
+	}
+
+	for _, alg := range DitherAlgorithms {
+		t.Run(string(alg), func(t *testing.T) {
+			dots := ConvertToDots(img, Options{Width: 16, Height: 8, DitherAlgorithm: alg})
+			lit, total := 0, 0
+			for _, row := range dots {
+				for _, on := range row {
+					total++
+					if on {
+						lit++
+					}
+				}
+			}
+			if frac := float64(lit) / float64(total); frac < 0.4 || frac > 0.6 {
+				t.Errorf("lit fraction = %.2f, want about 0.5", frac)
+			}
+		})
+	}
+}
+
+func TestBlueNoiseIsDeterministic(t *testing.T) {
+	lum := [][]float64{{0, 64, 128, 192, 255}, {255, 192, 128, 64, 0}}
+	a := applyBlueNoise(lum, 128)
+	b := applyBlueNoise(lum, 128)
+	for y := range a {
+		for x := range a[y] {
+			if a[y][x] != b[y][x] {
+				t.Errorf("dot (%d, %d) differs between runs", x, y)
+			}
+		}
+	}
+	if a[0][0] || a[1][4] {
+		t.Error("black pixels should never be lit")
+	}
+	if !a[0][4] || !a[1][0] {
+		t.Error("white pixels should always be lit")
+	}
+}
+
+func TestSerpentineDithering(t *testing.T) {
+	// Serpentine scanning mirrors the kernel on odd rows, so a mirrored input
+	// must produce a mirrored second row.
+	row := []float64{100, 30, 200, 90, 160, 10, 240, 120}
+	mirrored := make([]float64, len(row))
+	for i, v := range row {
+		mirrored[len(row)-1-i] = v
+	}
+
+	kernel := ditherKernels[DitherFloydSteinberg]
+	// A white first row diffuses no error
+	a := applyDithering([][]float64{{255, 255, 255, 255, 255, 255, 255, 255}, append([]float64(nil), row...)}, 128, kernel, false)
+	b := applyDithering([][]float64{{255, 255, 255, 255, 255, 255, 255, 255}, append([]float64(nil), mirrored...)}, 128, kernel, true)
+	for x := range row {
+		if a[1][x] != b[1][len(row)-1-x] {
+			t.Errorf("dot %d: left-to-right = %v, mirrored serpentine = %v", x, a[1][x], b[1][len(row)-1-x])
+		}
+	}
+}
+
+func TestColorDither(t *testing.T) {
+	// Mid-gray, halfway between the only two palette entries
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	for i := 0; i < len(img.Pix); i += 4 {
+		copy(img.Pix[i:], []uint8{128, 128, 128, 255})
+	}
+	palette := []color.RGBA{{0, 0, 0, 255}, {255, 255, 255, 255}}
+
+	for _, tt := range []struct {
+		desc       string
+		dither     bool
+		wantColors int
+	}{
+		{desc: "without dithering every cell is the same", dither: false, wantColors: 1},
+		{desc: "dithering mixes both palette entries", dither: true, wantColors: 2},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			grid := ConvertGrid(img, Options{Width: 20, Height: 10, Palette: palette, ColorDither: tt.dither})
+			counts := map[color.RGBA]int{}
+			for _, cells := range grid {
+				for _, cell := range cells {
+					counts[cell.Fg]++
+				}
+			}
+			if len(counts) != tt.wantColors {
+				t.Fatalf("got %d colors, want %d: %v", len(counts), tt.wantColors, counts)
+			}
+			if tt.dither {
+				if white := counts[color.RGBA{255, 255, 255, 255}]; white < 80 || white > 120 {
+					t.Errorf("got %d white cells of 200, want about half", white)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package dots
+
+import (
+	"fmt"
+	"math"
+)
+
+// EdgeDetector selects an edge-detection mode, in which only the edges of the image are lit.
+type EdgeDetector string
+
+// Supported edge detectors.
+const (
+	EdgeNone  EdgeDetector = ""
+	EdgeSobel EdgeDetector = "sobel"
+	// EdgeCanny is slower than EdgeSobel but finds thin, connected edges and ignores
+	// noise, making it better suited to photographs.
+	EdgeCanny EdgeDetector = "canny"
+)
+
+// EdgeDetectors lists the names of all supported edge detectors.
+var EdgeDetectors = []EdgeDetector{EdgeSobel, EdgeCanny}
+
+// ParseEdgeDetector validates an edge detector name.
+// The empty string and "none" disable edge detection.
+func ParseEdgeDetector(s string) (EdgeDetector, error) {
+	if s == "" || s == "none" {
+		return EdgeNone, nil
+	}
+	for _, e := range EdgeDetectors {
+		if EdgeDetector(s) == e {
+			return e, nil
+		}
+	}
+	return EdgeNone, fmt.Errorf("unknown edge detector %q", s)
+}
+
+// sobel computes the gradient of a luminance matrix with the Sobel operator,
+// returning the horizontal and vertical components at every pixel.
+// Pixels beyond the border are treated as copies of the nearest edge pixel.
+func sobel(lum [][]float64) (gx, gy [][]float64) {
+	at := func(x, y int) float64 {
+		y = max(0, min(y, len(lum)-1))
+		x = max(0, min(x, len(lum[y])-1))
+		return lum[y][x]
+	}
+
+	gx = make([][]float64, len(lum))
+	gy = make([][]float64, len(lum))
+	for y := range lum {
+		gx[y] = make([]float64, len(lum[y]))
+		gy[y] = make([]float64, len(lum[y]))
+		for x := range lum[y] {
+			// -1 0 1      -1 -2 -1
+			// -2 0 2       0  0  0
+			// -1 0 1       1  2  1
+			gx[y][x] = at(x+1, y-1) + 2*at(x+1, y) + at(x+1, y+1) -
+				at(x-1, y-1) - 2*at(x-1, y) - at(x-1, y+1)
+			gy[y][x] = at(x-1, y+1) + 2*at(x, y+1) + at(x+1, y+1) -
+				at(x-1, y-1) - 2*at(x, y-1) - at(x+1, y-1)
+		}
+	}
+	return gx, gy
+}
+
+// sobelEdges lights the dots whose gradient magnitude exceeds the threshold.
+// Magnitudes are scaled by 1/4 so a full black-to-white step measures 255.
+func sobelEdges(lum [][]float64, threshold uint8) [][]bool {
+	gx, gy := sobel(lum)
+	dots := make([][]bool, len(lum))
+	for y := range lum {
+		dots[y] = make([]bool, len(lum[y]))
+		for x := range lum[y] {
+			dots[y][x] = math.Hypot(gx[y][x], gy[y][x])/4 > float64(threshold)
+		}
+	}
+	return dots
+}
+
+// cannyEdges runs the Canny edge detector: Gaussian blur, Sobel gradient,
+// non-maximum suppression to thin edges to one dot wide, and hysteresis, which
+// keeps weak edges (magnitude above low) only if they connect to strong ones (above high).
+// Magnitudes are scaled like sobelEdges.
+func cannyEdges(lum [][]float64, low, high uint8) [][]bool {
+	gx, gy := sobel(gaussianBlur(lum, 1.0))
+
+	h := len(lum)
+	mag := make([][]float64, h)
+	for y := range mag {
+		mag[y] = make([]float64, len(lum[y]))
+		for x := range mag[y] {
+			mag[y][x] = math.Hypot(gx[y][x], gy[y][x]) / 4
+		}
+	}
+	magAt := func(x, y int) float64 {
+		if y < 0 || y >= h || x < 0 || x >= len(mag[y]) {
+			return 0
+		}
+		return mag[y][x]
+	}
+
+	// Non-maximum suppression: keep pixels that are local maxima along the gradient direction
+	thin := make([][]float64, h)
+	for y := range thin {
+		thin[y] = make([]float64, len(mag[y]))
+		for x, m := range mag[y] {
+			// Quantize the gradient direction to 0°, 45°, 90°, or 135°
+			angle := math.Atan2(gy[y][x], gx[y][x]) * 180 / math.Pi
+			if angle < 0 {
+				angle += 180
+			}
+			var dx, dy int
+			switch {
+			case angle < 22.5 || angle >= 157.5:
+				dx, dy = 1, 0
+			case angle < 67.5:
+				dx, dy = 1, 1
+			case angle < 112.5:
+				dx, dy = 0, 1
+			default:
+				dx, dy = -1, 1
+			}
+			if m >= magAt(x+dx, y+dy) && m >= magAt(x-dx, y-dy) {
+				thin[y][x] = m
+			}
+		}
+	}
+
+	// Hysteresis: flood from strong edges through connected weak edges
+	dots := make([][]bool, h)
+	for y := range dots {
+		dots[y] = make([]bool, len(thin[y]))
+	}
+	var stack [][2]int
+	for y := range thin {
+		for x, m := range thin[y] {
+			if m > float64(high) && !dots[y][x] {
+				dots[y][x] = true
+				stack = append(stack, [2]int{x, y})
+			}
+			for len(stack) > 0 {
+				p := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				for ny := p[1] - 1; ny <= p[1]+1; ny++ {
+					for nx := p[0] - 1; nx <= p[0]+1; nx++ {
+						if ny < 0 || ny >= h || nx < 0 || nx >= len(thin[ny]) || dots[ny][nx] {
+							continue
+						}
+						if thin[ny][nx] > float64(low) {
+							dots[ny][nx] = true
+							stack = append(stack, [2]int{nx, ny})
+						}
+					}
+				}
+			}
+		}
+	}
+	return dots
+}
+
+// gaussianBlur returns a copy of a luminance matrix blurred with a Gaussian of the given sigma.
+// The blur is separable, so it is applied horizontally and then vertically.
+func gaussianBlur(lum [][]float64, sigma float64) [][]float64 {
+	kernel := gaussianKernel(sigma)
+	radius := len(kernel) / 2
+
+	blur := func(src [][]float64, horizontal bool) [][]float64 {
+		dst := make([][]float64, len(src))
+		for y := range src {
+			dst[y] = make([]float64, len(src[y]))
+			for x := range src[y] {
+				v := 0.0
+				for i, k := range kernel {
+					sx, sy := x, y
+					if horizontal {
+						sx = max(0, min(x+i-radius, len(src[y])-1))
+					} else {
+						sy = max(0, min(y+i-radius, len(src)-1))
+					}
+					v += src[sy][sx] * k
+				}
+				dst[y][x] = v
+			}
+		}
+		return dst
+	}
+	return blur(blur(lum, true), false)
+}
+
+// gaussianKernel returns a normalized 1D Gaussian kernel of the given sigma,
+// extending 2 sigma on each side of its center.
+func gaussianKernel(sigma float64) []float64 {
+	radius := int(math.Ceil(sigma * 2))
+	kernel := make([]float64, 2*radius+1)
+	sum := 0.0
+	for i := range kernel {
+		d := float64(i - radius)
+		kernel[i] = math.Exp(-d * d / (2 * sigma * sigma))
+		sum += kernel[i]
+	}
+	for i := range kernel {
+		kernel[i] /= sum
+	}
+	return kernel
+}
@@ -0,0 +1,95 @@
+package dots
+
+import "testing"
+
+func TestSobelEdges(t *testing.T) {
+	// Left half black, right half white: only the columns at the step are edges
+	lum := make([][]float64, 4)
+	for y := range lum {
+		lum[y] = []float64{0, 0, 0, 255, 255, 255}
+	}
+
+	dots := sobelEdges(lum, 48)
+	for y, row := range dots {
+		for x, lit := range row {
+			want := x == 2 || x == 3
+			if lit != want {
+				t.Errorf("dot (%d, %d) = %v, want %v", x, y, lit, want)
+			}
+		}
+	}
+}
+
+func TestSobelUniform(t *testing.T) {
+	lum := [][]float64{{128, 128, 128}, {128, 128, 128}, {128, 128, 128}}
+	for y, row := range sobelEdges(lum, 0) {
+		for x, lit := range row {
+			if lit {
+				t.Errorf("dot (%d, %d) lit in a uniform image", x, y)
+			}
+		}
+	}
+}
+
+func TestParseEdgeDetector(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    EdgeDetector
+		wantErr bool
+	}{
+		{in: "", want: EdgeNone},
+		{in: "none", want: EdgeNone},
+		{in: "sobel", want: EdgeSobel},
+		{in: "canny", want: EdgeCanny},
+		{in: "bogus", wantErr: true},
+	} {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseEdgeDetector(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEdgeDetector(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEdgeDetector(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCannyEdges(t *testing.T) {
+	// A vertical step: Canny should find a single, one-dot-wide vertical line
+	lum := make([][]float64, 8)
+	for y := range lum {
+		lum[y] = []float64{0, 0, 0, 0, 255, 255, 255, 255}
+	}
+
+	dots := cannyEdges(lum, 24, 64)
+	for y, row := range dots {
+		lit := 0
+		for _, on := range row {
+			if on {
+				lit++
+			}
+		}
+		if lit != 1 {
+			t.Errorf("row %d: %d dots lit, want 1", y, lit)
+		}
+		if !row[3] && !row[4] {
+			t.Errorf("row %d: edge should be at the step", y)
+		}
+	}
+}
+
+func TestCannyHysteresis(t *testing.T) {
+	// A faint step on its own is below the high threshold and is dropped
+	lum := make([][]float64, 6)
+	for y := range lum {
+		lum[y] = []float64{100, 100, 100, 140, 140, 140}
+	}
+	for y, row := range cannyEdges(lum, 5, 64) {
+		for x, on := range row {
+			if on {
+				t.Errorf("dot (%d, %d) lit for a weak, unconnected edge", x, y)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package dots
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+var (
+	// ErrUnsupportedFormat reports an image in a format with no registered decoder.
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	// ErrTerminalTooSmall reports a terminal with no room for the output. Errors
+	// of type *TerminalSizeError match it with errors.Is.
+	ErrTerminalTooSmall = errors.New("terminal too small")
+)
+
+// DecodeError reports an image in a recognized format that couldn't be decoded,
+// e.g. because it's truncated or corrupt.
+type DecodeError struct {
+	Format string // The detected format, e.g. "png"
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode %s: %v", e.Format, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// TerminalSizeError reports a terminal smaller than the output needs.
+type TerminalSizeError struct {
+	Width, Height       int // The terminal's size in characters
+	MinWidth, MinHeight int // The smallest size that fits
+}
+
+func (e *TerminalSizeError) Error() string {
+	return fmt.Sprintf("terminal is %dx%d, needs at least %dx%d", e.Width, e.Height, e.MinWidth, e.MinHeight)
+}
+
+// Is makes a TerminalSizeError match ErrTerminalTooSmall.
+func (e *TerminalSizeError) Is(target error) bool { return target == ErrTerminalTooSmall }
+
+// Decode decodes an image in any registered format, like image.Decode, but
+// reports an unrecognized format as ErrUnsupportedFormat and other failures
+// as a *DecodeError, so callers can tell them apart.
+func Decode(r io.Reader) (image.Image, string, error) {
+	img, format, err := image.Decode(r)
+	if errors.Is(err, image.ErrFormat) {
+		return nil, "", ErrUnsupportedFormat
+	} else if err != nil {
+		return nil, format, &DecodeError{Format: format, Err: err}
+	}
+	return img, format, nil
+}
+
+// CheckTerminalSize returns a *TerminalSizeError if stdout is a terminal
+// smaller than width×height characters. Terminals whose size can't be read,
+// and output that isn't to a terminal, are assumed to fit.
+func CheckTerminalSize(width, height int) error {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return nil
+	}
+	w, h, err := term.GetSize(fd)
+	if err != nil {
+		return nil
+	}
+	if w < width || h < height {
+		return &TerminalSizeError{Width: w, Height: h, MinWidth: width, MinHeight: height}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package dots
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestDecodeErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, format, err := Decode(bytes.NewReader(buf.Bytes())); err != nil || format != "png" {
+		t.Errorf("Decode(png) = %q, %v, want png, nil", format, err)
+	}
+
+	if _, _, err := Decode(strings.NewReader("not an image")); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Decode(text) error = %v, want ErrUnsupportedFormat", err)
+	}
+
+	var decodeErr *DecodeError
+	_, _, err := Decode(bytes.NewReader(buf.Bytes()[:buf.Len()/2]))
+	if !errors.As(err, &decodeErr) || decodeErr.Format != "png" {
+		t.Errorf("Decode(truncated png) error = %v, want a *DecodeError for png", err)
+	}
+
+	if _, err := DecodeGIF(strings.NewReader("GIF89a")); !errors.As(err, &decodeErr) || decodeErr.Format != "gif" {
+		t.Errorf("DecodeGIF(truncated) error = %v, want a *DecodeError for gif", err)
+	}
+}
+
+func TestTerminalSizeError(t *testing.T) {
+	err := fmt.Errorf("playing: %w", &TerminalSizeError{Width: 2, Height: 1, MinWidth: 3, MinHeight: 3})
+	if !errors.Is(err, ErrTerminalTooSmall) {
+		t.Errorf("errors.Is(%v, ErrTerminalTooSmall) = false, want true", err)
+	}
+	if errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("errors.Is(%v, ErrUnsupportedFormat) = true, want false", err)
+	}
+	if want := "playing: terminal is 2x1, needs at least 3x3"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,121 @@
+package dots
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// WriteHTML writes the grid as a standalone HTML document.
+// Each cell is emitted as a span styled with its foreground and background colors,
+// unless opts.NoColor is set. A frame is drawn around the picture if opts.Frame is set.
+func (g Grid) WriteHTML(w io.Writer, opts Options) error {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n</head>\n")
+	sb.WriteString("<body style=\"background:#000;color:#fff\">\n<pre style=\"font-family:monospace;line-height:1\">\n")
+
+	width := 0
+	if len(g) > 0 {
+		width = len(g[0])
+	}
+	top, side, bottom := frameBorders(width, opts)
+	if opts.Frame {
+		sb.WriteString(html.EscapeString(top) + "\n")
+	}
+	for _, cells := range g {
+		if opts.Frame {
+			sb.WriteString(side)
+		}
+		for _, cell := range cells {
+			char := html.EscapeString(string(cell.Rune))
+			if opts.NoColor || (cell.Fg.A == 0 && cell.Bg.A == 0) {
+				sb.WriteString(char)
+				continue
+			}
+			style := "color:" + cssColor(cell.Fg)
+			if cell.Bg.A != 0 {
+				style += ";background:" + cssColor(cell.Bg)
+			}
+			fmt.Fprintf(&sb, "<span style=\"%s\">%s</span>", style, char)
+		}
+		if opts.Frame {
+			sb.WriteString(side)
+		}
+		sb.WriteString("\n")
+	}
+	if opts.Frame {
+		sb.WriteString(bottom + "\n")
+	}
+
+	sb.WriteString("</pre>\n</body>\n</html>\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// cssColor formats a color as a CSS hex color.
+func cssColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// Image rasterizes the grid, drawing each lit dot as a dotSize×dotSize square
+// in the cell's foreground color over the cell's background color (black if none).
+// Each cell covers 2×4 dots, with a one-dot gap between dots. Uncolored cells stay transparent.
+func (g Grid) Image(dotSize int) *image.RGBA {
+	return g.image(dotSize, 4)
+}
+
+// ImageSixDot is like Image for a grid made with Options.SixDot, whose cells cover
+// 2×3 dots.
+func (g Grid) ImageSixDot(dotSize int) *image.RGBA {
+	return g.image(dotSize, 3)
+}
+
+// image rasterizes the grid with rows dots down each cell.
+func (g Grid) image(dotSize, rows int) *image.RGBA {
+	if dotSize < 1 {
+		dotSize = 1
+	}
+	// Each dot occupies a 2×2 slot: the dot itself plus spacing to its right and below
+	slot := dotSize * 2
+	width := 0
+	if len(g) > 0 {
+		width = len(g[0])
+	}
+	img := image.NewRGBA(image.Rect(0, 0, width*2*slot, len(g)*rows*slot))
+
+	for row, cells := range g {
+		for col, cell := range cells {
+			if cell.Fg.A == 0 && cell.Bg.A == 0 {
+				continue
+			}
+			x0, y0 := col*2*slot, row*rows*slot
+			bg := color.RGBA{0, 0, 0, 255}
+			if cell.Bg.A != 0 {
+				bg = cell.Bg
+			}
+			fillRect(img, image.Rect(x0, y0, x0+2*slot, y0+rows*slot), bg)
+
+			for i, pos := range dotOffsets {
+				if cell.Pattern&(1<<i) == 0 {
+					continue
+				}
+				dx, dy := x0+pos[0]*slot+dotSize/2, y0+pos[1]*slot+dotSize/2
+				fillRect(img, image.Rect(dx, dy, dx+dotSize, dy+dotSize), cell.Fg)
+			}
+		}
+	}
+	return img
+}
+
+// fillRect fills a rectangle of img with a solid color.
+func fillRect(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	r = r.Intersect(img.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
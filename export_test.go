@@ -0,0 +1,115 @@
+package dots
+
+import (
+	"image/color"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWriteHTML(t *testing.T) {
+	grid := Grid{{
+		{Rune: '⣿', Pattern: 0xff, Fg: color.RGBA{255, 0, 0, 255}},
+		{Rune: '⠀', Pattern: 0, Fg: color.RGBA{0, 0, 0, 255}, Bg: color.RGBA{0, 0, 255, 255}},
+	}}
+
+	for _, tt := range []struct {
+		desc    string
+		opts    Options
+		want    []string
+		notWant []string
+	}{
+		{
+			desc: "colored",
+			opts: Options{},
+			want: []string{`<span style="color:#ff0000">⣿</span>`, `background:#0000ff`},
+		},
+		{
+			desc:    "no color",
+			opts:    Options{NoColor: true},
+			want:    []string{"⣿⠀"},
+			notWant: []string{"<span"},
+		},
+		{
+			desc: "frame",
+			opts: Options{Frame: true, NoColor: true},
+			want: []string{"┌──┐", "│⣿⠀│", "└──┘"},
+		},
+		{
+			desc: "frame style",
+			opts: Options{Frame: true, FrameStyle: FrameRounded, NoColor: true},
+			want: []string{"╭──╮", "│⣿⠀│", "╰──╯"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			var sb strings.Builder
+			if err := grid.WriteHTML(&sb, tt.opts); err != nil {
+				t.Fatalf("WriteHTML() error: %v", err)
+			}
+			got := sb.String()
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("output should contain %q, got:\n%s", w, got)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(got, w) {
+					t.Errorf("output should not contain %q, got:\n%s", w, got)
+				}
+			}
+		})
+	}
+
+	t.Run("frame title", func(t *testing.T) {
+		wide := Grid{slices.Repeat(grid[0], 4)}
+		var sb strings.Builder
+		if err := wide.WriteHTML(&sb, Options{Frame: true, FrameTitle: "<b>", NoColor: true}); err != nil {
+			t.Fatalf("WriteHTML() error: %v", err)
+		}
+		if want := "┌─ &lt;b&gt; ──┐"; !strings.Contains(sb.String(), want) {
+			t.Errorf("output should contain %q, got:\n%s", want, sb.String())
+		}
+	})
+}
+
+func TestGridImage(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	blue := color.RGBA{0, 0, 255, 255}
+	// Only dot 1 (top-left) is lit
+	grid := Grid{{{Rune: '⠁', Pattern: 0x01, Fg: red, Bg: blue}}}
+
+	img := grid.Image(1)
+	if got := img.Bounds().Dx(); got != 4 {
+		t.Errorf("image width = %d, want 4", got)
+	}
+	if got := img.Bounds().Dy(); got != 8 {
+		t.Errorf("image height = %d, want 8", got)
+	}
+	if got := img.RGBAAt(0, 0); got != red {
+		t.Errorf("lit dot color = %v, want %v", got, red)
+	}
+	if got := img.RGBAAt(2, 0); got != blue {
+		t.Errorf("unlit dot color = %v, want %v", got, blue)
+	}
+
+	t.Run("six-dot", func(t *testing.T) {
+		// Dots 3 and 6 are the bottom row of a six-dot cell
+		bottom := Grid{
+			{{Rune: '⠤', Pattern: 0x24, Fg: red, Bg: blue}},
+			{{Rune: '⠤', Pattern: 0x24, Fg: red, Bg: blue}},
+		}
+		img := bottom.ImageSixDot(1)
+		if got := img.Bounds().Dy(); got != 12 {
+			t.Errorf("image height = %d, want 12", got)
+		}
+		// The first cell's bottom row is directly above the second cell, with no gap
+		for _, y := range []int{4, 10} {
+			if got := img.RGBAAt(0, y); got != red {
+				t.Errorf("dot at y=%d color = %v, want %v", y, got, red)
+			}
+		}
+		if got := img.RGBAAt(0, 6); got != blue {
+			t.Errorf("top of the second cell color = %v, want %v", got, blue)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// applyFilters runs the enabled pre-processing filters on the resized image, in place.
+func applyFilters(img *image.RGBA, opts Options) {
+	if opts.Blur > 0 {
+		*img = *blurRGBA(img, opts.Blur)
+	}
+	if opts.Sharpen > 0 {
+		sharpen(img, opts.Sharpen)
+	}
+	if opts.AutoLevels {
+		autoLevels(img, opts.luma(), 0.01)
+	}
+	if opts.Brightness != 0 || opts.Contrast != 0 {
+		adjustBrightnessContrast(img, opts.Brightness, opts.Contrast)
+	}
+	if opts.Posterize >= 2 {
+		posterize(img, opts.Posterize)
+	}
+}
+
+// posterize rounds each channel to the nearest of levels evenly spaced values from 0 to 255.
+func posterize(img *image.RGBA, levels int) {
+	steps := float64(levels - 1)
+	for i := 0; i < len(img.Pix); i += 4 {
+		for c := 0; c < 3; c++ {
+			step := math.Round(float64(img.Pix[i+c]) * steps / 255)
+			img.Pix[i+c] = uint8(math.Round(step * 255 / steps))
+		}
+	}
+}
+
+// autoLevels stretches the image's luminance to the full range. The darkest and
+// brightest clip fractions of pixels are clipped, so a few outliers don't prevent
+// the stretch.
+func autoLevels(img *image.RGBA, luma Luma, clip float64) {
+	hist := luminanceHistogram(img, luma)
+	n := len(img.Pix) / 4
+	lo, hi := percentile(hist, n, clip), percentile(hist, n, 1-clip)
+	if hi <= lo {
+		return // Flat image; there is nothing to stretch
+	}
+	scale := 255 / float64(hi-lo)
+	for i := 0; i < len(img.Pix); i += 4 {
+		for c := 0; c < 3; c++ {
+			v := (float64(img.Pix[i+c]) - float64(lo)) * scale
+			img.Pix[i+c] = uint8(max(0, min(v, 255)) + 0.5)
+		}
+	}
+}
+
+// luminancePercentile returns the luminance below which fraction p of the image's pixels fall.
+func luminancePercentile(img *image.RGBA, luma Luma, p float64) uint8 {
+	return percentile(luminanceHistogram(img, luma), len(img.Pix)/4, p)
+}
+
+// luminanceHistogram counts the pixels of an image at each luminance.
+func luminanceHistogram(img *image.RGBA, luma Luma) [256]int {
+	var hist [256]int
+	for i := 0; i < len(img.Pix); i += 4 {
+		hist[luma.Y(color.RGBA{img.Pix[i], img.Pix[i+1], img.Pix[i+2], 255})]++
+	}
+	return hist
+}
+
+// percentile returns the smallest value such that at least fraction p of the n
+// values counted in hist are at or below it.
+func percentile(hist [256]int, n int, p float64) uint8 {
+	target := int(math.Ceil(p * float64(n)))
+	count := 0
+	for v, c := range hist {
+		count += c
+		if count >= target && count > 0 {
+			return uint8(v)
+		}
+	}
+	return 255
+}
+
+// adjustBrightnessContrast scales each channel's distance from mid-gray by 1+contrast,
+// then adds brightness×255. Both are in [-1, 1]; 0 leaves the image unchanged.
+func adjustBrightnessContrast(img *image.RGBA, brightness, contrast float64) {
+	factor := 1 + contrast
+	offset := brightness * 255
+	for i := 0; i < len(img.Pix); i += 4 {
+		// Channels are alpha-premultiplied, so they can't exceed alpha
+		a := float64(img.Pix[i+3])
+		for c := 0; c < 3; c++ {
+			v := (float64(img.Pix[i+c])-128)*factor + 128 + offset
+			img.Pix[i+c] = uint8(max(0, min(v, a)) + 0.5)
+		}
+	}
+}
+
+// sharpen applies an unsharp mask: each channel is pushed away from its blurred
+// value by amount, exaggerating edges and fine detail.
+func sharpen(img *image.RGBA, amount float64) {
+	blurred := blurRGBA(img, 1.0)
+	for i := 0; i < len(img.Pix); i += 4 {
+		a := float64(img.Pix[i+3])
+		for c := 0; c < 3; c++ {
+			v := float64(img.Pix[i+c])
+			v += (v - float64(blurred.Pix[i+c])) * amount
+			img.Pix[i+c] = uint8(max(0, min(v, a)) + 0.5)
+		}
+	}
+}
+
+// blurRGBA returns a copy of img blurred with a Gaussian of the given sigma.
+func blurRGBA(img *image.RGBA, sigma float64) *image.RGBA {
+	kernel := gaussianKernel(sigma)
+	radius := len(kernel) / 2
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	// The blur is separable, so it is applied horizontally and then vertically
+	pass := func(src *image.RGBA, dx, dy int) *image.RGBA {
+		dst := image.NewRGBA(image.Rect(0, 0, w, h))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				var sum [4]float64
+				for i, k := range kernel {
+					sx := max(0, min(x+(i-radius)*dx, w-1))
+					sy := max(0, min(y+(i-radius)*dy, h-1))
+					off := sy*src.Stride + sx*4
+					for c := range sum {
+						sum[c] += float64(src.Pix[off+c]) * k
+					}
+				}
+				off := y*dst.Stride + x*4
+				for c := range sum {
+					dst.Pix[off+c] = uint8(min(sum[c], 255) + 0.5)
+				}
+			}
+		}
+		return dst
+	}
+	return pass(pass(img, 1, 0), 0, 1)
+}
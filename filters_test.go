@@ -0,0 +1,114 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestAdjustBrightnessContrast(t *testing.T) {
+	for _, tt := range []struct {
+		desc                 string
+		in                   uint8
+		brightness, contrast float64
+		want                 uint8
+	}{
+		{desc: "unchanged", in: 100, want: 100},
+		{desc: "brighten", in: 100, brightness: 0.2, want: 151},
+		{desc: "darken clamps at black", in: 20, brightness: -0.5, want: 0},
+		{desc: "more contrast pushes away from mid-gray", in: 192, contrast: 0.5, want: 224},
+		{desc: "less contrast pulls toward mid-gray", in: 64, contrast: -0.5, want: 96},
+		{desc: "contrast clamps at white", in: 250, contrast: 1, want: 255},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+			img.Set(0, 0, color.RGBA{tt.in, tt.in, tt.in, 255})
+			adjustBrightnessContrast(img, tt.brightness, tt.contrast)
+			if got := img.RGBAAt(0, 0).R; got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSharpen(t *testing.T) {
+	// A soft step from dark to light gray gets steeper: the dark side darker, the light side lighter
+	img := image.NewRGBA(image.Rect(0, 0, 6, 1))
+	for x, v := range []uint8{80, 80, 80, 160, 160, 160} {
+		img.Set(x, 0, color.RGBA{v, v, v, 255})
+	}
+	sharpen(img, 1.0)
+
+	if got := img.RGBAAt(2, 0).R; got >= 80 {
+		t.Errorf("dark side of edge = %d, want < 80", got)
+	}
+	if got := img.RGBAAt(3, 0).R; got <= 160 {
+		t.Errorf("light side of edge = %d, want > 160", got)
+	}
+	if got := img.RGBAAt(0, 0).R; got != 80 {
+		t.Errorf("flat region = %d, want unchanged 80", got)
+	}
+}
+
+func TestBlurRGBA(t *testing.T) {
+	// A single bright speck is spread out and dimmed
+	img := image.NewRGBA(image.Rect(0, 0, 5, 5))
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 5; x++ {
+			img.Set(x, y, color.Black)
+		}
+	}
+	img.Set(2, 2, color.White)
+
+	blurred := blurRGBA(img, 0.8)
+	if got := blurred.RGBAAt(2, 2).R; got >= 255 || got == 0 {
+		t.Errorf("speck = %d, want dimmed", got)
+	}
+	if got := blurred.RGBAAt(1, 2).R; got == 0 {
+		t.Error("neighbor of speck should be lit by the blur")
+	}
+	if got := blurred.RGBAAt(2, 2).A; got != 255 {
+		t.Errorf("alpha = %d, want 255", got)
+	}
+	if blurred.RGBAAt(2, 2).R <= blurred.RGBAAt(1, 2).R {
+		t.Error("speck should remain the brightest pixel")
+	}
+}
+
+func TestPosterize(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 5, 1))
+	for x, v := range []uint8{0, 60, 100, 200, 255} {
+		img.SetRGBA(x, 0, color.RGBA{v, v, v, 255})
+	}
+
+	posterize(img, 3)
+
+	// Three levels: 0, 128, and 255
+	for x, want := range []uint8{0, 0, 128, 255, 255} {
+		if got := img.RGBAAt(x, 0).R; got != want {
+			t.Errorf("pixel %d = %d, want %d", x, got, want)
+		}
+	}
+}
+
+func TestAutoLevels(t *testing.T) {
+	// A dim, low-contrast gradient from 100 to 140, plus one bright outlier
+	img := image.NewRGBA(image.Rect(0, 0, 101, 1))
+	for x := 0; x < 100; x++ {
+		v := uint8(100 + x*40/99)
+		img.SetRGBA(x, 0, color.RGBA{v, v, v, 255})
+	}
+	img.SetRGBA(100, 0, color.RGBA{255, 255, 255, 255})
+
+	autoLevels(img, LumaRec601, 0.01)
+
+	if got := img.RGBAAt(0, 0).R; got != 0 {
+		t.Errorf("darkest pixel = %d, want 0", got)
+	}
+	if got := img.RGBAAt(99, 0).R; got != 255 {
+		t.Errorf("brightest gradient pixel = %d, want 255 despite the outlier", got)
+	}
+	if got := img.RGBAAt(50, 0).R; got < 120 || got > 135 {
+		t.Errorf("middle pixel = %d, want about 128", got)
+	}
+}
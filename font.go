@@ -0,0 +1,162 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"unicode"
+)
+
+// Glyphs of the font are 3×5 dots, spaced one dot apart horizontally and lines of
+// text one dot apart vertically.
+const (
+	glyphWidth, glyphHeight = 3, 5
+	glyphAdvance            = glyphWidth + 1
+	lineAdvance             = glyphHeight + 1
+)
+
+// font is a 3×5 bitmap font covering printable ASCII, indexed by rune-' '. Each
+// glyph is 5 rows from the top, with the leftmost dot in the high bit. Lowercase
+// letters are drawn as uppercase.
+var font = [...][glyphHeight]uint8{
+	' ' - ' ':  {0b000, 0b000, 0b000, 0b000, 0b000},
+	'!' - ' ':  {0b010, 0b010, 0b010, 0b000, 0b010},
+	'"' - ' ':  {0b101, 0b101, 0b000, 0b000, 0b000},
+	'#' - ' ':  {0b101, 0b111, 0b101, 0b111, 0b101},
+	'$' - ' ':  {0b011, 0b110, 0b010, 0b011, 0b110},
+	'%' - ' ':  {0b101, 0b001, 0b010, 0b100, 0b101},
+	'&' - ' ':  {0b010, 0b101, 0b010, 0b101, 0b011},
+	'\'' - ' ': {0b010, 0b010, 0b000, 0b000, 0b000},
+	'(' - ' ':  {0b001, 0b010, 0b010, 0b010, 0b001},
+	')' - ' ':  {0b100, 0b010, 0b010, 0b010, 0b100},
+	'*' - ' ':  {0b000, 0b101, 0b010, 0b101, 0b000},
+	'+' - ' ':  {0b000, 0b010, 0b111, 0b010, 0b000},
+	',' - ' ':  {0b000, 0b000, 0b000, 0b010, 0b100},
+	'-' - ' ':  {0b000, 0b000, 0b111, 0b000, 0b000},
+	'.' - ' ':  {0b000, 0b000, 0b000, 0b000, 0b010},
+	'/' - ' ':  {0b001, 0b001, 0b010, 0b100, 0b100},
+	'0' - ' ':  {0b111, 0b101, 0b101, 0b101, 0b111},
+	'1' - ' ':  {0b010, 0b110, 0b010, 0b010, 0b111},
+	'2' - ' ':  {0b111, 0b001, 0b111, 0b100, 0b111},
+	'3' - ' ':  {0b111, 0b001, 0b111, 0b001, 0b111},
+	'4' - ' ':  {0b101, 0b101, 0b111, 0b001, 0b001},
+	'5' - ' ':  {0b111, 0b100, 0b111, 0b001, 0b111},
+	'6' - ' ':  {0b111, 0b100, 0b111, 0b101, 0b111},
+	'7' - ' ':  {0b111, 0b001, 0b001, 0b010, 0b010},
+	'8' - ' ':  {0b111, 0b101, 0b111, 0b101, 0b111},
+	'9' - ' ':  {0b111, 0b101, 0b111, 0b001, 0b111},
+	':' - ' ':  {0b000, 0b010, 0b000, 0b010, 0b000},
+	';' - ' ':  {0b000, 0b010, 0b000, 0b010, 0b100},
+	'<' - ' ':  {0b001, 0b010, 0b100, 0b010, 0b001},
+	'=' - ' ':  {0b000, 0b111, 0b000, 0b111, 0b000},
+	'>' - ' ':  {0b100, 0b010, 0b001, 0b010, 0b100},
+	'?' - ' ':  {0b111, 0b001, 0b011, 0b000, 0b010},
+	'@' - ' ':  {0b010, 0b101, 0b111, 0b100, 0b011},
+	'A' - ' ':  {0b010, 0b101, 0b111, 0b101, 0b101},
+	'B' - ' ':  {0b110, 0b101, 0b110, 0b101, 0b110},
+	'C' - ' ':  {0b011, 0b100, 0b100, 0b100, 0b011},
+	'D' - ' ':  {0b110, 0b101, 0b101, 0b101, 0b110},
+	'E' - ' ':  {0b111, 0b100, 0b110, 0b100, 0b111},
+	'F' - ' ':  {0b111, 0b100, 0b110, 0b100, 0b100},
+	'G' - ' ':  {0b011, 0b100, 0b101, 0b101, 0b011},
+	'H' - ' ':  {0b101, 0b101, 0b111, 0b101, 0b101},
+	'I' - ' ':  {0b111, 0b010, 0b010, 0b010, 0b111},
+	'J' - ' ':  {0b001, 0b001, 0b001, 0b101, 0b010},
+	'K' - ' ':  {0b101, 0b101, 0b110, 0b101, 0b101},
+	'L' - ' ':  {0b100, 0b100, 0b100, 0b100, 0b111},
+	'M' - ' ':  {0b101, 0b111, 0b111, 0b101, 0b101},
+	'N' - ' ':  {0b110, 0b101, 0b101, 0b101, 0b101},
+	'O' - ' ':  {0b010, 0b101, 0b101, 0b101, 0b010},
+	'P' - ' ':  {0b110, 0b101, 0b110, 0b100, 0b100},
+	'Q' - ' ':  {0b010, 0b101, 0b101, 0b110, 0b011},
+	'R' - ' ':  {0b110, 0b101, 0b110, 0b101, 0b101},
+	'S' - ' ':  {0b011, 0b100, 0b010, 0b001, 0b110},
+	'T' - ' ':  {0b111, 0b010, 0b010, 0b010, 0b010},
+	'U' - ' ':  {0b101, 0b101, 0b101, 0b101, 0b111},
+	'V' - ' ':  {0b101, 0b101, 0b101, 0b101, 0b010},
+	'W' - ' ':  {0b101, 0b101, 0b111, 0b111, 0b101},
+	'X' - ' ':  {0b101, 0b101, 0b010, 0b101, 0b101},
+	'Y' - ' ':  {0b101, 0b101, 0b010, 0b010, 0b010},
+	'Z' - ' ':  {0b111, 0b001, 0b010, 0b100, 0b111},
+	'[' - ' ':  {0b110, 0b100, 0b100, 0b100, 0b110},
+	'\\' - ' ': {0b100, 0b100, 0b010, 0b001, 0b001},
+	']' - ' ':  {0b011, 0b001, 0b001, 0b001, 0b011},
+	'^' - ' ':  {0b010, 0b101, 0b000, 0b000, 0b000},
+	'_' - ' ':  {0b000, 0b000, 0b000, 0b000, 0b111},
+	'`' - ' ':  {0b100, 0b010, 0b000, 0b000, 0b000},
+	'{' - ' ':  {0b011, 0b010, 0b110, 0b010, 0b011},
+	'|' - ' ':  {0b010, 0b010, 0b010, 0b010, 0b010},
+	'}' - ' ':  {0b110, 0b010, 0b011, 0b010, 0b110},
+	'~' - ' ':  {0b000, 0b011, 0b110, 0b000, 0b000},
+}
+
+// glyph returns the bitmap of r in the font, or of '?' if the font lacks it.
+func glyph(r rune) [glyphHeight]uint8 {
+	r = unicode.ToUpper(r)
+	if r < ' ' || int(r-' ') >= len(font) {
+		r = '?'
+	}
+	return font[r-' ']
+}
+
+// Text draws s in a 3×5 dot font with its top left corner at (x, y), for titles and
+// labels. Each character takes 4 dots across and each line 6 dots down; characters
+// outside printable ASCII are drawn as '?'.
+func (c *Canvas) Text(x, y int, s string) {
+	for i, line := range strings.Split(s, "\n") {
+		gx := x
+		for _, r := range line {
+			rows := glyph(r)
+			for dy, bits := range rows {
+				for dx := range glyphWidth {
+					if bits&(1<<(glyphWidth-1-dx)) != 0 {
+						c.Set(gx+dx, y+i*lineAdvance+dy)
+					}
+				}
+			}
+			gx += glyphAdvance
+		}
+	}
+}
+
+// textSize returns the extent in dots of s drawn by Text, without trailing spacing.
+func textSize(s string) image.Point {
+	lines := strings.Split(s, "\n")
+	width := 0
+	for _, line := range lines {
+		width = max(width, len([]rune(line))*glyphAdvance-1)
+	}
+	return image.Pt(width, len(lines)*lineAdvance-1)
+}
+
+// captionLayout draws caption centered along the bottom of a width×height dot
+// matrix, returning the canvas with its text and the box behind it, which is a dot
+// wider than the text on each side so the text stands out from the image.
+func captionLayout(width, height int, caption string) (*Canvas, image.Rectangle) {
+	size := textSize(caption)
+	origin := image.Pt((width-size.X)/2, height-1-size.Y)
+	text := NewCanvas(width, height)
+	text.Text(origin.X, origin.Y, caption)
+	box := image.Rectangle{origin, origin.Add(size)}.Inset(-1).Intersect(text.Bounds())
+	return text, box
+}
+
+// paintCaption paints the caption's text white on a black box into the resized
+// image, so the cells of the caption are colored to match its dots, and makes the
+// box opaque in the image's alpha mask, if it has one.
+func paintCaption(img *image.RGBA, mask *image.Alpha, caption string) {
+	b := img.Bounds()
+	text, box := captionLayout(b.Dx(), b.Dy(), caption)
+	for y := box.Min.Y; y < box.Max.Y; y++ {
+		for x := box.Min.X; x < box.Max.X; x++ {
+			c := color.RGBA{A: 255}
+			if text.Get(x, y) {
+				c = color.RGBA{255, 255, 255, 255}
+			}
+			img.SetRGBA(b.Min.X+x, b.Min.Y+y, c)
+			if mask != nil {
+				mask.SetAlpha(b.Min.X+x, b.Min.Y+y, color.Alpha{255})
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	c := NewCanvas(8, 12)
+	c.Text(0, 0, "Hi\n-")
+	want := []image.Point{
+		{0, 0}, {2, 0}, {4, 0}, {5, 0}, {6, 0}, // H, I
+		{0, 1}, {2, 1}, {5, 1},
+		{0, 2}, {1, 2}, {2, 2}, {5, 2},
+		{0, 3}, {2, 3}, {5, 3},
+		{0, 4}, {2, 4}, {4, 4}, {5, 4}, {6, 4},
+		{0, 8}, {1, 8}, {2, 8}, // -, on the second line
+	}
+	if got := litDots(c); !slices.Equal(got, want) {
+		t.Errorf("Text() lit %v, want %v", got, want)
+	}
+
+	if glyph('a') != glyph('A') {
+		t.Error("glyph('a') isn't drawn as uppercase")
+	}
+	if glyph('é') != glyph('?') || glyph('\t') != glyph('?') {
+		t.Error("glyph() of a rune outside the font isn't '?'")
+	}
+	if got, want := textSize("Hi\n-"), image.Pt(7, 11); got != want {
+		t.Errorf("textSize() = %v, want %v", got, want)
+	}
+}
+
+func TestCaption(t *testing.T) {
+	// A bright red image, so the caption's box shows as unlit dots around the text
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+3] = 255, 255
+	}
+	opts := Options{Width: 10, Height: 5, Caption: "OK"}
+	dots := ConvertToDots(img, opts)
+
+	// "OK" is 7×5 dots, centered on the bottom of the 20×20 dots with a 1-dot margin
+	want := NewCanvas(20, 20)
+	want.FillRect(0, 0, 19, 19)
+	for y := 13; y < 20; y++ {
+		for x := 5; x < 14; x++ {
+			want.Unset(x, y)
+		}
+	}
+	want.Text(6, 14, "OK")
+	for y := range dots {
+		for x := range dots[y] {
+			if dots[y][x] != want.Get(x, y) {
+				t.Fatalf("dot (%d, %d) = %t, want %t", x, y, dots[y][x], want.Get(x, y))
+			}
+		}
+	}
+
+	// The text is painted white over the image's colors
+	opts.ColorLitDots = true
+	grid := ConvertGrid(img, opts)
+	if got, want := grid[4][3].Fg, (color.RGBA{255, 255, 255, 255}); got != want {
+		t.Errorf("caption cell color = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+package dots
+
+import "fmt"
+
+// FrameStyle selects the characters a frame is drawn with.
+type FrameStyle string
+
+// Supported frame styles.
+const (
+	FrameSingle  FrameStyle = "" // ┌─┐ light box-drawing lines
+	FrameDouble  FrameStyle = "double"
+	FrameRounded FrameStyle = "rounded"
+	FrameASCII   FrameStyle = "ascii" // +-+ for terminals and fonts without box drawing
+)
+
+// FrameStyles lists the names of all supported frame styles.
+var FrameStyles = []FrameStyle{FrameDouble, FrameRounded, FrameASCII}
+
+// ParseFrameStyle validates a frame style name. The empty string and "single"
+// select single lines.
+func ParseFrameStyle(s string) (FrameStyle, error) {
+	if s == "" || s == "single" {
+		return FrameSingle, nil
+	}
+	for _, style := range FrameStyles {
+		if FrameStyle(s) == style {
+			return style, nil
+		}
+	}
+	return FrameSingle, fmt.Errorf("unknown frame style %q", s)
+}
+
+// frameChars holds the characters of a frame style: the top left, top right,
+// bottom left, and bottom right corners, and the horizontal and vertical sides.
+type frameChars struct {
+	topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical string
+}
+
+var frameStyleChars = map[FrameStyle]frameChars{
+	FrameSingle:  {"┌", "┐", "└", "┘", "─", "│"},
+	FrameDouble:  {"╔", "╗", "╚", "╝", "═", "║"},
+	FrameRounded: {"╭", "╮", "╰", "╯", "─", "│"},
+	FrameASCII:   {"+", "+", "+", "+", "-", "|"},
+}
+
+// frameBorders returns the uncolored top border, side, and bottom border of the
+// frame around content of the given width, with opts.FrameTitle in the top
+// border, shortened with an ellipsis if it doesn't fit.
+func frameBorders(width int, opts Options) (top, side, bottom string) {
+	chars, ok := frameStyleChars[opts.FrameStyle]
+	if !ok {
+		chars = frameStyleChars[FrameSingle]
+	}
+
+	line := repeatString(chars.horizontal, width)
+	if title := []rune(opts.FrameTitle); len(title) > 0 && width >= 4 {
+		// A line, then the title padded with spaces, then the rest of the line
+		if len(title) > width-3 {
+			title = append(title[:max(width-4, 0)], '…')
+		}
+		line = chars.horizontal + " " + string(title) + " " + repeatString(chars.horizontal, width-len(title)-3)
+	}
+	top = chars.topLeft + line + chars.topRight
+	bottom = chars.bottomLeft + repeatString(chars.horizontal, width) + chars.bottomRight
+	return top, chars.vertical, bottom
+}
+
+// addFrame wraps the braille lines with a frame in the color given by opts.
+func addFrame(lines []string, opts Options) []string {
+	if len(lines) == 0 {
+		return lines
+	}
+
+	// Calculate the width of the content, ignoring ANSI codes if present
+	top, left, right, bottom := frameParts(visibleWidth(lines[0]), opts)
+
+	// Build the frame
+	result := make([]string, len(lines)+2)
+	result[0] = top
+	for i, line := range lines {
+		result[i+1] = left + line + right
+	}
+	result[len(result)-1] = bottom
+
+	return result
+}
+
+// frameParts returns the top border, left and right side borders, and bottom border
+// of a frame in the color given by opts around content of the given width.
+func frameParts(width int, opts Options) (top, left, right, bottom string) {
+	colorCode := ansiFgColor(opts.frameColor())
+	reset := ""
+	if !opts.NoColor {
+		reset = ansiReset()
+	} else {
+		colorCode = ""
+	}
+
+	top, side, bottom := frameBorders(width, opts)
+	return colorCode + top + reset, colorCode + side + reset, colorCode + side + reset, colorCode + bottom + reset
+}
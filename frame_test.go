@@ -0,0 +1,79 @@
+package dots
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseFrameStyle(t *testing.T) {
+	for _, tt := range []struct {
+		s       string
+		want    FrameStyle
+		wantErr bool
+	}{
+		{s: "", want: FrameSingle},
+		{s: "single", want: FrameSingle},
+		{s: "double", want: FrameDouble},
+		{s: "rounded", want: FrameRounded},
+		{s: "ascii", want: FrameASCII},
+		{s: "dotted", wantErr: true},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseFrameStyle(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFrameStyle(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFrameStyle(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrameStyleAndTitle(t *testing.T) {
+	grid := Grid{{{Rune: '⣿'}, {Rune: '⣿'}, {Rune: '⣿'}, {Rune: '⣿'}, {Rune: '⣿'}, {Rune: '⣿'}, {Rune: '⣿'}, {Rune: '⣿'}}}
+	for _, tt := range []struct {
+		desc string
+		opts Options
+		want []string
+	}{
+		{
+			desc: "single",
+			opts: Options{},
+			want: []string{"┌────────┐", "│⣿⣿⣿⣿⣿⣿⣿⣿│", "└────────┘"},
+		},
+		{
+			desc: "double",
+			opts: Options{FrameStyle: FrameDouble},
+			want: []string{"╔════════╗", "║⣿⣿⣿⣿⣿⣿⣿⣿║", "╚════════╝"},
+		},
+		{
+			desc: "rounded",
+			opts: Options{FrameStyle: FrameRounded},
+			want: []string{"╭────────╮", "│⣿⣿⣿⣿⣿⣿⣿⣿│", "╰────────╯"},
+		},
+		{
+			desc: "ascii",
+			opts: Options{FrameStyle: FrameASCII},
+			want: []string{"+--------+", "|⣿⣿⣿⣿⣿⣿⣿⣿|", "+--------+"},
+		},
+		{
+			desc: "title",
+			opts: Options{FrameTitle: "a.png"},
+			want: []string{"┌─ a.png ┐", "│⣿⣿⣿⣿⣿⣿⣿⣿│", "└────────┘"},
+		},
+		{
+			desc: "long title shortened",
+			opts: Options{FrameTitle: "picture.png", FrameStyle: FrameASCII},
+			want: []string{"+- pict… +", "|⣿⣿⣿⣿⣿⣿⣿⣿|", "+--------+"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			tt.opts.Frame = true
+			tt.opts.NoColor = true
+			if got := grid.Render(tt.opts); !slices.Equal(got, tt.want) {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
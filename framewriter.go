@@ -0,0 +1,93 @@
+package dots
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CellUpdate is a cell that changed between two frames, at its row and column.
+type CellUpdate struct {
+	Row, Col int
+	Cell     Cell
+}
+
+// Diff returns the cells of the canvas that differ from prev, e.g. the previous
+// frame of an animation, in row order. If prev is nil or a different size, every
+// cell is returned.
+func (c *Canvas) Diff(prev *Canvas) []CellUpdate {
+	grid := c.Grid()
+	var old Grid
+	if prev != nil && prev.Bounds() == c.Bounds() {
+		old = prev.Grid()
+	}
+	var updates []CellUpdate
+	for row, cells := range grid {
+		for col, cell := range cells {
+			if old == nil || old[row][col] != cell {
+				updates = append(updates, CellUpdate{Row: row, Col: col, Cell: cell})
+			}
+		}
+	}
+	return updates
+}
+
+// FrameWriter draws successive frames over each other on a terminal, starting at
+// the cursor's line. When a frame is the same size as the one before, only the
+// cells that changed are written, with cursor movements between them, so
+// animations don't flicker or flood slow links. The cursor is left on the line
+// below the frame.
+type FrameWriter struct {
+	w      io.Writer
+	opts   Options
+	prev   Grid // The frame on screen
+	height int  // Lines drawn for prev
+}
+
+// NewFrameWriter returns a FrameWriter that renders frames to w with opts.
+func NewFrameWriter(w io.Writer, opts Options) *FrameWriter {
+	return &FrameWriter{w: w, opts: opts}
+}
+
+// WriteCanvas draws a canvas over the previous frame.
+func (f *FrameWriter) WriteCanvas(c *Canvas) error {
+	return f.WriteGrid(c.Grid())
+}
+
+// WriteGrid draws a grid over the previous frame.
+func (f *FrameWriter) WriteGrid(grid Grid) error {
+	_, err := io.WriteString(f.w, f.render(grid))
+	return err
+}
+
+// Reset forgets the previous frame, so the next is drawn in full at the cursor,
+// e.g. after clearing the screen when the terminal is resized.
+func (f *FrameWriter) Reset() {
+	f.prev, f.height = nil, 0
+}
+
+// render returns the output that draws grid over the previous frame.
+func (f *FrameWriter) render(grid Grid) string {
+	defer func() { f.prev = grid }()
+	if canDiff(f.prev, grid, f.opts) {
+		return grid.renderDiff(f.prev, f.height, f.opts)
+	}
+
+	var sb strings.Builder
+	if f.height > 0 {
+		// Move back up to the first line of the previous frame
+		fmt.Fprintf(&sb, "\x1b[%dF", f.height)
+	}
+	// Lines end in CRLF, since the terminal may be in raw mode to read keys
+	lines := grid.Render(f.opts)
+	for _, line := range lines {
+		sb.WriteString(line)
+		sb.WriteString("\r\n")
+	}
+	if len(lines) < f.height {
+		// Clear what's left of the previous frame
+		sb.WriteString("\x1b[J")
+	}
+	f.height = len(lines)
+	return sb.String()
+}
@@ -0,0 +1,86 @@
+package dots
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCanvasDiff(t *testing.T) {
+	prev := NewCanvas(4, 4)
+	prev.Set(0, 0)
+	next := NewCanvas(4, 4)
+	next.Set(0, 0)
+	next.Set(3, 3)
+
+	want := []CellUpdate{{Row: 0, Col: 1, Cell: Cell{Rune: '⢀', Pattern: 0x80}}}
+	if got := next.Diff(prev); !slices.Equal(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+	if got := next.Diff(next); len(got) != 0 {
+		t.Errorf("Diff() of itself = %v, want none", got)
+	}
+	if got := next.Diff(nil); len(got) != 2 {
+		t.Errorf("Diff(nil) = %v, want every cell", got)
+	}
+	if got := next.Diff(NewCanvas(2, 4)); len(got) != 2 {
+		t.Errorf("Diff() of a smaller canvas = %v, want every cell", got)
+	}
+}
+
+func TestFrameWriter(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFrameWriter(&buf, Options{NoColor: true})
+	c := NewCanvas(4, 4)
+	for _, tt := range []struct {
+		desc string
+		draw func()
+		want string
+	}{
+		{"first frame in full", func() {}, "⠀⠀\r\n"},
+		{"changed cell", func() { c.Set(3, 3) }, "\x1b[1F\x1b[2G⢀\x1b[1E"},
+		{"unchanged", func() {}, ""},
+		{"reset", f.Reset, "⠀⢀\r\n"},
+	} {
+		buf.Reset()
+		tt.draw()
+		if err := f.WriteCanvas(c); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("%s: WriteCanvas() wrote %q, want %q", tt.desc, got, tt.want)
+		}
+	}
+
+	// A frame of another size is redrawn in full over the previous one
+	buf.Reset()
+	if err := f.WriteCanvas(NewCanvas(2, 8)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "\x1b[1F⠀\r\n⠀\r\n"; got != want {
+		t.Errorf("WriteCanvas() of a new size wrote %q, want %q", got, want)
+	}
+}
+
+func TestFrameWriterAlign(t *testing.T) {
+	var buf bytes.Buffer
+	// Tests don't run on a terminal, so frames are aligned within 80 columns
+	f := NewFrameWriter(&buf, Options{NoColor: true, Align: AlignRight})
+	c := NewCanvas(4, 4)
+	if err := f.WriteCanvas(c); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), strings.Repeat(" ", 78)+"⠀⠀\r\n"; got != want {
+		t.Errorf("first frame = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	c.Set(3, 3)
+	if err := f.WriteCanvas(c); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "\x1b[1F\x1b[80G⢀\x1b[1E"; got != want {
+		t.Errorf("changed cell = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,128 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync"
+)
+
+var (
+	gammaOnce sync.Once
+	// srgbToLinear maps 8-bit sRGB channel values to 16-bit linear light
+	srgbToLinear [256]uint16
+	// linearToSRGB maps 16-bit linear light to 8-bit sRGB channel values
+	linearToSRGB [65536]uint8
+)
+
+// initGamma fills the sRGB transfer function lookup tables.
+func initGamma() {
+	gammaOnce.Do(func() {
+		for i := range srgbToLinear {
+			v := float64(i) / 255
+			if v <= 0.04045 {
+				v /= 12.92
+			} else {
+				v = math.Pow((v+0.055)/1.055, 2.4)
+			}
+			srgbToLinear[i] = uint16(v*65535 + 0.5)
+		}
+		for i := range linearToSRGB {
+			v := float64(i) / 65535
+			if v <= 0.0031308 {
+				v *= 12.92
+			} else {
+				v = 1.055*math.Pow(v, 1/2.4) - 0.055
+			}
+			linearToSRGB[i] = uint8(v*255 + 0.5)
+		}
+	})
+}
+
+// toLinear converts an image to alpha-premultiplied linear-light RGB. The common
+// image types are read directly; converting each pixel through color.Color takes
+// most of the time on large photos.
+func toLinear(img image.Image) *image.RGBA64 {
+	initGamma()
+	bounds := img.Bounds()
+	dst := image.NewRGBA64(bounds)
+	switch src := img.(type) {
+	case *image.NRGBA:
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				p := src.Pix[src.PixOffset(x, y):]
+				dst.SetRGBA64(x, y, linearize(color.NRGBA{p[0], p[1], p[2], p[3]}))
+			}
+		}
+	case *image.RGBA:
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				p := src.Pix[src.PixOffset(x, y):]
+				dst.SetRGBA64(x, y, linearize(unpremultiply(color.RGBA{p[0], p[1], p[2], p[3]})))
+			}
+		}
+	case *image.YCbCr:
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				yi, ci := src.YOffset(x, y), src.COffset(x, y)
+				r, g, b := color.YCbCrToRGB(src.Y[yi], src.Cb[ci], src.Cr[ci])
+				dst.SetRGBA64(x, y, linearize(color.NRGBA{r, g, b, 0xff}))
+			}
+		}
+	default:
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				dst.SetRGBA64(x, y, linearize(color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)))
+			}
+		}
+	}
+	return dst
+}
+
+// linearize converts a straight (non-premultiplied) sRGB color to premultiplied
+// linear light.
+func linearize(c color.NRGBA) color.RGBA64 {
+	a := uint32(c.A) * 0x101
+	return color.RGBA64{
+		R: uint16(uint32(srgbToLinear[c.R]) * a / 0xffff),
+		G: uint16(uint32(srgbToLinear[c.G]) * a / 0xffff),
+		B: uint16(uint32(srgbToLinear[c.B]) * a / 0xffff),
+		A: uint16(a),
+	}
+}
+
+// unpremultiply converts c to a straight color, as color.NRGBAModel does.
+func unpremultiply(c color.RGBA) color.NRGBA {
+	switch c.A {
+	case 0xff:
+		return color.NRGBA{c.R, c.G, c.B, 0xff}
+	case 0:
+		return color.NRGBA{}
+	}
+	a := uint32(c.A) * 0x101
+	straight := func(v uint8) uint8 { return uint8(uint32(v) * 0x101 * 0xffff / a >> 8) }
+	return color.NRGBA{straight(c.R), straight(c.G), straight(c.B), c.A}
+}
+
+// toSRGB converts an alpha-premultiplied linear-light image back to 8-bit sRGB.
+func toSRGB(img *image.RGBA64) *image.RGBA {
+	initGamma()
+	bounds := img.Bounds()
+	dst := image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.RGBA64At(x, y)
+			if c.A == 0 {
+				continue
+			}
+			// Unpremultiply, encode, and premultiply again
+			a := uint32(c.A)
+			encode := func(v uint16) uint8 {
+				straight := min(uint32(v)*0xffff/a, 0xffff)
+				return uint8(uint32(linearToSRGB[straight]) * a / 0xffff)
+			}
+			dst.SetRGBA(x, y, color.RGBA{encode(c.R), encode(c.G), encode(c.B), uint8(a >> 8)})
+		}
+	}
+	return dst
+}
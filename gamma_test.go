@@ -0,0 +1,67 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestResizeInLinearLight(t *testing.T) {
+	// A fine black and white checkerboard averages to 50% linear light,
+	// which is about 188 in sRGB, not the 128 of naive gamma-space averaging.
+	src := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			if (x+y)%2 == 0 {
+				src.Set(x, y, color.White)
+			} else {
+				src.Set(x, y, color.Black)
+			}
+		}
+	}
+
+	got := resize(src, 4, 4).RGBAAt(2, 2)
+	if got.R < 180 || got.R > 195 {
+		t.Errorf("resized checkerboard = %d, want about 188", got.R)
+	}
+}
+
+func TestGammaRoundTrip(t *testing.T) {
+	initGamma()
+	for i := 0; i < 256; i++ {
+		if got := linearToSRGB[srgbToLinear[i]]; got != uint8(i) {
+			t.Errorf("linearToSRGB[srgbToLinear[%d]] = %d", i, got)
+		}
+	}
+}
+
+func TestToLinearFastPaths(t *testing.T) {
+	// Reading the common image types directly gives the same result as reading
+	// them through color.Color
+	r := image.Rect(1, 2, 33, 19)
+	nrgba, rgba := image.NewNRGBA(r), image.NewRGBA(r)
+	ycbcr := image.NewYCbCr(r, image.YCbCrSubsampleRatio420)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c := color.NRGBA{uint8(x * 7), uint8(y * 13), uint8(x * y), uint8(x*y*5 + 3)}
+			nrgba.SetNRGBA(x, y, c)
+			rgba.Set(x, y, c)
+			ycbcr.Y[ycbcr.YOffset(x, y)] = uint8(x * y)
+			ycbcr.Cb[ycbcr.COffset(x, y)] = uint8(x * 11)
+			ycbcr.Cr[ycbcr.COffset(x, y)] = uint8(y * 17)
+		}
+	}
+	for _, img := range []image.Image{nrgba, rgba, ycbcr} {
+		got, want := toLinear(img), toLinear(struct{ image.Image }{img})
+		if got.Bounds() != want.Bounds() {
+			t.Fatalf("%T: bounds = %v, want %v", img, got.Bounds(), want.Bounds())
+		}
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				if g, w := got.RGBA64At(x, y), want.RGBA64At(x, y); g != w {
+					t.Fatalf("%T: pixel (%d, %d) = %v, want %v", img, x, y, g, w)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package dots
+
+import "math"
+
+// ProgressBar returns a line of width braille characters filled from the left to
+// fraction, from 0 to 1, over a rail along the bottom. It fills one column of
+// dots at a time, so it moves smoothly at twice the precision of a bar of
+// block characters.
+func ProgressBar(fraction float64, width int) string {
+	c := NewCanvas(width*2, 4)
+	c.Line(0, 3, width*2-1, 3)
+	if filled := int(math.Round(clampFraction(fraction) * float64(width*2))); filled > 0 {
+		c.FillRect(0, 0, filled-1, 3)
+	}
+	return c.String()
+}
+
+// Gauge returns a dial width×height characters with a needle pointing at
+// fraction, from 0 at the left to 1 at the right, around a half circle as large
+// as fits, e.g. for speeds and loads.
+func Gauge(fraction float64, width, height int) string {
+	c := NewCanvas(width*2, height*4)
+	r := min((width*2-1)/2, height*4-1)
+	cx, cy := (width*2-1)/2, height*4-1
+	c.Arc(cx, cy, r, r, 0, math.Pi)
+	angle := math.Pi * (1 - clampFraction(fraction))
+	c.Line(cx, cy, cx+int(math.Round(float64(r)*math.Cos(angle))), cy-int(math.Round(float64(r)*math.Sin(angle))))
+	return c.String()
+}
+
+// VUMeter returns bars height characters tall, one character wide each, filled
+// from the bottom to each of levels, from 0 to 1, like the meters of an audio
+// mixer's channels. Each character adds four steps of precision.
+func VUMeter(levels []float64, height int) string {
+	c := NewCanvas(len(levels)*2, height*4)
+	for i, level := range levels {
+		if filled := int(math.Round(clampFraction(level) * float64(height*4))); filled > 0 {
+			c.FillRect(i*2, height*4-filled, i*2+1, height*4-1)
+		}
+	}
+	return c.String()
+}
+
+// clampFraction clamps f to [0, 1], treating NaN as 0.
+func clampFraction(f float64) float64 {
+	if math.IsNaN(f) {
+		return 0
+	}
+	return min(max(f, 0), 1)
+}
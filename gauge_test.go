@@ -0,0 +1,55 @@
+package dots
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProgressBarWidget(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		fraction float64
+		width    int
+		want     string
+	}{
+		{"empty", 0, 4, "⣀⣀⣀⣀"},
+		{"half", 0.5, 4, "⣿⣿⣀⣀"},
+		{"half a character", 0.375, 4, "⣿⣇⣀⣀"},
+		{"full", 1, 2, "⣿⣿"},
+		{"clamped", 2, 2, "⣿⣿"},
+		{"NaN", math.NaN(), 2, "⣀⣀"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := ProgressBar(tt.fraction, tt.width); got != tt.want {
+				t.Errorf("ProgressBar() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGauge(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		fraction float64
+		want     string
+	}{
+		{"left", 0, "⠀⢀⣀⠀⠀\n⣜⣁⡀⠙⡄"},
+		{"middle", 0.5, "⠀⢀⣀⠀⠀\n⡜⠁⡇⠙⡄"},
+		{"right", 1, "⠀⢀⣀⠀⠀\n⡜⠁⣀⣙⡄"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := Gauge(tt.fraction, 5, 2); got != tt.want {
+				t.Errorf("Gauge() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVUMeter(t *testing.T) {
+	if got, want := VUMeter([]float64{0, 0.5, 1}, 1), "⠀⣤⣿"; got != want {
+		t.Errorf("VUMeter() = %q, want %q", got, want)
+	}
+	if got, want := VUMeter([]float64{0.25, 0.75}, 2), "⠀⣤\n⣤⣿"; got != want {
+		t.Errorf("VUMeter() two rows = %q, want %q", got, want)
+	}
+}
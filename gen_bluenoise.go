@@ -0,0 +1,125 @@
+//go:build ignore
+
+// gen_bluenoise generates bluenoise_table.go, a 64×64 blue-noise threshold
+// mask built with Ulichney's void-and-cluster method.
+//
+// Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"math"
+	"math/rand"
+	"os"
+)
+
+const (
+	size  = 64
+	n     = size * size
+	sigma = 1.5
+)
+
+func main() {
+	// Gaussian weight for every toroidal offset
+	var weights [n]float64
+	for dy := 0; dy < size; dy++ {
+		for dx := 0; dx < size; dx++ {
+			x, y := min(dx, size-dx), min(dy, size-dy)
+			weights[dy*size+dx] = math.Exp(-float64(x*x+y*y) / (2 * sigma * sigma))
+		}
+	}
+
+	var pattern [n]bool
+	var energy [n]float64
+	toggle := func(i int, on bool) {
+		pattern[i] = on
+		sign := 1.0
+		if !on {
+			sign = -1
+		}
+		ix, iy := i%size, i/size
+		for j := range energy {
+			dx, dy := (j%size-ix+size)%size, (j/size-iy+size)%size
+			energy[j] += sign * weights[dy*size+dx]
+		}
+	}
+	// tightestCluster finds the lit pixel with the most energy, largestVoid the unlit pixel with the least
+	tightestCluster := func() int {
+		best := -1
+		for i := range pattern {
+			if pattern[i] && (best == -1 || energy[i] > energy[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+	largestVoid := func() int {
+		best := -1
+		for i := range pattern {
+			if !pattern[i] && (best == -1 || energy[i] < energy[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+
+	// Initial pattern: random points, relaxed until evenly spread
+	r := rand.New(rand.NewSource(1))
+	ones := n / 10
+	for _, i := range r.Perm(n)[:ones] {
+		toggle(i, true)
+	}
+	for {
+		c := tightestCluster()
+		toggle(c, false)
+		v := largestVoid()
+		if v == c {
+			toggle(c, true)
+			break
+		}
+		toggle(v, true)
+	}
+	initial, initialEnergy := pattern, energy
+
+	var rank [n]int
+
+	// Phase 1: rank the initial points by repeatedly removing the tightest cluster
+	for k := ones - 1; k >= 0; k-- {
+		c := tightestCluster()
+		toggle(c, false)
+		rank[c] = k
+	}
+
+	// Phase 2: rank the remaining pixels by repeatedly filling the largest void
+	pattern, energy = initial, initialEnergy
+	for k := ones; k < n; k++ {
+		v := largestVoid()
+		toggle(v, true)
+		rank[v] = k
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by gen_bluenoise.go; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package dots")
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "// blueNoise is a %d×%d blue-noise threshold mask, indexed [y*%d+x], with values in [0, 255].\n", size, size, size)
+	fmt.Fprintf(&buf, "var blueNoise = [%d]uint8{\n", n)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			fmt.Fprintf(&buf, "%d, ", rank[y*size+x]*256/n)
+		}
+		fmt.Fprintln(&buf)
+	}
+	fmt.Fprintln(&buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile("bluenoise_table.go", src, 0o644); err != nil {
+		panic(err)
+	}
+}
@@ -3,8 +3,9 @@ module github.com/imjasonh/dots
 go 1.25.1
 
 require (
+	github.com/fsnotify/fsnotify v1.9.0 // for -watch
+	golang.org/x/crypto v0.45.0 // for the ssh server
 	golang.org/x/image v0.33.0 // for resizing
+	golang.org/x/sys v0.38.0 // for terminal cell pixel size
 	golang.org/x/term v0.37.0 // for getting terminal size
 )
-
-require golang.org/x/sys v0.38.0 // indirect
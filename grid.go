@@ -0,0 +1,274 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"strings"
+)
+
+// Cell is a single braille character and its colors.
+type Cell struct {
+	Rune    rune       // Braille character
+	Fg, Bg  color.RGBA // Foreground and background colors; a zero-alpha color means none
+	Pattern uint8      // Dot pattern, one bit per dot in braille dot order
+}
+
+// Grid is the intermediate representation between quantization and escape-code emission.
+// It is indexed as grid[row][col].
+type Grid [][]Cell
+
+// ConvertGrid converts an image to a grid of braille cells.
+// Colors are already quantized to the ANSI 256 palette, so rendering the grid
+// with Render produces the same output as Convert.
+func ConvertGrid(img image.Image, opts Options) Grid {
+	resized, mask, opts := prepare(img, opts)
+	dots := dotMatrix(resized, opts)
+	dither := newCellDither(opts)
+
+	grid := make(Grid, opts.Height)
+	for row := range grid {
+		grid[row] = convertRow(resized, mask, dots, dither, row, opts)
+	}
+	return grid
+}
+
+// prepare resolves the options for an image and resizes it to the output's pixel dimensions.
+// The resized image is composited over the matte; if opts.Transparent is set, its
+// original alpha channel is returned too.
+func prepare(img image.Image, opts Options) (*image.RGBA, *image.Alpha, Options) {
+	resized, mask, opts := resizeImage(img, opts)
+	return resized, mask, filterImage(resized, mask, opts)
+}
+
+// resizeImage is the first half of prepare: it resolves the options, resizes the
+// image, and composites it over the matte.
+func resizeImage(img image.Image, opts Options) (*image.RGBA, *image.Alpha, Options) {
+	opts = resolveOptions(img, opts)
+
+	// Step 1: Spatial quantization - resize to target dimensions
+	// Each braille char is 2 pixels wide × 4 pixels tall (2×3 for 6-dot braille)
+	cellWidth, cellHeight := opts.cellSize()
+	targetWidth := opts.Width * cellWidth
+	targetHeight := opts.Height * cellHeight
+	resized := resize(img, targetWidth, targetHeight)
+	var mask *image.Alpha
+	if opts.Transparent {
+		mask = alphaMask(resized)
+	}
+	compositeMatte(resized, opts.Matte)
+	return resized, mask, opts
+}
+
+// filterImage is the second half of prepare: it filters the resized image in place,
+// resolves the threshold percentile, paints the caption, and builds the palette.
+func filterImage(resized *image.RGBA, mask *image.Alpha, opts Options) Options {
+	applyFilters(resized, opts)
+
+	if opts.ThresholdPercentile > 0 && opts.Edges == EdgeNone {
+		opts.Threshold = luminancePercentile(resized, opts.luma(), opts.ThresholdPercentile/100)
+	}
+
+	if opts.Caption != "" {
+		paintCaption(resized, mask, opts.Caption)
+	}
+
+	opts.quantize = opts.quantizer()
+	switch {
+	case opts.Grayscale, opts.Tint != nil:
+		// The ramp or gradient is the palette
+	case len(opts.Palette) > 0:
+		opts.quantize = newPalette(opts.Palette, opts.quantize).nearest
+	case opts.PaletteMode == PaletteMedianCut:
+		opts.quantize = newPalette(medianCut(resized, opts.paletteSize()), opts.quantize).nearest
+	case opts.PaletteMode == PaletteKMeans:
+		opts.quantize = newPalette(kMeans(resized, opts.paletteSize()), opts.quantize).nearest
+	}
+	return opts
+}
+
+// convertRow converts one row of braille cells from the resized image and its dot matrix.
+// Cells that are fully transparent in mask, if it is non-nil, are left blank and uncolored.
+// If dither is non-nil, rows must be converted in order.
+func convertRow(resized *image.RGBA, mask *image.Alpha, dots [][]bool, dither *cellDither, row int, opts Options) []Cell {
+	// Step 3: Pack dots into characters and quantize colors
+	cellWidth, cellHeight := opts.cellSize()
+	quantize := opts.quantizer()
+	cells := make([]Cell, opts.Width)
+	for col := range cells {
+		// Extract 2×4 pixel block
+		x0, y0 := col*cellWidth, row*cellHeight
+		if mask != nil && transparentBlock(mask, x0, y0, cellWidth, cellHeight) {
+			cells[col] = Cell{Rune: 0x2800}
+			continue
+		}
+		block := extractBlock(resized, x0, y0)
+		pixels := block[:]
+
+		// Pack the block's dots into a braille character
+		lit := cellDots(dots, x0, y0)
+		char := packBraille(lit)
+		litDots := lit[:]
+		if opts.SixDot {
+			// Dots 7 and 8 belong to the next row of cells; drop them
+			char = 0x2800 + (char-0x2800)&0x3f
+			pixels, litDots = block[:6], lit[:6]
+		}
+
+		// Color quantization: get ANSI colors
+		cell := Cell{
+			Rune:    char,
+			Pattern: uint8(char - 0x2800),
+		}
+		fgPixels := pixels
+		if opts.ColorLitDots {
+			fgPixels = foregroundPixels(pixels, litDots)
+		}
+		bgPixels := backgroundPixels(pixels, litDots)
+		if dither != nil {
+			r, g, b := averageColor(fgPixels)
+			cell.Fg = opts.codeColor(dither.fg.quantize(col, row, r, g, b, quantize, opts.codeColor))
+		} else {
+			cell.Fg = opts.codeColor(opts.BlockColor.blockCode(fgPixels, quantize))
+		}
+		if opts.SampleBackground && dither != nil {
+			r, g, b := averageColor(bgPixels)
+			cell.Bg = opts.codeColor(dither.bg.quantize(col, row, r, g, b, quantize, opts.codeColor))
+		} else if opts.SampleBackground {
+			cell.Bg = opts.codeColor(opts.BlockColor.blockCode(bgPixels, quantize))
+		} else if opts.BackgroundColor != nil {
+			cell.Bg = opts.codeColor(*opts.BackgroundColor)
+		}
+		cells[col] = cell
+	}
+	return cells
+}
+
+// Render emits the grid as lines of text, one per row, honoring the NoColor, Frame, Hyperlink, and Align options.
+func (g Grid) Render(opts Options) []string {
+	opts.NoColor = opts.NoColor || envNoColor(opts)
+
+	lines := make([]string, len(g))
+	for row, cells := range g {
+		lines[row] = renderRow(cells, opts)
+	}
+
+	// Add frame if requested
+	if opts.Frame {
+		lines = addFrame(lines, opts)
+	}
+
+	// Wrap each line in a hyperlink if requested
+	if opts.Hyperlink != "" && !opts.NoColor {
+		for i, line := range lines {
+			lines[i] = hyperlink(opts.Hyperlink, line)
+		}
+	}
+
+	if opts.Align != AlignLeft && len(lines) > 0 {
+		width := 0
+		for _, cells := range g {
+			width = max(width, len(cells))
+		}
+		if opts.Frame {
+			width += 2
+		}
+		padding := alignPadding(width, opts)
+		for i, line := range lines {
+			lines[i] = padding + line
+		}
+	}
+	return lines
+}
+
+// renderRow emits a single row of cells, with ANSI color codes unless opts.NoColor is set.
+func renderRow(cells []Cell, opts Options) string {
+	var sb strings.Builder
+	for _, cell := range cells {
+		if opts.NoColor || (cell.Fg.A == 0 && cell.Bg.A == 0) {
+			sb.WriteRune(cell.Rune)
+			continue
+		}
+		fgColor := opts.ansiCode(cell.Fg)
+		if cell.Bg.A != 0 {
+			sb.WriteString(ansiFgBgColor(fgColor, opts.ansiCode(cell.Bg)))
+		} else {
+			sb.WriteString(ansiFgColor(fgColor))
+		}
+		sb.WriteRune(cell.Rune)
+		sb.WriteString(ansiReset())
+	}
+	return sb.String()
+}
+
+// Draw calls set for every cell of the grid, with the grid's top-left corner placed at (x, y).
+// It lets TUIs that manage their own screen buffer write cells directly instead of
+// emitting escape strings. For example, with tcell:
+//
+//	grid.Draw(0, 0, func(x, y int, c dots.Cell) {
+//		style := tcell.StyleDefault.Foreground(tcell.NewRGBColor(int32(c.Fg.R), int32(c.Fg.G), int32(c.Fg.B)))
+//		if c.Bg.A != 0 {
+//			style = style.Background(tcell.NewRGBColor(int32(c.Bg.R), int32(c.Bg.G), int32(c.Bg.B)))
+//		}
+//		screen.SetContent(x, y, c.Rune, nil, style)
+//	})
+func (g Grid) Draw(x, y int, set func(x, y int, cell Cell)) {
+	for row, cells := range g {
+		for col, cell := range cells {
+			set(x+col, y+row, cell)
+		}
+	}
+}
+
+// SideBySide joins grids left to right with gap blank columns between them, top
+// aligned. Shorter grids are padded with blank cells to the height of the tallest,
+// and each grid is as wide as its widest row, so ragged grids stay in columns.
+func SideBySide(gap int, grids ...Grid) Grid {
+	height := 0
+	for _, g := range grids {
+		height = max(height, len(g))
+	}
+	out := make(Grid, height)
+	for i, g := range grids {
+		width := 0
+		for _, cells := range g {
+			width = max(width, len(cells))
+		}
+		for row := range out {
+			if i > 0 {
+				out[row] = appendBlank(out[row], gap)
+			}
+			var cells []Cell
+			if row < len(g) {
+				cells = g[row]
+			}
+			out[row] = appendBlank(append(out[row], cells...), width-len(cells))
+		}
+	}
+	return out
+}
+
+// appendBlank appends n blank, uncolored cells to cells.
+func appendBlank(cells []Cell, n int) []Cell {
+	for range n {
+		cells = append(cells, Cell{Rune: ' '})
+	}
+	return cells
+}
+
+// WithHeader returns the grid with a line of text above it, e.g. a file name,
+// shortened with an ellipsis to the grid's width.
+func (g Grid) WithHeader(text string) Grid {
+	width := 0
+	for _, cells := range g {
+		width = max(width, len(cells))
+	}
+	runes := []rune(text)
+	if len(runes) > width {
+		runes = append(runes[:max(width-1, 0)], '…')[:width]
+	}
+	header := appendBlank(nil, width)
+	for i, r := range runes {
+		header[i].Rune = r
+	}
+	return append(Grid{header}, g...)
+}
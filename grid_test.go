@@ -0,0 +1,126 @@
+package dots
+
+import (
+	"image/png"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestConvertGrid(t *testing.T) {
+	f, err := os.Open("testdata/rainbow.png")
+	if err != nil {
+		t.Fatalf("failed to open test image: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode test image: %v", err)
+	}
+
+	bg := uint8(21)
+	opts := Options{Width: 6, Height: 3, BackgroundColor: &bg}
+	grid := ConvertGrid(img, opts)
+
+	if len(grid) != 3 {
+		t.Fatalf("got %d rows, want 3", len(grid))
+	}
+	for row, cells := range grid {
+		if len(cells) != 6 {
+			t.Errorf("row %d: got %d cells, want 6", row, len(cells))
+		}
+		for col, cell := range cells {
+			if cell.Rune != rune(0x2800+int(cell.Pattern)) {
+				t.Errorf("cell (%d, %d): rune %U does not match pattern %08b", row, col, cell.Rune, cell.Pattern)
+			}
+			if rgbaToANSI(cell.Bg) != bg {
+				t.Errorf("cell (%d, %d): background = %v, want ANSI %d", row, col, cell.Bg, bg)
+			}
+		}
+	}
+
+	// Rendering the grid must match Convert exactly.
+	want := Convert(img, opts)
+	got := grid.Render(opts)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d: Render() = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestANSIRoundTrip(t *testing.T) {
+	for code := 16; code < 256; code++ {
+		if got := rgbaToANSI(ansiToRGBA(uint8(code))); got != uint8(code) {
+			t.Errorf("rgbaToANSI(ansiToRGBA(%d)) = %d", code, got)
+		}
+	}
+}
+
+func TestGridDraw(t *testing.T) {
+	grid := Grid{
+		{{Rune: '⠁'}, {Rune: '⠂'}},
+		{{Rune: '⠄'}, {Rune: '⡀'}},
+	}
+
+	got := map[[2]int]rune{}
+	grid.Draw(10, 5, func(x, y int, c Cell) {
+		got[[2]int{x, y}] = c.Rune
+	})
+
+	want := map[[2]int]rune{
+		{10, 5}: '⠁', {11, 5}: '⠂',
+		{10, 6}: '⠄', {11, 6}: '⡀',
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d cells, want %d", len(got), len(want))
+	}
+	for pos, r := range want {
+		if got[pos] != r {
+			t.Errorf("cell at %v = %c, want %c", pos, got[pos], r)
+		}
+	}
+}
+
+func TestSideBySide(t *testing.T) {
+	a := Grid{
+		{{Rune: '⠁'}, {Rune: '⠂'}},
+		{{Rune: '⠄'}},
+	}
+	b := Grid{{{Rune: '⡀'}}}
+	c := Grid{
+		{{Rune: '⣿'}},
+		{{Rune: '⣿'}},
+		{{Rune: '⣿'}},
+	}
+
+	got := SideBySide(1, a, b, c).Render(Options{NoColor: true})
+	// Short rows and grids are padded so the columns stay aligned
+	want := []string{
+		"⠁⠂ ⡀ ⣿",
+		"⠄    ⣿",
+		"     ⣿",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("SideBySide() = %q, want %q", got, want)
+	}
+}
+
+func TestWithHeader(t *testing.T) {
+	grid := Grid{{{Rune: '⣿'}, {Rune: '⣿'}, {Rune: '⣿'}, {Rune: '⣿'}}}
+	for _, tt := range []struct {
+		text string
+		want []string
+	}{
+		{text: "a", want: []string{"a   ", "⣿⣿⣿⣿"}},
+		{text: "abcd", want: []string{"abcd", "⣿⣿⣿⣿"}},
+		{text: "abcdef", want: []string{"abc…", "⣿⣿⣿⣿"}},
+	} {
+		if got := grid.WithHeader(tt.text).Render(Options{NoColor: true}); !slices.Equal(got, tt.want) {
+			t.Errorf("WithHeader(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+	if got := (Grid{}).WithHeader("abc"); len(got) != 1 || len(got[0]) != 0 {
+		t.Errorf("WithHeader() of an empty grid = %v, want an empty line", got)
+	}
+}
@@ -0,0 +1,117 @@
+package dots
+
+import (
+	"image/color"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// lab is a color in the CIELAB space, where Euclidean distance (ΔE) approximates perceived difference.
+type lab struct {
+	L, A, B float64
+}
+
+// srgbToLab converts an 8-bit sRGB color to CIELAB, relative to the D65 white point.
+func srgbToLab(r, g, b uint8) lab {
+	linear := func(v uint8) float64 {
+		c := float64(v) / 255
+		if c <= 0.04045 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	rl, gl, bl := linear(r), linear(g), linear(b)
+
+	// Linear sRGB to XYZ, normalized by the D65 white point
+	x := (0.4124*rl + 0.3576*gl + 0.1805*bl) / 0.95047
+	y := 0.2126*rl + 0.7152*gl + 0.0722*bl
+	z := (0.0193*rl + 0.1192*gl + 0.9505*bl) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return lab{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// distance returns the squared ΔE (CIE76) between two colors.
+func (c lab) distance(o lab) float64 {
+	dl, da, db := c.L-o.L, c.A-o.A, c.B-o.B
+	return dl*dl + da*da + db*db
+}
+
+// labBits is the number of bits per channel of the nearest-color lookup table.
+const labBits = 6
+
+var (
+	labOnce    sync.Once
+	labPalette [240]lab
+	// labTable caches the palette entry nearest to each level of the lookup table,
+	// filled in on first use since searching for all of them up front is slow.
+	// Zero means not yet computed, as the system colors are never chosen.
+	labTable [1 << (3 * labBits)]atomic.Uint32
+)
+
+// nearestANSI returns the ANSI 256 color code perceptually closest to an RGB color.
+// The system colors 0-15 are skipped, since terminals customize them.
+func nearestANSI(r, g, b uint8) uint8 {
+	i := labIndex(r)<<(2*labBits) | labIndex(g)<<labBits | labIndex(b)
+	if code := labTable[i].Load(); code != 0 {
+		return uint8(code)
+	}
+
+	labOnce.Do(func() {
+		for i := range labPalette {
+			c := ansiToRGBA(uint8(16 + i))
+			labPalette[i] = srgbToLab(c.R, c.G, c.B)
+		}
+	})
+	c := srgbToLab(labLevel(labIndex(r)), labLevel(labIndex(g)), labLevel(labIndex(b)))
+	best, bestDist := 0, math.Inf(1)
+	for j, p := range labPalette {
+		if d := c.distance(p); d < bestDist {
+			best, bestDist = j, d
+		}
+	}
+	code := uint8(16 + best)
+	labTable[i].Store(uint32(code))
+	return code
+}
+
+// labIndex maps a channel value to the nearest level of the lookup table.
+func labIndex(v uint8) int {
+	const levels = 1<<labBits - 1
+	return (int(v)*levels + 127) / 255
+}
+
+// labLevel returns the channel value of a level of the lookup table.
+// The levels include 0 and 255, so black and white map exactly.
+func labLevel(i int) uint8 {
+	const levels = 1<<labBits - 1
+	return uint8((i*255 + levels/2) / levels)
+}
+
+// systemQuantizer returns a function mapping colors to the perceptually nearest
+// ANSI 256 color code, including the system colors 0-15 with the given values.
+func systemQuantizer(system *[16]color.RGBA) func(r, g, b uint8) uint8 {
+	var labs [16]lab
+	for i, c := range system {
+		labs[i] = srgbToLab(c.R, c.G, c.B)
+	}
+	return func(r, g, b uint8) uint8 {
+		c := srgbToLab(r, g, b)
+		best := nearestANSI(r, g, b)
+		nc := ansiToRGBA(best)
+		bestDist := c.distance(srgbToLab(nc.R, nc.G, nc.B))
+		for i, l := range labs {
+			if d := c.distance(l); d < bestDist {
+				best, bestDist = uint8(i), d
+			}
+		}
+		return best
+	}
+}
@@ -0,0 +1,123 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestSRGBToLab(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		r, g, b uint8
+		want    lab
+	}{
+		{desc: "black", r: 0, g: 0, b: 0, want: lab{0, 0, 0}},
+		{desc: "white", r: 255, g: 255, b: 255, want: lab{100, 0, 0}},
+		{desc: "red", r: 255, g: 0, b: 0, want: lab{53.24, 80.09, 67.20}},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := srgbToLab(tt.r, tt.g, tt.b)
+			if math.Abs(got.L-tt.want.L) > 0.05 || math.Abs(got.A-tt.want.A) > 0.05 || math.Abs(got.B-tt.want.B) > 0.05 {
+				t.Errorf("srgbToLab(%d, %d, %d) = %+v, want %+v", tt.r, tt.g, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuantizeRGBPerceptual(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		r, g, b uint8
+		want    uint8
+	}{
+		{
+			desc: "dark brown stays neutral",
+			r:    60, g: 50, b: 40,
+			want: 236, // Nearest per channel is olive (95, 95, 0)
+		},
+		{
+			desc: "skin tone",
+			r:    241, g: 194, b: 125,
+			want: 222, // Nearest per channel is pinker (255, 175, 135)
+		},
+		{
+			desc: "pastel green",
+			r:    200, g: 230, b: 200,
+			want: 151, // Nearest per channel is gray (215, 215, 215)
+		},
+		{
+			desc: "palette entries map to themselves",
+			r:    215, g: 135, b: 95,
+			want: 173,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := quantizeRGB(tt.r, tt.g, tt.b); got != tt.want {
+				t.Errorf("quantizeRGB(%d, %d, %d) = %d %v, want %d %v", tt.r, tt.g, tt.b, got, ansiToRGBA(got), tt.want, ansiToRGBA(tt.want))
+			}
+		})
+	}
+}
+
+func TestSystemColors(t *testing.T) {
+	// A theme whose red (code 1) is a salmon that isn't in the 6×6×6 cube
+	system := systemColors
+	system[1] = color.RGBA{250, 128, 114, 255}
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 8))
+	for i := 0; i < len(img.Pix); i += 4 {
+		copy(img.Pix[i:], []uint8{250, 128, 114, 255})
+	}
+
+	opts := Options{Width: 2, Height: 2, SystemColors: &system}
+	grid := ConvertGrid(img, opts)
+	if got := grid[0][0].Fg; got != system[1] {
+		t.Errorf("Fg = %v, want the theme's red %v", got, system[1])
+	}
+	if line := grid.Render(opts)[0]; !strings.Contains(line, "\x1b[38;5;1m") {
+		t.Errorf("Render() = %q, want ANSI color 1", line)
+	}
+
+	// Without the theme, the system colors are avoided
+	grid = ConvertGrid(img, Options{Width: 2, Height: 2})
+	if code := rgbaToANSI(grid[0][0].Fg); code < 16 {
+		t.Errorf("got system color %d without SystemColors", code)
+	}
+}
+
+func TestGrayscale(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		v    uint8
+		want uint8
+	}{
+		{desc: "black", v: 0, want: 232},
+		{desc: "first step", v: 8, want: 232},
+		{desc: "rounds up", v: 14, want: 233},
+		{desc: "mid gray", v: 128, want: 244},
+		{desc: "white", v: 255, want: 255},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := quantizeGray(tt.v); got != tt.want {
+				t.Errorf("quantizeGray(%d) = %d, want %d", tt.v, got, tt.want)
+			}
+		})
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 8))
+	for i := 0; i < len(img.Pix); i += 4 {
+		copy(img.Pix[i:], []uint8{255, 0, 0, 255})
+	}
+	grid := ConvertGrid(img, Options{Width: 2, Height: 2, Grayscale: true, SampleBackground: true})
+	for _, cell := range grid[0] {
+		if code := rgbaToANSI(cell.Fg); code < 232 {
+			t.Errorf("Fg = %d, want a grayscale ramp color", code)
+		}
+		if code := rgbaToANSI(cell.Bg); code < 232 {
+			t.Errorf("Bg = %d, want a grayscale ramp color", code)
+		}
+	}
+}
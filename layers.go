@@ -0,0 +1,128 @@
+package dots
+
+import (
+	"image/color"
+	"slices"
+	"strings"
+)
+
+// Layers is a stack of named canvases of the same size, composited when rendered,
+// so parts of a drawing such as a background diagram, moving markers, and a HUD can
+// be redrawn, hidden, and reordered independently.
+type Layers struct {
+	width, height int
+	layers        []*Layer // From bottom to top
+}
+
+// Layer is one named canvas of a Layers.
+type Layer struct {
+	*Canvas
+	Name string
+	// Hidden leaves the layer out of the composite.
+	Hidden bool
+	// Opaque hides the layers below in every cell where the layer has a lit dot,
+	// so e.g. text stays legible over a busy drawing. Otherwise the dots of every
+	// layer show through.
+	Opaque bool
+	// Color colors the cells where the layer is the topmost with a lit dot, unless
+	// its dots there are colored; a zero-alpha color leaves them uncolored.
+	Color color.RGBA
+}
+
+// NewLayers creates an empty stack of layers of width×height dots.
+func NewLayers(width, height int) *Layers {
+	return &Layers{width: width, height: height}
+}
+
+// Layer returns the layer named name, adding a blank one on top if there is none.
+func (l *Layers) Layer(name string) *Layer {
+	if i := l.index(name); i >= 0 {
+		return l.layers[i]
+	}
+	layer := &Layer{Canvas: NewCanvas(l.width, l.height), Name: name}
+	l.layers = append(l.layers, layer)
+	return layer
+}
+
+// Names returns the names of the layers from bottom to top.
+func (l *Layers) Names() []string {
+	names := make([]string, len(l.layers))
+	for i, layer := range l.layers {
+		names[i] = layer.Name
+	}
+	return names
+}
+
+// Remove removes the layer named name, if there is one.
+func (l *Layers) Remove(name string) {
+	if i := l.index(name); i >= 0 {
+		l.layers = slices.Delete(l.layers, i, i+1)
+	}
+}
+
+// Raise moves the layer named name to the top, if there is one.
+func (l *Layers) Raise(name string) {
+	if i := l.index(name); i >= 0 {
+		layer := l.layers[i]
+		l.layers = append(slices.Delete(l.layers, i, i+1), layer)
+	}
+}
+
+// Lower moves the layer named name to the bottom, if there is one.
+func (l *Layers) Lower(name string) {
+	if i := l.index(name); i >= 0 {
+		layer := l.layers[i]
+		l.layers = slices.Insert(slices.Delete(l.layers, i, i+1), 0, layer)
+	}
+}
+
+func (l *Layers) index(name string) int {
+	return slices.IndexFunc(l.layers, func(layer *Layer) bool { return layer.Name == name })
+}
+
+// Grid composites the visible layers into braille cells, from the top down: each
+// cell has the dots of every layer down to the first opaque one with a lit dot in
+// it, and the color of the topmost layer with a lit dot in it.
+func (l *Layers) Grid() Grid {
+	grid := NewCanvas(l.width, l.height).Grid()
+	grids := make([]Grid, len(l.layers))
+	for i, layer := range l.layers {
+		if !layer.Hidden {
+			grids[i] = layer.Canvas.Grid()
+		}
+	}
+	for row := range grid {
+		for col := range grid[row] {
+			cell := &grid[row][col]
+			colored := false
+			for i := len(l.layers) - 1; i >= 0; i-- {
+				if grids[i] == nil {
+					continue
+				}
+				above := grids[i][row][col]
+				if above.Pattern == 0 {
+					continue
+				}
+				cell.Pattern |= above.Pattern
+				if !colored {
+					// Colored dots take precedence over the layer's color
+					cell.Fg = above.Fg
+					if cell.Fg.A == 0 {
+						cell.Fg = l.layers[i].Color
+					}
+					colored = true
+				}
+				if l.layers[i].Opaque {
+					break
+				}
+			}
+			cell.Rune = rune(0x2800 + int(cell.Pattern))
+		}
+	}
+	return grid
+}
+
+// String returns the composite as lines of braille characters, without colors.
+func (l *Layers) String() string {
+	return strings.Join(l.Grid().Render(Options{NoColor: true}), "\n")
+}
@@ -0,0 +1,48 @@
+package dots
+
+import (
+	"image/color"
+	"slices"
+	"testing"
+)
+
+func TestLayers(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	l := NewLayers(4, 4)
+	bg := l.Layer("background")
+	bg.FillRect(0, 0, 3, 3)
+	hud := l.Layer("hud")
+	hud.Set(0, 0)
+	hud.Color = red
+
+	if got, want := l.Names(), []string{"background", "hud"}; !slices.Equal(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+	if l.Layer("hud") != hud {
+		t.Error("Layer() of an existing name made a new layer")
+	}
+
+	for _, tt := range []struct {
+		desc  string
+		setup func()
+		want  string
+		fg    color.RGBA // Of the first cell
+	}{
+		{"transparent", func() {}, "⣿⣿", red},
+		{"opaque", func() { hud.Opaque = true }, "⠁⣿", red},
+		{"lowered", func() { l.Lower("hud") }, "⣿⣿", color.RGBA{}},
+		{"raised", func() { l.Raise("hud") }, "⠁⣿", red},
+		{"hidden", func() { hud.Hidden = true }, "⣿⣿", color.RGBA{}},
+		{"cleared", func() { hud.Hidden = false; bg.Clear() }, "⠁⠀", red},
+		{"removed", func() { l.Remove("hud") }, "⠀⠀", color.RGBA{}},
+	} {
+		tt.setup()
+		g := l.Grid()
+		if got := l.String(); got != tt.want {
+			t.Errorf("%s: String() = %q, want %q", tt.desc, got, tt.want)
+		}
+		if got := g[0][0].Fg; got != tt.fg {
+			t.Errorf("%s: first cell color = %v, want %v", tt.desc, got, tt.fg)
+		}
+	}
+}
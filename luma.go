@@ -0,0 +1,60 @@
+package dots
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// Luma holds the weights of the red, green, and blue channels in a pixel's luminance.
+type Luma struct {
+	R, G, B float64
+}
+
+// Standard luma coefficients.
+var (
+	// LumaRec601 is the default, from SD video and most still images.
+	LumaRec601 = Luma{0.299, 0.587, 0.114}
+	// LumaRec709 matches HD video and screenshots of sRGB displays.
+	LumaRec709 = Luma{0.2126, 0.7152, 0.0722}
+)
+
+// ParseLuma parses a luma formula: "rec601" (or "601"), "rec709" (or "709"),
+// or custom weights as three comma-separated numbers, which are normalized to sum to 1.
+// The empty string selects Rec.601.
+func ParseLuma(s string) (Luma, error) {
+	switch strings.ToLower(s) {
+	case "", "rec601", "601":
+		return LumaRec601, nil
+	case "rec709", "709":
+		return LumaRec709, nil
+	}
+
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return Luma{}, fmt.Errorf("unknown luma %q (expected rec601, rec709, or r,g,b weights)", s)
+	}
+	var w [3]float64
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil || v < 0 {
+			return Luma{}, fmt.Errorf("invalid luma weight %q", p)
+		}
+		w[i] = v
+	}
+	sum := w[0] + w[1] + w[2]
+	if sum == 0 {
+		return Luma{}, fmt.Errorf("luma weights must not all be zero")
+	}
+	return Luma{w[0] / sum, w[1] / sum, w[2] / sum}, nil
+}
+
+// Y converts a color to grayscale using the luma weights.
+func (l Luma) Y(c color.Color) uint8 {
+	r, g, b, _ := c.RGBA()
+	// RGBA() returns values in [0, 65535], convert to [0, 255]
+	r8, g8, b8 := uint8(r>>8), uint8(g>>8), uint8(b>>8)
+	// Round off float error, so white stays 255 whatever the weights
+	return uint8(min(l.R*float64(r8)+l.G*float64(g8)+l.B*float64(b8)+1e-9, 255))
+}
@@ -0,0 +1,62 @@
+package dots
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestParseLuma(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		s       string
+		want    Luma
+		wantErr bool
+	}{
+		{desc: "default", s: "", want: LumaRec601},
+		{desc: "rec601", s: "rec601", want: LumaRec601},
+		{desc: "rec709", s: "Rec709", want: LumaRec709},
+		{desc: "short 709", s: "709", want: LumaRec709},
+		{desc: "custom weights are normalized", s: "1, 2, 1", want: Luma{0.25, 0.5, 0.25}},
+		{desc: "wrong number of weights", s: "1,2", wantErr: true},
+		{desc: "negative weight", s: "1,-1,1", wantErr: true},
+		{desc: "all zero", s: "0,0,0", wantErr: true},
+		{desc: "unknown name", s: "rec2020", wantErr: true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseLuma(tt.s)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseLuma(%q) expected error, got nil", tt.s)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLuma(%q) unexpected error: %v", tt.s, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLuma(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLumaY(t *testing.T) {
+	green := color.RGBA{0, 255, 0, 255}
+	for _, tt := range []struct {
+		desc string
+		luma Luma
+		c    color.Color
+		want uint8
+	}{
+		{desc: "rec601 green", luma: LumaRec601, c: green, want: 149},
+		{desc: "rec709 green", luma: LumaRec709, c: green, want: 182},
+		{desc: "rec709 blue", luma: LumaRec709, c: color.RGBA{0, 0, 255, 255}, want: 18},
+		{desc: "white", luma: LumaRec709, c: color.White, want: 255},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := tt.luma.Y(tt.c); got != tt.want {
+				t.Errorf("Y(%v) = %d, want %d", tt.c, got, tt.want)
+			}
+		})
+	}
+}
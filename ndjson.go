@@ -0,0 +1,64 @@
+package dots
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// NDJSONFrame is a frame of the NDJSON frame protocol, which carries renders from
+// the process producing them to one displaying or recording them. Each line of
+// the stream is a frame as a JSON object:
+//
+//	{"frame":0,"lines":["⣿⣿⣿⣿","⠛⠛⠛⠛"],"delay_ms":100}
+//
+// Frames are numbered from 0. The lines are rendered output, escape codes and
+// all. DelayMS is how long the frame is shown before the next, and is omitted
+// for still images and live frames, which are shown until the next arrives.
+type NDJSONFrame struct {
+	Frame   int      `json:"frame"`
+	Lines   []string `json:"lines"`
+	DelayMS int64    `json:"delay_ms,omitempty"`
+}
+
+// Delay returns how long the frame is shown before the next.
+func (f NDJSONFrame) Delay() time.Duration {
+	return time.Duration(f.DelayMS) * time.Millisecond
+}
+
+// WriteNDJSON writes every frame of an animation to w as NDJSON frames, with
+// their delays.
+func WriteNDJSON(w io.Writer, anim *Animation, opts Options) error {
+	enc := json.NewEncoder(w)
+	for i, frame := range anim.Frames {
+		err := enc.Encode(NDJSONFrame{
+			Frame:   i,
+			Lines:   Convert(frame.Image, opts),
+			DelayMS: frame.Delay.Milliseconds(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadNDJSON reads NDJSON frames from r, calling fn with each as soon as it's
+// read, so frames from a live producer are handled as they arrive. It returns
+// at the end of r, or with the first error from fn or in the stream.
+func ReadNDJSON(r io.Reader, fn func(NDJSONFrame) error) error {
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var frame NDJSONFrame
+		if err := dec.Decode(&frame); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("invalid frame %d: %w", n, err)
+		}
+		if err := fn(frame); err != nil {
+			return err
+		}
+	}
+}
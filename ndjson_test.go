@@ -0,0 +1,78 @@
+package dots
+
+import (
+	"image"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNDJSONRoundTrip(t *testing.T) {
+	white := image.NewGray(image.Rect(0, 0, 4, 8))
+	for i := range white.Pix {
+		white.Pix[i] = 255
+	}
+	anim := &Animation{Frames: []Frame{
+		{Image: image.NewGray(image.Rect(0, 0, 4, 8)), Delay: 100 * time.Millisecond},
+		{Image: white, Delay: 250 * time.Millisecond},
+	}}
+	opts := Options{Width: 2, Height: 2, NoColor: true, CellAspect: 0.5}
+
+	var sb strings.Builder
+	if err := WriteNDJSON(&sb, anim, opts); err != nil {
+		t.Fatalf("WriteNDJSON() = %v", err)
+	}
+	if n := strings.Count(sb.String(), "\n"); n != 2 {
+		t.Errorf("WriteNDJSON() wrote %d lines, want 2:\n%s", n, sb.String())
+	}
+
+	var frames []NDJSONFrame
+	err := ReadNDJSON(strings.NewReader(sb.String()), func(f NDJSONFrame) error {
+		frames = append(frames, f)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadNDJSON() = %v", err)
+	}
+	if len(frames) != len(anim.Frames) {
+		t.Fatalf("ReadNDJSON() read %d frames, want %d", len(frames), len(anim.Frames))
+	}
+	for i, f := range frames {
+		if f.Frame != i {
+			t.Errorf("frame %d: Frame = %d", i, f.Frame)
+		}
+		if f.Delay() != anim.Frames[i].Delay {
+			t.Errorf("frame %d: Delay() = %v, want %v", i, f.Delay(), anim.Frames[i].Delay)
+		}
+		if want := Convert(anim.Frames[i].Image, opts); !slices.Equal(f.Lines, want) {
+			t.Errorf("frame %d: Lines = %q, want %q", i, f.Lines, want)
+		}
+	}
+}
+
+func TestNDJSONFormat(t *testing.T) {
+	anim := &Animation{Frames: []Frame{{Image: image.NewGray(image.Rect(0, 0, 2, 4))}}}
+	var sb strings.Builder
+	if err := WriteNDJSON(&sb, anim, Options{Width: 1, Height: 1, NoColor: true}); err != nil {
+		t.Fatal(err)
+	}
+	// A still frame has no delay
+	if got, want := sb.String(), `{"frame":0,"lines":["⠀"]}`+"\n"; got != want {
+		t.Errorf("WriteNDJSON() = %q, want %q", got, want)
+	}
+}
+
+func TestReadNDJSONInvalid(t *testing.T) {
+	var n int
+	err := ReadNDJSON(strings.NewReader(`{"frame":0,"lines":["⣿"]}`+"\nnot json\n"), func(NDJSONFrame) error {
+		n++
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "frame 2") {
+		t.Errorf("ReadNDJSON() = %v, want an error for frame 2", err)
+	}
+	if n != 1 {
+		t.Errorf("ReadNDJSON() read %d frames before the error, want 1", n)
+	}
+}
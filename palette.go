@@ -0,0 +1,286 @@
+package dots
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// PaletteMode selects how an image-specific palette is built for quantizing block colors.
+type PaletteMode string
+
+// Supported palette modes.
+const (
+	// PaletteNone quantizes every block independently to the ANSI 256 palette.
+	PaletteNone PaletteMode = ""
+	// PaletteMedianCut builds the palette by recursively splitting the image's colors
+	// at the median of their widest channel.
+	PaletteMedianCut PaletteMode = "median-cut"
+	// PaletteKMeans locks the output to the image's dominant colors, as found by ExtractPalette.
+	PaletteKMeans PaletteMode = "k-means"
+)
+
+// PaletteModes lists the names of all supported palette modes.
+var PaletteModes = []PaletteMode{PaletteMedianCut, PaletteKMeans}
+
+// ParsePaletteMode validates a palette mode name.
+// The empty string and "none" select no adaptive palette.
+func ParsePaletteMode(s string) (PaletteMode, error) {
+	if s == "" || s == "none" {
+		return PaletteNone, nil
+	}
+	for _, mode := range PaletteModes {
+		if PaletteMode(s) == mode {
+			return mode, nil
+		}
+	}
+	return PaletteNone, fmt.Errorf("unknown palette mode %q", s)
+}
+
+// ParsePalette reads a palette file: either a GIMP palette (.gpl), or a list of
+// hex colors separated by whitespace or commas, such as a Lospec .hex file.
+// Lines starting with "//" or ";" in hex lists are comments.
+func ParsePalette(r io.Reader) ([]color.RGBA, error) {
+	scanner := bufio.NewScanner(r)
+	var colors []color.RGBA
+	gpl := false
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if line == 1 && text == "GIMP Palette" {
+			gpl = true
+			continue
+		}
+
+		if gpl {
+			// Skip blank lines, comments, and header fields like "Name: Solarized"
+			if text == "" || text[0] < '0' || text[0] > '9' {
+				continue
+			}
+			fields := strings.Fields(text)
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("line %d: expected R G B values", line)
+			}
+			var rgb [3]uint8
+			for i := range rgb {
+				v, err := strconv.ParseUint(fields[i], 10, 8)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid color value %q", line, fields[i])
+				}
+				rgb[i] = uint8(v)
+			}
+			colors = append(colors, color.RGBA{rgb[0], rgb[1], rgb[2], 255})
+			continue
+		}
+
+		if strings.HasPrefix(text, "//") || strings.HasPrefix(text, ";") {
+			continue
+		}
+		for _, field := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+			c, err := ParseHexColor(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			colors = append(colors, c)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("palette has no colors")
+	}
+	return colors, nil
+}
+
+// PaletteColor is a color extracted from an image, with its nearest ANSI 256 color code.
+type PaletteColor struct {
+	color.RGBA
+	ANSI uint8
+}
+
+// ExtractPalette returns up to k dominant colors of an image, most common first,
+// found by k-means clustering.
+func ExtractPalette(img image.Image, k int) []PaletteColor {
+	// Clustering a thumbnail finds the same dominant colors much faster
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil
+	}
+	if scale := 64.0 / float64(max(width, height)); scale < 1 {
+		width, height = max(int(float64(width)*scale), 1), max(int(float64(height)*scale), 1)
+	}
+	thumb := resize(img, width, height)
+	compositeMatte(thumb, nil)
+
+	colors := kMeans(thumb, k)
+	palette := make([]PaletteColor, len(colors))
+	for i, c := range colors {
+		palette[i] = PaletteColor{RGBA: c, ANSI: quantizeRGB(c.R, c.G, c.B)}
+	}
+	return palette
+}
+
+// palette quantizes colors to a fixed set of entries, each shown as its nearest ANSI 256 color.
+type palette struct {
+	labs  []lab
+	codes []uint8
+}
+
+// newPalette prepares a palette of colors for nearest-color searches,
+// using quantize to choose the ANSI 256 color code shown for each entry.
+func newPalette(colors []color.RGBA, quantize func(r, g, b uint8) uint8) *palette {
+	p := &palette{}
+	for _, c := range colors {
+		p.labs = append(p.labs, srgbToLab(c.R, c.G, c.B))
+		p.codes = append(p.codes, quantize(c.R, c.G, c.B))
+	}
+	return p
+}
+
+// nearest returns the ANSI 256 color code of the palette entry perceptually closest to an RGB color.
+func (p *palette) nearest(r, g, b uint8) uint8 {
+	c := srgbToLab(r, g, b)
+	best, bestDist := 0, math.Inf(1)
+	for i, l := range p.labs {
+		if d := c.distance(l); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return p.codes[best]
+}
+
+// medianCut builds a palette of up to n colors from the pixels of an image.
+func medianCut(img *image.RGBA, n int) []color.RGBA {
+	pixels := make([][3]uint8, 0, len(img.Pix)/4)
+	for i := 0; i < len(img.Pix); i += 4 {
+		pixels = append(pixels, [3]uint8{img.Pix[i], img.Pix[i+1], img.Pix[i+2]})
+	}
+	if len(pixels) == 0 || n < 1 {
+		return nil
+	}
+
+	boxes := [][][3]uint8{pixels}
+	for len(boxes) < n {
+		// Split the box with the widest channel range
+		split, channel, widest := -1, 0, uint8(0)
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+			if c, r := widestChannel(box); r > widest {
+				split, channel, widest = i, c, r
+			}
+		}
+		if split < 0 {
+			break // Every box holds a single color
+		}
+
+		box := boxes[split]
+		slices.SortFunc(box, func(a, b [3]uint8) int { return int(a[channel]) - int(b[channel]) })
+		mid := len(box) / 2
+		boxes[split] = box[:mid]
+		boxes = append(boxes, box[mid:])
+	}
+
+	colors := make([]color.RGBA, len(boxes))
+	for i, box := range boxes {
+		var sum [3]int
+		for _, p := range box {
+			for c := range sum {
+				sum[c] += int(p[c])
+			}
+		}
+		colors[i] = color.RGBA{uint8(sum[0] / len(box)), uint8(sum[1] / len(box)), uint8(sum[2] / len(box)), 255}
+	}
+	return colors
+}
+
+// kMeans clusters the pixels of an image into up to k colors, returned most common first.
+// Clusters start from the median-cut palette, so the result is deterministic.
+func kMeans(img *image.RGBA, k int) []color.RGBA {
+	centers := medianCut(img, k)
+	if len(centers) == 0 {
+		return nil
+	}
+
+	means := make([][3]float64, len(centers))
+	for i, c := range centers {
+		means[i] = [3]float64{float64(c.R), float64(c.G), float64(c.B)}
+	}
+	counts := make([]int, len(means))
+	for range 10 {
+		sums := make([][3]float64, len(means))
+		clear(counts)
+		for i := 0; i < len(img.Pix); i += 4 {
+			p := [3]float64{float64(img.Pix[i]), float64(img.Pix[i+1]), float64(img.Pix[i+2])}
+			best, bestDist := 0, math.Inf(1)
+			for j, m := range means {
+				dr, dg, db := p[0]-m[0], p[1]-m[1], p[2]-m[2]
+				if d := dr*dr + dg*dg + db*db; d < bestDist {
+					best, bestDist = j, d
+				}
+			}
+			for c := range p {
+				sums[best][c] += p[c]
+			}
+			counts[best]++
+		}
+
+		moved := false
+		for j := range means {
+			if counts[j] == 0 {
+				continue
+			}
+			for c := range means[j] {
+				m := sums[j][c] / float64(counts[j])
+				moved = moved || math.Abs(m-means[j][c]) > 0.5
+				means[j][c] = m
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+
+	// Drop empty clusters and sort by population
+	order := make([]int, 0, len(means))
+	for j := range means {
+		if counts[j] > 0 {
+			order = append(order, j)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return counts[b] - counts[a] })
+	colors := make([]color.RGBA, len(order))
+	for i, j := range order {
+		m := means[j]
+		colors[i] = color.RGBA{uint8(math.Round(m[0])), uint8(math.Round(m[1])), uint8(math.Round(m[2])), 255}
+	}
+	return colors
+}
+
+// widestChannel returns the channel with the largest range of values in a box, and that range.
+func widestChannel(box [][3]uint8) (int, uint8) {
+	lo := [3]uint8{255, 255, 255}
+	var hi [3]uint8
+	for _, p := range box {
+		for c := range p {
+			lo[c] = min(lo[c], p[c])
+			hi[c] = max(hi[c], p[c])
+		}
+	}
+	channel := 0
+	for c := 1; c < 3; c++ {
+		if hi[c]-lo[c] > hi[channel]-lo[channel] {
+			channel = c
+		}
+	}
+	return channel, hi[channel] - lo[channel]
+}
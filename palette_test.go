@@ -0,0 +1,200 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParsePaletteMode(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		s       string
+		want    PaletteMode
+		wantErr bool
+	}{
+		{desc: "empty", s: "", want: PaletteNone},
+		{desc: "none", s: "none", want: PaletteNone},
+		{desc: "median-cut", s: "median-cut", want: PaletteMedianCut},
+		{desc: "k-means", s: "k-means", want: PaletteKMeans},
+		{desc: "unknown", s: "octree", wantErr: true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParsePaletteMode(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePaletteMode(%q) error = %v, wantErr %t", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePaletteMode(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMedianCut(t *testing.T) {
+	// Left half red, right half blue
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			c := color.RGBA{255, 0, 0, 255}
+			if x >= 2 {
+				c = color.RGBA{0, 0, 255, 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	for _, tt := range []struct {
+		desc string
+		n    int
+		want int
+	}{
+		{desc: "one color averages everything", n: 1, want: 1},
+		{desc: "two colors split red and blue", n: 2, want: 2},
+		{desc: "stops when every box is a single color", n: 8, want: 2},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			colors := medianCut(img, tt.n)
+			if len(colors) != tt.want {
+				t.Fatalf("medianCut(%d) returned %d colors, want %d: %v", tt.n, len(colors), tt.want, colors)
+			}
+			if tt.want == 2 {
+				got := map[color.RGBA]bool{colors[0]: true, colors[1]: true}
+				if !got[color.RGBA{255, 0, 0, 255}] || !got[color.RGBA{0, 0, 255, 255}] {
+					t.Errorf("medianCut(%d) = %v, want red and blue", tt.n, colors)
+				}
+			}
+		})
+	}
+}
+
+func TestPaletteMedianCut(t *testing.T) {
+	f, err := os.Open("testdata/rainbow_gradient.png")
+	if err != nil {
+		t.Fatalf("failed to open test image: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode test image: %v", err)
+	}
+
+	grid := ConvertGrid(img, Options{Width: 40, Height: 10, PaletteMode: PaletteMedianCut, PaletteSize: 4})
+	codes := map[uint8]bool{}
+	for _, cells := range grid {
+		for _, cell := range cells {
+			codes[rgbaToANSI(cell.Fg)] = true
+		}
+	}
+	if len(codes) > 4 {
+		t.Errorf("got %d distinct colors with a 4-color palette, want at most 4", len(codes))
+	}
+}
+
+func TestExtractPalette(t *testing.T) {
+	// Three quarters green, one quarter magenta
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			c := color.RGBA{0, 255, 0, 255}
+			if x >= 75 {
+				c = color.RGBA{255, 0, 255, 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	palette := ExtractPalette(img, 2)
+	if len(palette) != 2 {
+		t.Fatalf("ExtractPalette() returned %d colors, want 2", len(palette))
+	}
+	// The thumbnail blends colors along the boundary, so only the ANSI codes are exact
+	for i, want := range []uint8{46, 201} {
+		if palette[i].ANSI != want {
+			t.Errorf("palette[%d] = %v, want ANSI %d", i, palette[i], want)
+		}
+	}
+}
+
+func TestParsePalette(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		file    string
+		want    []color.RGBA
+		wantErr bool
+	}{
+		{
+			desc: "hex list",
+			file: "0f380f\n306230\n\n8bac0f\n9bbc0f\n",
+			want: []color.RGBA{{15, 56, 15, 255}, {48, 98, 48, 255}, {139, 172, 15, 255}, {155, 188, 15, 255}},
+		},
+		{
+			desc: "comma-separated with comments",
+			file: "// CGA\n#000, #5ff, #f5f, #fff\n",
+			want: []color.RGBA{{0, 0, 0, 255}, {85, 255, 255, 255}, {255, 85, 255, 255}, {255, 255, 255, 255}},
+		},
+		{
+			desc: "GIMP palette",
+			file: "GIMP Palette\nName: Solarized\nColumns: 2\n#\n  0  43  54\tbase03\n253 246 227\tbase3\n",
+			want: []color.RGBA{{0, 43, 54, 255}, {253, 246, 227, 255}},
+		},
+		{
+			desc:    "invalid hex",
+			file:    "ff0000\nnope\n",
+			wantErr: true,
+		},
+		{
+			desc:    "invalid GIMP value",
+			file:    "GIMP Palette\n0 0 300 red\n",
+			wantErr: true,
+		},
+		{
+			desc:    "empty",
+			file:    "// nothing here\n",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParsePalette(strings.NewReader(tt.file))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParsePalette() expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePalette() unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParsePalette() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomPalette(t *testing.T) {
+	f, err := os.Open("testdata/rainbow.png")
+	if err != nil {
+		t.Fatalf("failed to open test image: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode test image: %v", err)
+	}
+
+	// Red and blue only
+	palette := []color.RGBA{{255, 0, 0, 255}, {0, 0, 255, 255}}
+	grid := ConvertGrid(img, Options{Width: 20, Height: 10, Palette: palette})
+	for _, cells := range grid {
+		for _, cell := range cells {
+			if code := rgbaToANSI(cell.Fg); code != 196 && code != 21 {
+				t.Fatalf("got color %d, want only red (196) or blue (21)", code)
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package plot
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/imjasonh/dots"
+)
+
+// Axes configures the decorations drawn in regular characters around a chart's
+// braille plotting area. The zero value draws none, giving the whole chart to the
+// plot. The decorations take their space out of the chart's Width and Height.
+type Axes struct {
+	// Show draws an x axis along the bottom and a y axis along the left, with tick
+	// marks and labels at round numbers.
+	Show bool
+	// Grid draws dotted gridlines across the plot at the ticks, under the data.
+	Grid bool
+	// Legend draws a line above the plot naming each series in its color.
+	Legend bool
+	// XTicks and YTicks are the approximate numbers of ticks on each axis; 0 picks
+	// a number suited to the axis's length.
+	XTicks, YTicks int
+	// Format formats tick labels; nil formats them with as many decimals as the
+	// spacing between ticks needs.
+	Format func(v float64) string
+}
+
+// gridColor is the color of gridlines, a dark gray from the ANSI grayscale ramp.
+var gridColor = color.RGBA{0x58, 0x58, 0x58, 255}
+
+// legendEntry is a series' name and color, for the legend.
+type legendEntry struct {
+	name  string
+	color color.RGBA
+}
+
+// draw returns a width×height grid with a plot inside the axes. The plot spans xlo to
+// xhi and ylo to yhi; plot draws it for an area of w×h characters, composing its
+// layers over the given background layers. If the chart is too small for the
+// decorations, only the plot is drawn.
+func (a Axes) draw(width, height int, xlo, xhi, ylo, yhi float64, legend []legendEntry, plot func(w, h int, background []layer) dots.Grid) dots.Grid {
+	top := 0
+	if a.Legend {
+		top = 1
+	}
+	left, bottom := 0, 0
+	var yTicks []float64
+	var yLabels []string
+	if a.Show {
+		var step float64
+		yTicks, step = ticks(ylo, yhi, a.tickCount(a.YTicks, height-top-2, 3))
+		yLabels = a.labels(yTicks, step)
+		for _, l := range yLabels {
+			left = max(left, len([]rune(l)))
+		}
+		left++ // The axis line
+		bottom = 2
+	}
+	w, h := width-left, height-top-bottom
+	if w < 1 || h < 1 {
+		return plot(width, height, nil)
+	}
+
+	var xTicks []float64
+	var xStep float64
+	if a.Show || a.Grid {
+		xTicks, xStep = ticks(xlo, xhi, a.tickCount(a.XTicks, w, 10))
+		if yTicks == nil {
+			yTicks, _ = ticks(ylo, yhi, a.tickCount(a.YTicks, h, 3))
+		}
+	}
+	// The dot of each tick along the plot
+	xDots := make([]int, len(xTicks))
+	for i, t := range xTicks {
+		xDots[i] = scale(t, xlo, xhi, w*2)
+	}
+	yDots := make([]int, len(yTicks))
+	for i, t := range yTicks {
+		yDots[i] = h*4 - 1 - scale(t, ylo, yhi, h*4)
+	}
+
+	var background []layer
+	if a.Grid {
+		canvas := dots.NewCanvas(w*2, h*4)
+		for _, y := range yDots {
+			for x := 0; x < w*2; x += 2 {
+				canvas.Set(x, y)
+			}
+		}
+		for _, x := range xDots {
+			for y := 0; y < h*4; y += 2 {
+				canvas.Set(x, y)
+			}
+		}
+		background = append(background, layer{canvas: canvas, color: gridColor, background: true})
+	}
+	area := plot(w, h, background)
+
+	grid := make(dots.Grid, height)
+	for row := range grid {
+		grid[row] = make([]dots.Cell, width)
+		for col := range grid[row] {
+			grid[row][col] = dots.Cell{Rune: ' '}
+		}
+	}
+	for row := range area {
+		copy(grid[top+row][left:], area[row])
+	}
+
+	if a.Legend {
+		col := left
+		for _, e := range legend {
+			col = writeText(grid[0], col, "⣿", e.color)
+			col = writeText(grid[0], col+1, e.name, color.RGBA{}) + 2
+		}
+	}
+
+	if a.Show {
+		// The y axis, with labels right-aligned against it
+		for row := range h {
+			grid[top+row][left-1].Rune = '│'
+		}
+		for i := len(yDots) - 1; i >= 0; i-- {
+			row := top + yDots[i]/4
+			if grid[row][left-1].Rune == '┤' {
+				// A higher tick already labels this row
+				continue
+			}
+			grid[row][left-1].Rune = '┤'
+			label := []rune(yLabels[i])
+			writeText(grid[row], left-1-len(label), yLabels[i], color.RGBA{})
+		}
+
+		// The x axis, with labels centered under the ticks where they don't overlap
+		axis := grid[top+h]
+		axis[left-1].Rune = '└'
+		for col := left; col < width; col++ {
+			axis[col].Rune = '─'
+		}
+		xLabels := a.labels(xTicks, xStep)
+		free := 0 // First column of the label row not yet written
+		for i, x := range xDots {
+			col := left + x/2
+			axis[col].Rune = '┬'
+			label := xLabels[i]
+			start := min(max(col-len([]rune(label))/2, 0), width-len([]rune(label)))
+			if start >= free {
+				free = writeText(grid[top+h+1], start, label, color.RGBA{}) + 1
+			}
+		}
+	}
+	return grid
+}
+
+// tickCount returns the number of ticks wanted on an axis n characters long, with
+// at least spacing characters between them, unless it's set explicitly.
+func (a Axes) tickCount(set, n, spacing int) int {
+	if set > 0 {
+		return set
+	}
+	return max(n/spacing, 2)
+}
+
+// labels formats tick values spaced step apart.
+func (a Axes) labels(ticks []float64, step float64) []string {
+	labels := make([]string, len(ticks))
+	decimals := max(0, -int(math.Floor(math.Log10(step)+1e-9)))
+	for i, t := range ticks {
+		if a.Format != nil {
+			labels[i] = a.Format(t)
+		} else {
+			labels[i] = strconv.FormatFloat(t, 'f', decimals, 64)
+		}
+	}
+	return labels
+}
+
+// ticks returns round numbers from lo to hi, at least n of them if the range allows,
+// and the step between them: the largest of 1, 2, or 5 times a power of ten that
+// gives enough.
+func ticks(lo, hi float64, n int) ([]float64, float64) {
+	if hi <= lo || n < 2 {
+		return []float64{lo}, 1
+	}
+	mag := math.Pow(10, math.Ceil(math.Log10(hi-lo)))
+	var out []float64
+	var step float64
+	for i := 0; len(out) < n && i < 12; i++ {
+		step = mag * []float64{1, 0.5, 0.2}[i%3] / math.Pow(10, float64(i/3))
+		out = out[:0]
+		for k := math.Ceil(lo/step - 1e-9); k*step <= hi+step*1e-9; k++ {
+			// Adding 0 turns -0 into 0, so it isn't labeled "-0"
+			out = append(out, k*step+0)
+		}
+	}
+	return out, step
+}
+
+// writeText writes s into the cells of a row from col, clipped to the row, with an
+// optional color, and returns the column after it.
+func writeText(row []dots.Cell, col int, s string, fg color.RGBA) int {
+	for _, r := range s {
+		if col >= 0 && col < len(row) {
+			row[col] = dots.Cell{Rune: r, Fg: fg}
+		}
+		col++
+	}
+	return col
+}
@@ -0,0 +1,100 @@
+package plot
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestTicks(t *testing.T) {
+	for _, tt := range []struct {
+		lo, hi float64
+		n      int
+		want   []float64
+		step   float64
+	}{
+		{lo: 0, hi: 4, n: 2, want: []float64{0, 2, 4}, step: 2},
+		{lo: -3, hi: 3, n: 3, want: []float64{-2, 0, 2}, step: 2},
+		{lo: 0, hi: 99, n: 4, want: []float64{0, 20, 40, 60, 80}, step: 20},
+		{lo: 0.5, hi: 1.5, n: 2, want: []float64{0.5, 1, 1.5}, step: 0.5},
+		{lo: 7, hi: 7, n: 3, want: []float64{7}, step: 1},
+	} {
+		t.Run(fmt.Sprintf("%v-%v", tt.lo, tt.hi), func(t *testing.T) {
+			got, step := ticks(tt.lo, tt.hi, tt.n)
+			if !slices.Equal(got, tt.want) || step != tt.step {
+				t.Errorf("ticks() = %v, %v, want %v, %v", got, step, tt.want, tt.step)
+			}
+		})
+	}
+
+	if got, want := (Axes{}).labels([]float64{0, 0.5, 1}, 0.5), []string{"0.0", "0.5", "1.0"}; !slices.Equal(got, want) {
+		t.Errorf("labels() = %v, want %v", got, want)
+	}
+	pct := Axes{Format: func(v float64) string { return fmt.Sprintf("%g%%", v*100) }}
+	if got, want := pct.labels([]float64{0, 0.5}, 0.5), []string{"0%", "50%"}; !slices.Equal(got, want) {
+		t.Errorf("labels() with Format = %v, want %v", got, want)
+	}
+}
+
+func TestAxes(t *testing.T) {
+	c := &LineChart{Width: 12, Height: 5, Axes: Axes{Show: true, Legend: true, XTicks: 2, YTicks: 2}}
+	c.Add("up", []float64{0, 1, 2, 3, 4})
+
+	g := c.Grid()
+	want := "  ⣿ up      \n" +
+		"4┤⠀⠀⠀⠀⢀⣀⠤⠤⠒⠉\n" +
+		"0┤⣀⠤⠒⠊⠁⠀⠀⠀⠀⠀\n" +
+		" └┬────┬───┬\n" +
+		"  0    2   4"
+	if got := text(g); got != want {
+		t.Errorf("Grid() =\n%s\nwant\n%s", got, want)
+	}
+	if got := g[0][2].Fg; got != DefaultColors[0] {
+		t.Errorf("legend color = %v, want %v", got, DefaultColors[0])
+	}
+
+	// Too small for the axes, the chart is all plot
+	c.Width, c.Height = 3, 2
+	if got, want := text(c.Grid()), "⠀⢀⠜\n⡰⠁⠀"; got != want {
+		t.Errorf("Grid() too small for axes = %q, want %q", got, want)
+	}
+}
+
+func TestGridlines(t *testing.T) {
+	c := &LineChart{Width: 4, Height: 1, Axes: Axes{Grid: true, XTicks: 2, YTicks: 2}}
+	c.Add("flat", []float64{0, 0})
+
+	// Gridlines light dots under the data, but cells with data keep its color
+	g := c.Grid()
+	if got, want := text(g), "⡗⡓⡓⡻"; got != want {
+		t.Errorf("Grid() = %q, want %q", got, want)
+	}
+	for col, cell := range g[0] {
+		if cell.Fg != DefaultColors[0] {
+			t.Errorf("cell %d color = %v, want %v", col, cell.Fg, DefaultColors[0])
+		}
+	}
+
+	c.Series = nil
+	if got := c.Grid()[0][0].Fg; got != gridColor {
+		t.Errorf("gridline color = %v, want %v", got, gridColor)
+	}
+}
+
+func TestAxesEqualRange(t *testing.T) {
+	// Equal fixed ends are widened, like the range of a flat series
+	one := 1.0
+	c := &LineChart{Width: 12, Height: 5, Min: &one, Max: &one, Axes: Axes{Show: true, YTicks: 2}}
+	c.Add("up", []float64{0, 1, 2})
+	if lo, hi := c.Range(); lo != 0 || hi != 2 {
+		t.Errorf("Range() = %v, %v, want 0, 2", lo, hi)
+	}
+	want := "2┤⠀⠀⠀⠀⠀⠀⢀⡠⠔⠊\n" +
+		" │⠀⠀⠀⣀⠤⠊⠁⠀⠀⠀\n" +
+		"0┤⡠⠔⠊⠀⠀⠀⠀⠀⠀⠀\n" +
+		" └┬────────┬\n" +
+		"  0        2"
+	if got := text(c.Grid()); got != want {
+		t.Errorf("Grid() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+package plot
+
+// coastlines are rough outlines of the continents and largest islands, as
+// polygons of {longitude, latitude} in degrees, accurate to a few degrees: about
+// the resolution of a world map in a terminal.
+var coastlines = [][][2]float64{
+	// North America
+	{
+		{-168, 66}, {-156, 71}, {-141, 70}, {-125, 70}, {-110, 68}, {-95, 69}, {-94, 60},
+		{-88, 56}, {-82, 52}, {-79, 55}, {-77, 61}, {-70, 59}, {-64, 60}, {-60, 55},
+		{-56, 52}, {-60, 47}, {-66, 44}, {-70, 42}, {-74, 40}, {-76, 35}, {-81, 31},
+		{-80, 26}, {-81, 25}, {-83, 29}, {-89, 30}, {-94, 29}, {-97, 26}, {-97, 21},
+		{-95, 19}, {-91, 19}, {-90, 21}, {-87, 21}, {-88, 16}, {-84, 15}, {-83, 10},
+		{-79, 9}, {-77, 8}, {-80, 7}, {-83, 8}, {-86, 11}, {-92, 14}, {-97, 16},
+		{-105, 20}, {-106, 23}, {-110, 23}, {-115, 30}, {-117, 32}, {-121, 35},
+		{-124, 40}, {-124, 47}, {-127, 51}, {-133, 56}, {-140, 60}, {-150, 61},
+		{-154, 58}, {-162, 55}, {-165, 60},
+	},
+	// Greenland
+	{
+		{-73, 78}, {-60, 82}, {-30, 83}, {-20, 80}, {-18, 75}, {-22, 70}, {-32, 68},
+		{-40, 65}, {-44, 60}, {-50, 62}, {-54, 67}, {-56, 72}, {-66, 76},
+	},
+	// South America
+	{
+		{-77, 8}, {-72, 12}, {-63, 10}, {-60, 8}, {-52, 5}, {-50, 0}, {-44, -2},
+		{-35, -5}, {-35, -9}, {-39, -13}, {-39, -18}, {-41, -22}, {-45, -23}, {-48, -26},
+		{-52, -32}, {-58, -35}, {-57, -38}, {-62, -39}, {-65, -42}, {-65, -47},
+		{-69, -51}, {-68, -55}, {-72, -54}, {-75, -50}, {-74, -44}, {-73, -37},
+		{-71, -30}, {-70, -23}, {-70, -18}, {-76, -14}, {-80, -8}, {-81, -4}, {-80, 0},
+		{-78, 3},
+	},
+	// Eurasia
+	{
+		{-9, 37}, {-9, 43}, {-2, 43}, {-1, 46}, {-4, 48}, {2, 51}, {5, 53}, {8, 54},
+		{8, 57}, {10, 54}, {14, 54}, {21, 55}, {24, 58}, {29, 60}, {23, 60}, {21, 62},
+		{25, 65}, {22, 66}, {18, 63}, {17, 61}, {19, 60}, {16, 57}, {13, 56}, {11, 58},
+		{8, 58}, {5, 60}, {5, 62}, {10, 64}, {14, 67}, {18, 69}, {24, 71}, {30, 70},
+		{40, 68}, {44, 68}, {54, 68}, {60, 70}, {68, 69}, {72, 73}, {80, 73}, {90, 76},
+		{100, 77}, {105, 78}, {112, 74}, {125, 73}, {140, 72}, {150, 71}, {160, 70},
+		{170, 70}, {180, 68}, {180, 65}, {178, 62}, {170, 60}, {163, 60}, {157, 51},
+		{156, 57}, {150, 59}, {142, 59}, {137, 54}, {141, 52}, {140, 48}, {135, 43},
+		{130, 42}, {129, 35}, {126, 35}, {126, 38}, {122, 40}, {121, 39}, {122, 37},
+		{119, 35}, {121, 32}, {122, 30}, {120, 26}, {117, 23}, {111, 21}, {108, 21},
+		{106, 18}, {109, 12}, {107, 10}, {105, 9}, {100, 13}, {99, 10}, {100, 6},
+		{103, 1}, {98, 8}, {98, 16}, {94, 17}, {92, 21}, {89, 22}, {87, 21}, {85, 19},
+		{80, 15}, {80, 10}, {77, 8}, {76, 10}, {73, 16}, {73, 21}, {69, 22}, {67, 25},
+		{62, 25}, {57, 26}, {56, 27}, {52, 27}, {50, 30}, {48, 29}, {50, 26}, {51, 24},
+		{56, 24}, {59, 22}, {57, 19}, {52, 16}, {45, 13}, {43, 13}, {39, 20}, {35, 28},
+		{34, 31}, {35, 33}, {36, 36}, {30, 36}, {27, 37}, {26, 40}, {29, 41}, {35, 42},
+		{41, 41}, {38, 44}, {34, 44}, {30, 46}, {29, 45}, {28, 42}, {26, 41}, {23, 40},
+		{24, 38}, {22, 37}, {21, 39}, {19, 42}, {15, 45}, {14, 45}, {12, 44}, {14, 42},
+		{18, 40}, {16, 38}, {16, 40}, {12, 42}, {10, 44}, {8, 44}, {6, 43}, {3, 43},
+		{3, 42}, {0, 39}, {-1, 37}, {-5, 36},
+	},
+	// Africa
+	{
+		{-6, 36}, {-2, 35}, {3, 37}, {10, 37}, {11, 34}, {20, 31}, {25, 32}, {32, 31},
+		{34, 28}, {38, 22}, {43, 13}, {51, 12}, {51, 10}, {47, 5}, {41, -2}, {40, -10},
+		{41, -15}, {35, -24}, {33, -28}, {27, -34}, {20, -35}, {18, -32}, {15, -27},
+		{12, -17}, {14, -11}, {12, -5}, {9, -1}, {9, 4}, {5, 6}, {-2, 5}, {-8, 4},
+		{-13, 8}, {-17, 14}, {-16, 20}, {-13, 27}, {-9, 32},
+	},
+	// Australia
+	{
+		{114, -22}, {114, -26}, {115, -34}, {118, -35}, {124, -33}, {129, -31},
+		{134, -32}, {138, -35}, {141, -38}, {146, -39}, {150, -37}, {153, -32},
+		{153, -25}, {150, -22}, {146, -19}, {145, -15}, {142, -11}, {141, -17},
+		{136, -15}, {137, -12}, {132, -11}, {129, -15}, {126, -14}, {122, -18},
+	},
+	// Great Britain
+	{
+		{-5, 50}, {1, 51}, {2, 53}, {0, 54}, {-2, 56}, {-2, 58}, {-5, 59}, {-6, 57},
+		{-5, 55}, {-3, 55}, {-3, 54}, {-5, 53}, {-5, 52}, {-3, 51},
+	},
+	// Ireland
+	{{-6, 52}, {-6, 54}, {-8, 55}, {-10, 54}, {-10, 52}},
+	// Iceland
+	{{-24, 66}, {-22, 66}, {-15, 67}, {-14, 65}, {-18, 63}, {-22, 64}},
+	// Japan
+	{
+		{130, 31}, {130, 34}, {133, 35}, {136, 36}, {140, 38}, {140, 41}, {142, 45},
+		{145, 44}, {142, 40}, {141, 36}, {139, 35}, {135, 33}, {131, 31},
+	},
+	// Sumatra
+	{{95, 5}, {98, 3}, {104, -2}, {106, -6}, {104, -6}, {100, -1}, {97, 2}},
+	// Borneo
+	{{109, 1}, {111, -3}, {116, -4}, {119, 1}, {117, 7}, {113, 4}},
+	// New Guinea
+	{{131, -1}, {138, -1}, {147, -6}, {150, -10}, {141, -9}, {138, -8}, {133, -4}},
+	// Madagascar
+	{{49, -12}, {50, -16}, {47, -25}, {44, -24}, {44, -17}},
+	// New Zealand
+	{{172, -34}, {178, -37}, {177, -39}, {175, -41}, {172, -41}, {167, -46}, {169, -47}, {174, -41}, {174, -39}},
+}
@@ -0,0 +1,102 @@
+package plot
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/imjasonh/dots"
+)
+
+// Location is a point on the Earth, in degrees of latitude north and longitude east.
+type Location struct{ Lat, Lon float64 }
+
+// LocationSeries is a named set of locations.
+type LocationSeries struct {
+	Name      string
+	Locations []Location
+	Color     color.Color // nil picks the next of DefaultColors
+}
+
+// Projection maps locations on the globe onto a flat map.
+type Projection int
+
+const (
+	// Equirectangular spaces latitude and longitude evenly, so the whole world
+	// fits on a map twice as wide as it is tall.
+	Equirectangular Projection = iota
+	// Mercator keeps shapes true but stretches latitudes towards the poles; the map
+	// is cut off beyond 85° north and south.
+	Mercator
+)
+
+// mercatorLimit is the latitude, in radians, beyond which the Mercator projection
+// is cut off, making its map square.
+var mercatorLimit = 2*math.Atan(math.Exp(math.Pi)) - math.Pi/2
+
+// project returns the position of loc on a map of the world w×h dots, which may be
+// outside it.
+func (p Projection) project(loc Location, w, h int) (float64, float64) {
+	x := (loc.Lon + 180) / 360 * float64(w)
+	y := (90 - loc.Lat) / 180 * float64(h)
+	if p == Mercator {
+		lat := max(min(loc.Lat*math.Pi/180, mercatorLimit), -mercatorLimit)
+		y = (math.Pi - math.Log(math.Tan(math.Pi/4+lat/2))) / (2 * math.Pi) * float64(h)
+	}
+	return x, y
+}
+
+// Map plots locations on a map of the world, e.g. of request origins or a fleet of
+// vehicles, each as a dot.
+type Map struct {
+	Width, Height int // Size in characters; a world map is about four times as wide as it is tall
+	Series        []LocationSeries
+	Projection    Projection
+	// Coastline draws a rough outline of the continents in gray under the locations.
+	Coastline bool
+}
+
+// Add appends a series of locations to the map.
+func (m *Map) Add(name string, locations []Location) {
+	m.Series = append(m.Series, LocationSeries{Name: name, Locations: locations})
+}
+
+// Grid draws the map as a grid of braille cells.
+func (m *Map) Grid() dots.Grid {
+	w, h := m.Width*2, m.Height*4
+	at := func(loc Location) image.Point {
+		x, y := m.Projection.project(loc, w, h)
+		return image.Pt(int(math.Floor(x)), int(math.Floor(y)))
+	}
+
+	var layers []layer
+	if m.Coastline {
+		canvas := dots.NewCanvas(w, h)
+		for _, outline := range coastlines {
+			pts := make([]image.Point, len(outline))
+			for i, ll := range outline {
+				pts[i] = at(Location{Lat: ll[1], Lon: ll[0]})
+			}
+			canvas.Polygon(pts...)
+		}
+		layers = append(layers, layer{canvas: canvas, color: gridColor, background: true})
+	}
+	for i, s := range m.Series {
+		canvas := dots.NewCanvas(w, h)
+		for _, loc := range s.Locations {
+			if math.IsNaN(loc.Lat) || math.IsNaN(loc.Lon) {
+				continue
+			}
+			p := at(loc)
+			// The antimeridian and poles are on the map's far edges
+			canvas.Set(min(p.X, w-1), min(p.Y, h-1))
+		}
+		layers = append(layers, layer{canvas: canvas, color: seriesColor(s.Color, i)})
+	}
+	return compose(m.Width, m.Height, layers)
+}
+
+// String draws the map as lines of braille characters with ANSI colors.
+func (m *Map) String() string {
+	return render(m.Grid())
+}
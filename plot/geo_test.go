@@ -0,0 +1,62 @@
+package plot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProject(t *testing.T) {
+	for _, tt := range []struct {
+		desc       string
+		projection Projection
+		loc        Location
+		x, y       float64
+	}{
+		{"equirectangular origin", Equirectangular, Location{0, 0}, 180, 90},
+		{"equirectangular corner", Equirectangular, Location{90, -180}, 0, 0},
+		{"equirectangular south", Equirectangular, Location{-45, 90}, 270, 135},
+		{"mercator origin", Mercator, Location{0, 0}, 180, 180},
+		{"mercator cut off", Mercator, Location{89, 0}, 180, 0},
+		{"mercator stretches", Mercator, Location{60, 0}, 180, 180 - math.Log(math.Tan(math.Pi/4+math.Pi/6))/(2*math.Pi)*360},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			h := 180
+			if tt.projection == Mercator {
+				h = 360
+			}
+			x, y := tt.projection.project(tt.loc, 360, h)
+			if math.Abs(x-tt.x) > 1e-9 || math.Abs(y-tt.y) > 1e-9 {
+				t.Errorf("project() = (%v, %v), want (%v, %v)", x, y, tt.x, tt.y)
+			}
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	m := &Map{Width: 2, Height: 1}
+	m.Add("origin", []Location{{0, 0}, {math.NaN(), 0}})
+	m.Add("edges", []Location{{-90, 180}})
+	g := m.Grid()
+	if got, want := text(g), "⠀⢄"; got != want {
+		t.Errorf("Grid() = %q, want %q", got, want)
+	}
+	if got, want := g[0][1].Fg, DefaultColors[1]; got != want {
+		t.Errorf("cell color = %v, want %v", got, want)
+	}
+
+	m = &Map{Width: 80, Height: 20, Coastline: true}
+	lit := 0
+	for _, row := range m.Grid() {
+		for _, c := range row {
+			if c.Pattern != 0 {
+				lit++
+				if c.Fg != gridColor {
+					t.Fatalf("coastline color = %v, want %v", c.Fg, gridColor)
+				}
+			}
+		}
+	}
+	if lit < 100 {
+		t.Errorf("coastline lit %d cells, want an outline of the continents", lit)
+	}
+}
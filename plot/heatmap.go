@@ -0,0 +1,127 @@
+package plot
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/imjasonh/dots"
+)
+
+// DefaultGradient is the color scale of heatmaps without one of their own, from
+// low to high values (an approximation of viridis).
+var DefaultGradient = []color.RGBA{
+	{0x44, 0x01, 0x54, 255},
+	{0x3b, 0x52, 0x8b, 255},
+	{0x21, 0x91, 0x8c, 255},
+	{0x5e, 0xc9, 0x62, 255},
+	{0xfd, 0xe7, 0x25, 255},
+}
+
+// cellOrder ranks the dots of a braille cell, indexed [y][x], in the order they're
+// lit as a heatmap value rises, spreading them evenly like an ordered dither.
+var cellOrder = [4][2]int{
+	{0, 4},
+	{6, 2},
+	{1, 5},
+	{7, 3},
+}
+
+// Heatmap plots a matrix of values as shaded cells, for correlation matrices,
+// spectrograms, and the like. Each value shows both as the share of lit dots in
+// its area and as a color along a gradient.
+type Heatmap struct {
+	Width, Height int         // Size in characters
+	Values        [][]float64 // Rows of values, from the top; NaN values are blank
+	// Min and Max fix the range of the values; nil scales it to fit them. Values
+	// outside a fixed range are clamped.
+	Min, Max *float64
+	// Gradient is the color scale from low to high values; nil is DefaultGradient.
+	Gradient []color.RGBA
+}
+
+// Range returns the range of the values.
+func (m *Heatmap) Range() (lo, hi float64) {
+	lo, hi = bounds(m.Values...)
+	return fix(lo, hi, m.Min, m.Max)
+}
+
+// Grid draws the heatmap as a grid of braille cells. The matrix is stretched over the
+// whole grid; each dot shows the value nearest to it, and each cell is colored by the
+// average of its dots' values.
+func (m *Heatmap) Grid() dots.Grid {
+	lo, hi := m.Range()
+	gradient := m.Gradient
+	if len(gradient) == 0 {
+		gradient = DefaultGradient
+	}
+	w, h := m.Width*2, m.Height*4
+
+	grid := make(dots.Grid, m.Height)
+	for row := range grid {
+		grid[row] = make([]dots.Cell, m.Width)
+		for col := range grid[row] {
+			var pattern uint8
+			sum, n := 0.0, 0
+			for dy := range 4 {
+				for dx := range 2 {
+					v := m.at(col*2+dx, row*4+dy, w, h)
+					if math.IsNaN(v) {
+						continue
+					}
+					t := min(max((v-lo)/(hi-lo), 0), 1)
+					sum += t
+					n++
+					if t*8 > float64(cellOrder[dy][dx]) {
+						pattern |= dotBit(dx, dy)
+					}
+				}
+			}
+			cell := dots.Cell{Rune: rune(0x2800 + int(pattern)), Pattern: pattern}
+			if pattern != 0 {
+				cell.Fg = gradientColor(gradient, sum/float64(n))
+			}
+			grid[row][col] = cell
+		}
+	}
+	return grid
+}
+
+// String draws the heatmap as lines of braille characters with ANSI colors.
+func (m *Heatmap) String() string {
+	return render(m.Grid())
+}
+
+// at returns the value nearest the dot (x, y) of a w×h dot matrix stretched over the
+// values, or NaN if there is none.
+func (m *Heatmap) at(x, y, w, h int) float64 {
+	if len(m.Values) == 0 {
+		return math.NaN()
+	}
+	values := m.Values[y*len(m.Values)/h]
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return values[x*len(values)/w]
+}
+
+// dotBit returns the bit of the braille pattern for the dot at (x, y) in a cell.
+func dotBit(x, y int) uint8 {
+	if y == 3 {
+		return 1 << (6 + x)
+	}
+	return 1 << (y + 3*x)
+}
+
+// gradientColor returns the color at t, from 0 to 1, along the gradient.
+func gradientColor(gradient []color.RGBA, t float64) color.RGBA {
+	if len(gradient) == 1 {
+		return gradient[0]
+	}
+	pos := t * float64(len(gradient)-1)
+	i := min(int(pos), len(gradient)-2)
+	a, b, f := gradient[i], gradient[i+1], pos-float64(i)
+	lerp := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*f))
+	}
+	return color.RGBA{lerp(a.R, b.R), lerp(a.G, b.G), lerp(a.B, b.B), 255}
+}
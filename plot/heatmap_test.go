@@ -0,0 +1,49 @@
+package plot
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestHeatmap(t *testing.T) {
+	m := &Heatmap{
+		Width: 4, Height: 1,
+		Values: [][]float64{{0, 0.25, 0.5, 1}},
+	}
+	g := m.Grid()
+	// Each value lights its share of the 8 dots of its cell
+	if got, want := text(g), "⠀⠅⢕⣿"; got != want {
+		t.Errorf("Grid() = %q, want %q", got, want)
+	}
+	if got, want := g[0][3].Fg, DefaultGradient[len(DefaultGradient)-1]; got != want {
+		t.Errorf("highest cell color = %v, want %v", got, want)
+	}
+	if got, want := g[0][2].Fg, DefaultGradient[2]; got != want {
+		t.Errorf("middle cell color = %v, want %v", got, want)
+	}
+	if got := g[0][0].Fg; got.A != 0 {
+		t.Errorf("empty cell color = %v, want none", got)
+	}
+}
+
+func TestHeatmapStretch(t *testing.T) {
+	black, white := color.RGBA{0, 0, 0, 255}, color.RGBA{255, 255, 255, 255}
+	lo, hi := 0.0, 2.0
+	m := &Heatmap{
+		Width: 2, Height: 1,
+		Values:   [][]float64{{4, math.NaN()}, {1, 1}},
+		Min:      &lo,
+		Max:      &hi,
+		Gradient: []color.RGBA{black, white},
+	}
+	// The top left is clamped to full, the top right is blank, and the bottom is half full
+	g := m.Grid()
+	if got, want := text(g), "⢟⢄"; got != want {
+		t.Errorf("Grid() = %q, want %q", got, want)
+	}
+	// The left cell averages 1 and 0.5
+	if got, want := g[0][0].Fg, (color.RGBA{191, 191, 191, 255}); got != want {
+		t.Errorf("left cell color = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package plot
+
+import (
+	"image/color"
+
+	"github.com/imjasonh/dots"
+)
+
+// Series is a named sequence of values, one per step along the x axis.
+type Series struct {
+	Name   string
+	Values []float64   // NaN and infinite values leave gaps
+	Color  color.Color // nil picks the next of DefaultColors
+}
+
+// LineChart plots one or more series as lines across the same axes.
+type LineChart struct {
+	Width, Height int // Size in characters
+	Series        []Series
+	// Min and Max fix the range of the y axis; nil scales it to fit the values. If
+	// they are equal, the range is widened by 1 either way.
+	Min, Max *float64
+	// Axes are drawn around the plot, with the x axis counting values from 0.
+	Axes Axes
+}
+
+// Add appends a series of values to the chart.
+func (c *LineChart) Add(name string, values []float64) {
+	c.Series = append(c.Series, Series{Name: name, Values: values})
+}
+
+// Range returns the range of the y axis.
+func (c *LineChart) Range() (lo, hi float64) {
+	all := make([][]float64, len(c.Series))
+	for i, s := range c.Series {
+		all[i] = s.Values
+	}
+	lo, hi = bounds(all...)
+	return fix(lo, hi, c.Min, c.Max)
+}
+
+// Grid draws the chart as a grid of braille cells, inside its axes. The first value
+// of each series is at the left edge and the last value of the longest series at the
+// right edge. Values outside a fixed range are drawn at its edge.
+func (c *LineChart) Grid() dots.Grid {
+	lo, hi := c.Range()
+	steps := 0
+	for _, s := range c.Series {
+		steps = max(steps, len(s.Values)-1)
+	}
+	legend := make([]legendEntry, len(c.Series))
+	for i, s := range c.Series {
+		legend[i] = legendEntry{s.Name, seriesColor(s.Color, i)}
+	}
+
+	return c.Axes.draw(c.Width, c.Height, 0, float64(max(steps, 1)), lo, hi, legend, func(width, height int, background []layer) dots.Grid {
+		w, h := width*2, height*4
+		layers := background
+		for i, s := range c.Series {
+			canvas := dots.NewCanvas(w, h)
+			px, py := -1, 0
+			for j, v := range s.Values {
+				if !finite(v) {
+					px = -1
+					continue
+				}
+				x, y := 0, h-1-scale(v, lo, hi, h)
+				if steps > 0 {
+					x = scale(float64(j), 0, float64(steps), w)
+				}
+				if px < 0 {
+					canvas.Set(x, y)
+				} else {
+					canvas.Line(px, py, x, y)
+				}
+				px, py = x, y
+			}
+			layers = append(layers, layer{canvas: canvas, color: legend[i].color})
+		}
+		return compose(width, height, layers)
+	})
+}
+
+// String draws the chart as lines of braille characters with ANSI colors.
+func (c *LineChart) String() string {
+	return render(c.Grid())
+}
@@ -0,0 +1,84 @@
+package plot
+
+import (
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/imjasonh/dots"
+)
+
+// text returns the grid's braille characters, without colors.
+func text(g dots.Grid) string {
+	return strings.Join(g.Render(dots.Options{NoColor: true}), "\n")
+}
+
+func TestLineChart(t *testing.T) {
+	c := &LineChart{Width: 8, Height: 2}
+	c.Add("up", []float64{0, 1, 2, 3, 4, 5, 6, 7})
+	c.Add("down", []float64{7, 6, 5, 4, 3, 2, 1, 0})
+
+	g := c.Grid()
+	if got, want := text(g), "⠑⠢⢄⣀⣀⠤⠒⠉\n⡠⠔⠊⠉⠉⠒⠤⣀"; got != want {
+		t.Errorf("Grid() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Each cell takes the color of its series
+	up, down := DefaultColors[0], DefaultColors[1]
+	for _, tt := range []struct {
+		row, col int
+		want     color.RGBA
+	}{{0, 0, down}, {0, 7, up}, {1, 0, up}, {1, 7, down}} {
+		if got := g[tt.row][tt.col].Fg; got != tt.want {
+			t.Errorf("cell (%d, %d) color = %v, want %v", tt.col, tt.row, got, tt.want)
+		}
+	}
+
+	if lo, hi := c.Range(); lo != 0 || hi != 7 {
+		t.Errorf("Range() = %v, %v, want 0, 7", lo, hi)
+	}
+}
+
+func TestLineChartRange(t *testing.T) {
+	lo, hi := 0.0, 10.0
+	c := &LineChart{Width: 4, Height: 1, Min: &lo, Max: &hi, Series: []Series{{
+		Values: []float64{10, 10, math.NaN(), 0, 0},
+		Color:  color.White,
+	}}}
+
+	// The gap splits the line at the top from the line at the bottom
+	if got, want := text(c.Grid()), "⠉⠁⢀⣀"; got != want {
+		t.Errorf("Grid() = %q, want %q", got, want)
+	}
+	if got, want := c.Grid()[0][0].Fg, (color.RGBA{255, 255, 255, 255}); got != want {
+		t.Errorf("series color = %v, want %v", got, want)
+	}
+
+	// A flat series sits in the middle of its auto-scaled range
+	flat := &LineChart{Width: 2, Height: 1}
+	flat.Add("flat", []float64{3, 3, 3, 3})
+	if got, want := text(flat.Grid()), "⠒⠒"; got != want {
+		t.Errorf("flat Grid() = %q, want %q", got, want)
+	}
+}
+
+func TestLineChartInfinite(t *testing.T) {
+	// Infinite values leave gaps, like NaN, and don't stretch the range
+	c := &LineChart{Width: 4, Height: 1}
+	c.Add("inf", []float64{10, 10, math.Inf(1), 0, math.Inf(-1)})
+	if lo, hi := c.Range(); lo != 0 || hi != 10 {
+		t.Errorf("Range() = %v, %v, want 0, 10", lo, hi)
+	}
+	if got, want := text(c.Grid()), "⠉⠁⢀⠀"; got != want {
+		t.Errorf("Grid() = %q, want %q", got, want)
+	}
+
+	// Values outside a fixed range are drawn at its edge
+	lo, hi := 0.0, 1.0
+	clamped := &LineChart{Width: 2, Height: 1, Min: &lo, Max: &hi}
+	clamped.Add("big", []float64{1e300, 1e300})
+	if got, want := text(clamped.Grid()), "⠉⠉"; got != want {
+		t.Errorf("clamped Grid() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,130 @@
+// Package plot draws charts of data onto braille canvases, with 2×4 dots per
+// character cell, for monitoring tools and other terminal programs.
+package plot
+
+import (
+	"image/color"
+	"math"
+	"math/bits"
+	"strings"
+
+	"github.com/imjasonh/dots"
+)
+
+// DefaultColors are the colors given in turn to series without a color of their own.
+// They are exact ANSI 256 colors, so they render unchanged.
+var DefaultColors = []color.RGBA{
+	{0x5f, 0xaf, 0xff, 255}, // Blue
+	{0xff, 0x87, 0x5f, 255}, // Orange
+	{0x87, 0xd7, 0x5f, 255}, // Green
+	{0xd7, 0x87, 0xd7, 255}, // Magenta
+	{0xff, 0xd7, 0x5f, 255}, // Yellow
+	{0x5f, 0xd7, 0xd7, 255}, // Cyan
+}
+
+// seriesColor returns c as RGBA, or the i'th default color if c is nil.
+func seriesColor(c color.Color, i int) color.RGBA {
+	if c == nil {
+		return DefaultColors[i%len(DefaultColors)]
+	}
+	return color.RGBAModel.Convert(c).(color.RGBA)
+}
+
+// layer is the dots of one series, drawn in one color.
+type layer struct {
+	canvas     *dots.Canvas
+	color      color.RGBA
+	background bool // Whether the layer only colors cells no other layer has dots in
+}
+
+// compose packs the layers into one grid of braille cells. Each cell lights the dots
+// of every layer and takes the color of the layer with the most dots in it; ties go
+// to the later layer, which is drawn on top. Background layers only color cells
+// without dots from other layers.
+func compose(width, height int, layers []layer) dots.Grid {
+	grids := make([]dots.Grid, len(layers))
+	for i, l := range layers {
+		grids[i] = l.canvas.Grid()
+	}
+
+	grid := make(dots.Grid, height)
+	for row := range grid {
+		grid[row] = make([]dots.Cell, width)
+		for col := range grid[row] {
+			var pattern uint8
+			var fg, bg color.RGBA
+			most := 0
+			for i, g := range grids {
+				p := g[row][col].Pattern
+				pattern |= p
+				if p != 0 && layers[i].background {
+					bg = layers[i].color
+				} else if n := bits.OnesCount8(p); n > 0 && n >= most {
+					most, fg = n, layers[i].color
+				}
+			}
+			if most == 0 {
+				fg = bg
+			}
+			char := rune(0x2800 + int(pattern))
+			grid[row][col] = dots.Cell{Rune: char, Fg: fg, Pattern: pattern}
+		}
+	}
+	return grid
+}
+
+// render returns the grid as lines of text with ANSI colors.
+func render(grid dots.Grid) string {
+	return strings.Join(grid.Render(dots.Options{}), "\n")
+}
+
+// finite reports whether v can be plotted: NaN and infinite values leave gaps.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// bounds returns the minimum and maximum of the finite values of every slice,
+// widened by 1 either way if they're equal so they span a range. With no values, it
+// returns 0 and 1.
+func bounds(values ...[]float64) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, vs := range values {
+		for _, v := range vs {
+			if finite(v) {
+				lo, hi = min(lo, v), max(hi, v)
+			}
+		}
+	}
+	switch {
+	case lo > hi:
+		return 0, 1
+	case lo == hi:
+		return lo - 1, hi + 1
+	}
+	return lo, hi
+}
+
+// fix overrides lo and hi with the fixed ends of a range, if they're set, widening
+// the range by 1 either way if they end up equal.
+func fix(lo, hi float64, fixedLo, fixedHi *float64) (float64, float64) {
+	if fixedLo != nil {
+		lo = *fixedLo
+	}
+	if fixedHi != nil {
+		hi = *fixedHi
+	}
+	if lo == hi {
+		return lo - 1, hi + 1
+	}
+	return lo, hi
+}
+
+// scale maps v from the range [lo, hi] onto the dots 0 to n-1, clamping values
+// outside the range to its ends.
+func scale(v, lo, hi float64, n int) int {
+	d := math.Round((v - lo) / (hi - lo) * float64(n-1))
+	if math.IsNaN(d) {
+		return 0
+	}
+	return int(min(max(d, 0), float64(n-1)))
+}
@@ -0,0 +1,120 @@
+package plot
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/imjasonh/dots"
+)
+
+// Point is a point of data.
+type Point struct{ X, Y float64 }
+
+// PointSeries is a named set of points.
+type PointSeries struct {
+	Name   string
+	Points []Point
+	Color  color.Color // nil picks the next of DefaultColors
+}
+
+// ScatterPlot plots one or more sets of points as individual dots.
+type ScatterPlot struct {
+	Width, Height int // Size in characters
+	Series        []PointSeries
+	// XMin, XMax, YMin, and YMax fix the ranges of the axes; nil scales them to fit
+	// the points. Points outside a fixed range are dropped.
+	XMin, XMax, YMin, YMax *float64
+	// Axes are drawn around the plot.
+	Axes Axes
+	// Density shades each cell by how many points fall in it, from dim for a single
+	// point to full brightness for the most crowded cell, so clusters of overlapping
+	// points stand out from stray ones.
+	Density bool
+}
+
+// Add appends a series of points to the plot.
+func (p *ScatterPlot) Add(name string, points []Point) {
+	p.Series = append(p.Series, PointSeries{Name: name, Points: points})
+}
+
+// Range returns the ranges of the x and y axes.
+func (p *ScatterPlot) Range() (xlo, xhi, ylo, yhi float64) {
+	var xs, ys []float64
+	for _, s := range p.Series {
+		for _, pt := range s.Points {
+			xs, ys = append(xs, pt.X), append(ys, pt.Y)
+		}
+	}
+	xlo, xhi = bounds(xs)
+	ylo, yhi = bounds(ys)
+	xlo, xhi = fix(xlo, xhi, p.XMin, p.XMax)
+	ylo, yhi = fix(ylo, yhi, p.YMin, p.YMax)
+	return xlo, xhi, ylo, yhi
+}
+
+// Grid draws the plot as a grid of braille cells, inside its axes.
+func (p *ScatterPlot) Grid() dots.Grid {
+	xlo, xhi, ylo, yhi := p.Range()
+	legend := make([]legendEntry, len(p.Series))
+	for i, s := range p.Series {
+		legend[i] = legendEntry{s.Name, seriesColor(s.Color, i)}
+	}
+
+	return p.Axes.draw(p.Width, p.Height, xlo, xhi, ylo, yhi, legend, func(width, height int, background []layer) dots.Grid {
+		w, h := width*2, height*4
+		counts := make([][]int, height)
+		for row := range counts {
+			counts[row] = make([]int, width)
+		}
+		layers := background
+		for i, s := range p.Series {
+			canvas := dots.NewCanvas(w, h)
+			for _, pt := range s.Points {
+				if !finite(pt.X) || !finite(pt.Y) || !inRange(pt.X, xlo, xhi) || !inRange(pt.Y, ylo, yhi) {
+					continue
+				}
+				x, y := scale(pt.X, xlo, xhi, w), h-1-scale(pt.Y, ylo, yhi, h)
+				canvas.Set(x, y)
+				counts[y/4][x/2]++
+			}
+			layers = append(layers, layer{canvas: canvas, color: legend[i].color})
+		}
+
+		grid := compose(width, height, layers)
+		if p.Density {
+			most := 0
+			for _, row := range counts {
+				for _, n := range row {
+					most = max(most, n)
+				}
+			}
+			for row, cells := range grid {
+				for col := range cells {
+					if n := counts[row][col]; n > 0 {
+						cells[col].Fg = shade(cells[col].Fg, float64(n)/float64(most))
+					}
+				}
+			}
+		}
+		return grid
+	})
+}
+
+// String draws the plot as lines of braille characters with ANSI colors.
+func (p *ScatterPlot) String() string {
+	return render(p.Grid())
+}
+
+// inRange reports whether v is between lo and hi, inclusive.
+func inRange(v, lo, hi float64) bool {
+	return v >= min(lo, hi) && v <= max(lo, hi)
+}
+
+// shade dims c for a density from 0 to 1, keeping a third of its brightness at the
+// lowest density. The square root keeps sparse cells from fading out entirely next
+// to one very dense cell.
+func shade(c color.RGBA, density float64) color.RGBA {
+	f := 1.0 / 3
+	f += (1 - f) * math.Sqrt(density)
+	return color.RGBA{uint8(float64(c.R) * f), uint8(float64(c.G) * f), uint8(float64(c.B) * f), c.A}
+}
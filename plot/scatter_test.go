@@ -0,0 +1,52 @@
+package plot
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestScatterPlot(t *testing.T) {
+	p := &ScatterPlot{Width: 2, Height: 1}
+	p.Add("corners", []Point{{0, 0}, {3, 3}})
+	p.Add("middle", []Point{{1, 1}, {1, 2}})
+
+	g := p.Grid()
+	if got, want := text(g), "⡰⠈"; got != want {
+		t.Errorf("Grid() = %q, want %q", got, want)
+	}
+	// The middle series has more dots in the first cell
+	if got, want := g[0][0].Fg, DefaultColors[1]; got != want {
+		t.Errorf("cell color = %v, want %v", got, want)
+	}
+
+	// Points outside a fixed range are dropped
+	hi := 2.0
+	p.XMax = &hi
+	if got, want := text(p.Grid()), "⡀⠆"; got != want {
+		t.Errorf("Grid() with XMax = %q, want %q", got, want)
+	}
+
+	// Points with infinite coordinates are skipped
+	p.XMax = nil
+	p.Add("infinite", []Point{{math.Inf(1), 1}, {1, math.Inf(-1)}})
+	if got, want := text(p.Grid()), "⡰⠈"; got != want {
+		t.Errorf("Grid() with infinite points = %q, want %q", got, want)
+	}
+}
+
+func TestScatterPlotDensity(t *testing.T) {
+	white := color.RGBA{255, 255, 255, 255}
+	p := &ScatterPlot{Width: 2, Height: 1, Density: true, Series: []PointSeries{{
+		Points: []Point{{0, 0}, {0, 0}, {0, 0}, {0, 0}, {3, 3}},
+		Color:  white,
+	}}}
+	g := p.Grid()
+	if got := g[0][0].Fg; got != white {
+		t.Errorf("crowded cell color = %v, want %v", got, white)
+	}
+	// A quarter of the density keeps a third plus two thirds of half the brightness
+	if got, want := g[0][1].Fg, (color.RGBA{170, 170, 170, 255}); got != want {
+		t.Errorf("sparse cell color = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package dots
+
+import (
+	"image"
+	"io"
+	"strings"
+)
+
+// RenderProgressive converts an image and writes it to w, a terminal, in passes of
+// increasing detail, so something useful appears quickly over high-latency links.
+// The first pass draws only every step-th cell of every step-th row; each later pass
+// halves the step and fills in the cells drawn so far left blank, moving the cursor
+// over those already on screen. A step of 1 or less writes the image in one pass.
+func RenderProgressive(w io.Writer, img image.Image, opts Options, step int) error {
+	grid := ConvertGrid(img, opts)
+	if !canDiff(grid, grid, opts) {
+		// Cells can't be redrawn individually, e.g. in hyperlinked lines
+		step = 1
+	}
+	step = max(step, 1)
+
+	pass := grid.sample(step)
+	var sb strings.Builder
+	lines := pass.Render(opts)
+	for _, line := range lines {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return err
+	}
+	for step > 1 {
+		step /= 2
+		next := grid.sample(step)
+		if _, err := io.WriteString(w, next.renderDiff(pass, len(lines), opts)); err != nil {
+			return err
+		}
+		pass = next
+	}
+	return nil
+}
+
+// sample returns a copy of the grid keeping only every step-th cell of every
+// step-th row; the others are blank.
+func (g Grid) sample(step int) Grid {
+	if step <= 1 {
+		return g
+	}
+	sampled := make(Grid, len(g))
+	for row, cells := range g {
+		sampled[row] = make([]Cell, len(cells))
+		for col, cell := range cells {
+			if row%step == 0 && col%step == 0 {
+				sampled[row][col] = cell
+			} else {
+				sampled[row][col] = Cell{Rune: 0x2800}
+			}
+		}
+	}
+	return sampled
+}
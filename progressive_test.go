@@ -0,0 +1,92 @@
+package dots
+
+import (
+	"bytes"
+	"image"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// screen replays uncolored output containing cursor movements, such as that of
+// RenderProgressive, returning the lines on screen.
+func screen(out string) []string {
+	var lines [][]rune
+	row, col := 0, 0
+	for len(out) > 0 {
+		if rest, ok := strings.CutPrefix(out, "\x1b["); ok {
+			end := strings.IndexFunc(rest, func(r rune) bool { return r >= '@' && r <= '~' })
+			n, _ := strconv.Atoi(rest[:end])
+			switch rest[end] {
+			case 'F':
+				row, col = row-n, 0
+			case 'E':
+				row, col = row+n, 0
+			case 'G':
+				col = n - 1
+			}
+			out = rest[end+1:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(out)
+		out = out[size:]
+		switch r {
+		case '\r':
+			col = 0
+			continue
+		case '\n':
+			row, col = row+1, 0
+			continue
+		}
+		for len(lines) <= row {
+			lines = append(lines, nil)
+		}
+		for len(lines[row]) <= col {
+			lines[row] = append(lines[row], ' ')
+		}
+		lines[row][col] = r
+		col++
+	}
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = string(line)
+	}
+	return result
+}
+
+func TestRenderProgressive(t *testing.T) {
+	// A noisy pattern, so every cell differs from its neighbors
+	img := image.NewGray(image.Rect(0, 0, 32, 32))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i * 7919 % 256)
+	}
+
+	for _, tt := range []struct {
+		desc string
+		step int
+		opts Options
+	}{
+		{desc: "single pass", step: 1},
+		{desc: "two passes", step: 2},
+		{desc: "three passes", step: 4},
+		{desc: "step larger than the image", step: 64},
+		{desc: "framed", step: 4, opts: Options{Frame: true}},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			opts := tt.opts
+			opts.Width, opts.Height, opts.NoColor = 16, 8, true
+			var buf bytes.Buffer
+			if err := RenderProgressive(&buf, img, opts, tt.step); err != nil {
+				t.Fatalf("RenderProgressive() unexpected error: %v", err)
+			}
+			if got, want := screen(buf.String()), Convert(img, opts); !slices.Equal(got, want) {
+				t.Errorf("screen after RenderProgressive() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+			}
+			if tt.step > 1 && tt.step < 16 && !strings.Contains(buf.String(), "\x1b[") {
+				t.Errorf("RenderProgressive() drew a single pass, want %d", tt.step)
+			}
+		})
+	}
+}
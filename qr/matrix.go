@@ -0,0 +1,271 @@
+package qr
+
+// matrix is a QR code being laid out: its modules, and which of them are function
+// patterns rather than data.
+type matrix struct {
+	size     int
+	modules  [][]bool // Dark modules, indexed [y][x]
+	function [][]bool // Function modules, indexed [y][x]
+	version  int
+}
+
+func newMatrix(version int) *matrix {
+	size := version*4 + 17
+	m := &matrix{size: size, version: version}
+	m.modules = make([][]bool, size)
+	m.function = make([][]bool, size)
+	for y := range size {
+		m.modules[y] = make([]bool, size)
+		m.function[y] = make([]bool, size)
+	}
+	return m
+}
+
+// setFunction sets a function module, ignoring positions outside the code.
+func (m *matrix) setFunction(x, y int, dark bool) {
+	if x >= 0 && y >= 0 && x < m.size && y < m.size {
+		m.modules[y][x] = dark
+		m.function[y][x] = true
+	}
+}
+
+// drawFunctionPatterns draws the timing, finder, and alignment patterns and the
+// version information, and reserves the format information's modules.
+func (m *matrix) drawFunctionPatterns() {
+	for i := range m.size {
+		m.setFunction(6, i, i%2 == 0)
+		m.setFunction(i, 6, i%2 == 0)
+	}
+
+	// Finders, with their separators, in three corners
+	for _, c := range [][2]int{{3, 3}, {m.size - 4, 3}, {3, m.size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				d := max(abs(dx), abs(dy))
+				m.setFunction(c[0]+dx, c[1]+dy, d != 2 && d != 4)
+			}
+		}
+	}
+
+	// Alignment patterns on a grid, except where they'd overlap the finders
+	pos := alignmentPositions(m.version)
+	last := len(pos) - 1
+	for i, y := range pos {
+		for j, x := range pos {
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					m.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	m.drawFormatBits(0, 0)
+
+	if m.version >= 7 {
+		rem := m.version
+		for range 12 {
+			rem = rem<<1 ^ (rem>>11)*0x1f25
+		}
+		bits := m.version<<12 | rem
+		for i := range 18 {
+			dark := bits>>i&1 != 0
+			a, b := m.size-11+i%3, i/3
+			m.setFunction(a, b, dark)
+			m.setFunction(b, a, dark)
+		}
+	}
+}
+
+// alignmentPositions returns the coordinates of the centers of a version's
+// alignment patterns, along each axis.
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	n := version/7 + 2
+	step := 26
+	if version != 32 {
+		step = (version*4 + n*2 + 1) / (n*2 - 2) * 2
+	}
+	pos := make([]int, n)
+	pos[0] = 6
+	for i, p := n-1, version*4+10; i >= 1; i, p = i-1, p-step {
+		pos[i] = p
+	}
+	return pos
+}
+
+// drawFormatBits draws both copies of the format information for a level and mask,
+// and the dark module beside the lower one.
+func (m *matrix) drawFormatBits(level Level, mask int) {
+	data := level.formatBits()<<3 | mask
+	rem := data
+	for range 10 {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>i&1 != 0 }
+
+	for i := range 6 {
+		m.setFunction(8, i, bit(i))
+	}
+	m.setFunction(8, 7, bit(6))
+	m.setFunction(8, 8, bit(7))
+	m.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := range 8 {
+		m.setFunction(m.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.setFunction(8, m.size-15+i, bit(i))
+	}
+	m.setFunction(8, m.size-8, true)
+}
+
+// drawCodewords places the codewords' bits in the data modules, in two-module-wide
+// columns zigzagging up and down from the bottom right corner. Modules left over
+// stay light.
+func (m *matrix) drawCodewords(data []byte) {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			// Skip the vertical timing pattern
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := range m.size {
+			y := vert
+			if upward {
+				y = m.size - 1 - vert
+			}
+			for j := range 2 {
+				x := right - j
+				if !m.function[y][x] && i < len(data)*8 {
+					m.modules[y][x] = data[i/8]>>(7-i%8)&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by one of the 8 mask patterns.
+func (m *matrix) applyMask(mask int) {
+	for y := range m.size {
+		for x := range m.size {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !m.function[y][x] {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the code is to scan, for picking a mask: long runs and
+// blocks of one color, patterns that look like finders, and an imbalance of dark and
+// light modules all count against it.
+func (m *matrix) penalty() int {
+	p := 0
+	at := func(x, y int, transpose bool) bool {
+		if transpose {
+			return m.modules[x][y]
+		}
+		return m.modules[y][x]
+	}
+	finder := []bool{true, false, true, true, true, false, true}
+	for _, transpose := range []bool{false, true} {
+		for y := range m.size {
+			run := 0
+			for x := range m.size {
+				if x > 0 && at(x, y, transpose) == at(x-1, y, transpose) {
+					run++
+				} else {
+					run = 1
+				}
+				if run == 5 {
+					p += 3
+				} else if run > 5 {
+					p++
+				}
+			}
+
+			// Finder-like patterns with four light modules on either side
+			for x := 0; x+7 <= m.size; x++ {
+				match := true
+				for i, dark := range finder {
+					if at(x+i, y, transpose) != dark {
+						match = false
+						break
+					}
+				}
+				if match && (m.light(x-4, x, y, transpose) || m.light(x+7, x+11, y, transpose)) {
+					p += 40
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := range m.size {
+		for x := range m.size {
+			c := m.modules[y][x]
+			if c {
+				dark++
+			}
+			if x > 0 && y > 0 && c == m.modules[y][x-1] && c == m.modules[y-1][x] && c == m.modules[y-1][x-1] {
+				p += 3
+			}
+		}
+	}
+	total := m.size * m.size
+	// Ten points for every 5% away from half dark
+	p += (abs(dark*20-total*10)+total-1)/total*10 - 10
+	return max(p, 0)
+}
+
+// light reports whether the modules from x0 to x1 along row y, or column y if
+// transposed, are all light. Modules outside the code are light.
+func (m *matrix) light(x0, x1, y int, transpose bool) bool {
+	for x := max(x0, 0); x < min(x1, m.size); x++ {
+		dark := m.modules[y][x]
+		if transpose {
+			dark = m.modules[x][y]
+		}
+		if dark {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
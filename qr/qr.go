@@ -0,0 +1,254 @@
+// Package qr encodes text as QR codes and draws them with braille dots or
+// half-block characters, for sharing URLs and other short text from a terminal.
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Level is the error correction level of a QR code. Higher levels survive more
+// damage, at the cost of a larger code.
+type Level int
+
+// Error correction levels, recovering about 7%, 15%, 25%, and 30% of the code.
+const (
+	Low Level = iota
+	Medium
+	Quartile
+	High
+)
+
+// ParseLevel parses an error correction level: L, M, Q, or H.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToUpper(s) {
+	case "L":
+		return Low, nil
+	case "M":
+		return Medium, nil
+	case "Q":
+		return Quartile, nil
+	case "H":
+		return High, nil
+	}
+	return Low, fmt.Errorf("unknown error correction level %q (expected L, M, Q, or H)", s)
+}
+
+// formatBits returns the level's 2-bit code in the format information.
+func (l Level) formatBits() int {
+	return [...]int{1, 0, 3, 2}[l]
+}
+
+// ErrTooLong is returned when text doesn't fit in the largest QR code.
+var ErrTooLong = errors.New("text is too long for a QR code")
+
+// Code is an encoded QR code: a square of dark and light modules.
+type Code struct {
+	Size    int      // Width and height in modules
+	modules [][]bool // Dark modules, indexed [y][x]
+}
+
+// Black reports whether the module at (x, y) is dark. Modules outside the code,
+// as in its quiet zone, are light.
+func (c *Code) Black(x, y int) bool {
+	return x >= 0 && y >= 0 && x < c.Size && y < c.Size && c.modules[y][x]
+}
+
+// Encode encodes text as a QR code in byte mode, using the smallest version that
+// fits at the given error correction level.
+func Encode(text string, level Level) (*Code, error) {
+	data := []byte(text)
+	version := 0
+	for v := 1; v <= 40; v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if len(data) < 1<<countBits && 4+countBits+8*len(data) <= dataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	m := newMatrix(version)
+	m.drawFunctionPatterns()
+	m.drawCodewords(interleave(segment(data, version, level), version, level))
+
+	// Keep the mask with the lowest penalty
+	best, bestPenalty := 0, -1
+	for mask := range 8 {
+		m.applyMask(mask)
+		m.drawFormatBits(level, mask)
+		if p := m.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		m.applyMask(mask) // Masks are XORs, so this undoes it
+	}
+	m.applyMask(best)
+	m.drawFormatBits(level, best)
+	return &Code{Size: m.size, modules: m.modules}, nil
+}
+
+// segment returns the data codewords for data in byte mode, padded to fill the
+// version's capacity.
+func segment(data []byte, version int, level Level) []byte {
+	var bb bitBuffer
+	bb.append(0b0100, 4) // Byte mode
+	if version >= 10 {
+		bb.append(len(data), 16)
+	} else {
+		bb.append(len(data), 8)
+	}
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+
+	capacity := dataCodewords(version, level) * 8
+	bb.append(0, min(4, capacity-len(bb))) // Terminator
+	bb.append(0, (8-len(bb)%8)%8)
+	for pad := 0xEC; len(bb) < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	codewords := make([]byte, len(bb)/8)
+	for i, bit := range bb {
+		if bit {
+			codewords[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return codewords
+}
+
+// bitBuffer is a sequence of bits, most significant first.
+type bitBuffer []bool
+
+// append appends the n low bits of v.
+func (bb *bitBuffer) append(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*bb = append(*bb, v>>i&1 != 0)
+	}
+}
+
+// interleave splits the data codewords into blocks, appends error correction
+// codewords to each, and interleaves the blocks' codewords in the order they're
+// placed in the code.
+func interleave(data []byte, version int, level Level) []byte {
+	numBlocks := numECBlocks[level][version]
+	ecLen := ecCodewordsPerBlock[level][version]
+	raw := rawDataModules(version) / 8
+	numShort := numBlocks - raw%numBlocks
+	shortLen := raw / numBlocks // Including error correction codewords
+
+	divisor := rsDivisor(ecLen)
+	blocks := make([][]byte, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		n := shortLen - ecLen
+		if i >= numShort {
+			n++
+		}
+		block := data[k : k+n]
+		k += n
+		blocks[i] = append(slices.Clip(block), rsRemainder(block, divisor)...)
+	}
+
+	// The data codewords, then the error correction codewords, one from each block
+	// in turn
+	out := make([]byte, 0, raw)
+	for i := range shortLen - ecLen + 1 {
+		for _, block := range blocks {
+			if i < len(block)-ecLen {
+				out = append(out, block[i])
+			}
+		}
+	}
+	for i := range ecLen {
+		for _, block := range blocks {
+			out = append(out, block[len(block)-ecLen+i])
+		}
+	}
+	return out
+}
+
+// dataCodewords returns the number of data codewords in a version at a level.
+func dataCodewords(version int, level Level) int {
+	return rawDataModules(version)/8 - ecCodewordsPerBlock[level][version]*numECBlocks[level][version]
+}
+
+// rawDataModules returns the number of modules of a version available for data
+// and error correction codewords, after the function patterns, including any
+// remainder bits.
+func rawDataModules(version int) int {
+	n := (16*version+128)*version + 64
+	if version >= 2 {
+		align := version/7 + 2
+		n -= (25*align-10)*align - 55
+		if version >= 7 {
+			n -= 36
+		}
+	}
+	return n
+}
+
+// ecCodewordsPerBlock and numECBlocks give each level and version's error
+// correction codewords per block and number of blocks, indexed [level][version].
+var (
+	ecCodewordsPerBlock = [4][41]int{
+		{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+		{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+		{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+		{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	}
+	numECBlocks = [4][41]int{
+		{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+		{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+		{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+		{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+	}
+)
+
+// rsDivisor returns the Reed-Solomon generator polynomial of a degree, without its
+// leading 1, with coefficients from highest to lowest power.
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for range degree {
+		for j := range result {
+			result[j] = gfMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+	return result
+}
+
+// rsRemainder returns the Reed-Solomon error correction codewords for data.
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= gfMul(d, factor)
+		}
+	}
+	return result
+}
+
+// gfMul multiplies in GF(2⁸) modulo x⁸ + x⁴ + x³ + x² + 1.
+func gfMul(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x11d
+		z ^= int(y>>i&1) * int(x)
+	}
+	return byte(z)
+}
@@ -0,0 +1,276 @@
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRSRemainder(t *testing.T) {
+	// "HELLO WORLD" as a 1-M code, from the standard's worked example
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := rsRemainder(data, rsDivisor(len(want))); string(got) != string(want) {
+		t.Errorf("rsRemainder() = %v, want %v", got, want)
+	}
+}
+
+func TestFunctionPatterns(t *testing.T) {
+	for _, tt := range []struct {
+		desc  string
+		level Level
+		mask  int
+		want  string // The format information, most significant bit first
+	}{
+		{"L, mask 0", Low, 0, "111011111000100"},
+		{"M, mask 0", Medium, 0, "101010000010010"},
+		{"Q, mask 0", Quartile, 0, "011010101011111"},
+		{"H, mask 7", High, 7, "000100000111011"},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			m := newMatrix(1)
+			m.drawFormatBits(tt.level, tt.mask)
+			if got := bitString(readFormatBits(m), 15); got != tt.want {
+				t.Errorf("format information = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	m := newMatrix(7)
+	m.drawFunctionPatterns()
+	var version int
+	for i := range 18 {
+		if m.modules[i/3][m.size-11+i%3] {
+			version |= 1 << i
+		}
+	}
+	if got, want := bitString(version, 18), "000111110010010100"; got != want {
+		t.Errorf("version 7 information = %s, want %s", got, want)
+	}
+	if got, want := alignmentPositions(7), []int{6, 22, 38}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("alignmentPositions(7) = %v, want %v", got, want)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for _, tt := range []struct {
+		desc  string
+		text  string
+		level Level
+		size  int
+	}{
+		{"empty", "", Low, 21},
+		{"short", "hello", Medium, 21},
+		{"fills version 1", strings.Repeat("x", 17), Low, 21},
+		{"spills to version 2", strings.Repeat("x", 18), Low, 25},
+		{"url", "https://github.com/imjasonh/dots", High, 33},
+		{"multiple blocks", strings.Repeat("dots ", 40), Quartile, 65},
+		{"version info", strings.Repeat("0123456789", 30), Medium, 69},
+		{"utf-8", "⣿ braille ⣀", Medium, 25},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			c, err := Encode(tt.text, tt.level)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if c.Size != tt.size {
+				t.Errorf("Size = %d, want %d", c.Size, tt.size)
+			}
+			got, level, err := decode(c)
+			if err != nil {
+				t.Fatalf("decode() error = %v", err)
+			}
+			if got != tt.text {
+				t.Errorf("decode() = %q, want %q", got, tt.text)
+			}
+			if level != tt.level {
+				t.Errorf("decoded level = %d, want %d", level, tt.level)
+			}
+		})
+	}
+
+	if _, err := Encode(strings.Repeat("x", 3000), Low); !errors.Is(err, ErrTooLong) {
+		t.Errorf("Encode(3000 bytes) error = %v, want %v", err, ErrTooLong)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	if l, err := ParseLevel("q"); err != nil || l != Quartile {
+		t.Errorf("ParseLevel(q) = %v, %v, want %v", l, err, Quartile)
+	}
+	if _, err := ParseLevel("X"); err == nil {
+		t.Error("ParseLevel(X) succeeded, want error")
+	}
+}
+
+func TestRender(t *testing.T) {
+	c, err := Encode("dots", Low)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := c.Size + 2*DefaultQuietZone
+	canvas := c.Canvas(DefaultQuietZone, false)
+	if got, want := canvas.Bounds().Size().X, n*2; got != want {
+		t.Errorf("canvas width = %d, want %d", got, want)
+	}
+	// The quiet zone is light, so it's lit, and the finder's corner is dark
+	if !canvas.Get(0, 0) || canvas.Get(DefaultQuietZone*2, DefaultQuietZone*2) {
+		t.Error("canvas isn't lit for light modules")
+	}
+	if canvas := c.Canvas(0, true); !canvas.Get(0, 0) || !canvas.Get(1, 1) {
+		t.Error("inverted canvas isn't lit for dark modules")
+	}
+
+	lines := strings.Split(c.HalfBlocks(DefaultQuietZone, false), "\n")
+	if got, want := len(lines), (n+1)/2; got != want {
+		t.Fatalf("half blocks lines = %d, want %d", got, want)
+	}
+	if got, want := lines[0], strings.Repeat("█", n); got != want {
+		t.Errorf("half blocks first line = %q, want %q", got, want)
+	}
+	// The code is odd-sized, so the last line is only the quiet zone's top halves
+	if got, want := lines[len(lines)-1], strings.Repeat("▀", n); got != want {
+		t.Errorf("half blocks last line = %q, want %q", got, want)
+	}
+	if got, want := c.HalfBlocks(0, true)[:len("█▀▀▀▀▀█")], "█▀▀▀▀▀█"; got != want {
+		t.Errorf("inverted half blocks = %q, want the top of a finder", got)
+	}
+}
+
+// bitString formats the n low bits of v, most significant first.
+func bitString(v, n int) string {
+	return fmt.Sprintf("%0*b", n, v)
+}
+
+// readFormatBits reads the first copy of the format information.
+func readFormatBits(m *matrix) int {
+	var bits int
+	set := func(i int, dark bool) {
+		if dark {
+			bits |= 1 << i
+		}
+	}
+	for i := range 6 {
+		set(i, m.modules[i][8])
+	}
+	set(6, m.modules[7][8])
+	set(7, m.modules[8][8])
+	set(8, m.modules[8][7])
+	for i := 9; i < 15; i++ {
+		set(i, m.modules[8][14-i])
+	}
+	return bits
+}
+
+// decode reads back a code's text and level, checking its error correction
+// codewords, as independently of Encode as is practical.
+func decode(c *Code) (string, Level, error) {
+	version := (c.Size - 17) / 4
+	m := newMatrix(version)
+	m.drawFunctionPatterns()
+	format := readFormatBits(&matrix{size: c.Size, modules: c.modules}) ^ 0x5412
+	var level Level
+	for l := range 4 {
+		if Level(l).formatBits() == format>>13 {
+			level = Level(l)
+		}
+	}
+	mask := format >> 10 & 7
+
+	// Unmask a copy of the data modules and read them in placement order
+	for y := range c.Size {
+		copy(m.modules[y], c.modules[y])
+	}
+	m.applyMask(mask)
+	var codewords []byte
+	bit := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := range c.Size {
+			y := vert
+			if (right+1)&2 == 0 {
+				y = c.Size - 1 - vert
+			}
+			for _, x := range []int{right, right - 1} {
+				if m.function[y][x] || bit/8 >= rawDataModules(version)/8 {
+					continue
+				}
+				if bit%8 == 0 {
+					codewords = append(codewords, 0)
+				}
+				if m.modules[y][x] {
+					codewords[bit/8] |= 1 << (7 - bit%8)
+				}
+				bit++
+			}
+		}
+	}
+
+	// Deinterleave the blocks, check each is a multiple of the generator, and
+	// gather the data
+	numBlocks := numECBlocks[level][version]
+	ecLen := ecCodewordsPerBlock[level][version]
+	blocks := make([][]byte, numBlocks)
+	dataLen := dataCodewords(version, level)
+	numShort := numBlocks - len(codewords)%numBlocks
+	shortData := len(codewords)/numBlocks - ecLen
+	for i := range dataLen {
+		// Every block has shortData codewords, and then the long blocks one more
+		b := i % numBlocks
+		if i >= shortData*numBlocks {
+			b = numShort + i - shortData*numBlocks
+		}
+		blocks[b] = append(blocks[b], codewords[i])
+	}
+	for i := dataLen; i < len(codewords); i++ {
+		blocks[(i-dataLen)%numBlocks] = append(blocks[(i-dataLen)%numBlocks], codewords[i])
+	}
+	var data []byte
+	for i, block := range blocks {
+		for k := range ecLen {
+			root := byte(1)
+			for range k {
+				root = gfMul(root, 2)
+			}
+			var syndrome byte
+			for _, b := range block {
+				syndrome = gfMul(syndrome, root) ^ b
+			}
+			if syndrome != 0 {
+				return "", level, fmt.Errorf("block %d has syndrome %d = %d", i, k, syndrome)
+			}
+		}
+		data = append(data, block[:len(block)-ecLen]...)
+	}
+
+	if data[0]>>4 != 0b0100 {
+		return "", level, fmt.Errorf("mode = %04b, want byte mode", data[0]>>4)
+	}
+	var bits bitBuffer
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	read := func(start, n int) int {
+		v := 0
+		for _, b := range bits[start : start+n] {
+			v <<= 1
+			if b {
+				v |= 1
+			}
+		}
+		return v
+	}
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	n := read(4, countBits)
+	text := make([]byte, n)
+	for i := range text {
+		text[i] = byte(read(4+countBits+i*8, 8))
+	}
+	return string(text), level, nil
+}
@@ -0,0 +1,65 @@
+package qr
+
+import (
+	"strings"
+
+	"github.com/imjasonh/dots"
+)
+
+// DefaultQuietZone is the width in modules of the light margin scanners need
+// around a code.
+const DefaultQuietZone = 4
+
+// lit reports whether the module at (x, y), including the quiet zone of width
+// quiet, is drawn. Terminals draw characters light on dark, so light modules are
+// drawn to show the code the usual way around on a dark background; invert draws
+// the dark modules instead, for light backgrounds.
+func (c *Code) lit(x, y, quiet int, invert bool) bool {
+	return c.Black(x-quiet, y-quiet) == invert
+}
+
+// Canvas draws the code on a canvas with each module 2×2 dots, so it comes out
+// square, surrounded by a quiet zone quiet modules wide. Light modules are lit
+// unless invert is set. Braille leaves gaps between dots, so HalfBlocks scans more
+// reliably where it's available.
+func (c *Code) Canvas(quiet int, invert bool) *dots.Canvas {
+	n := c.Size + quiet*2
+	canvas := dots.NewCanvas(n*2, n*2)
+	for y := range n {
+		for x := range n {
+			if c.lit(x, y, quiet, invert) {
+				canvas.FillRect(x*2, y*2, x*2+1, y*2+1)
+			}
+		}
+	}
+	return canvas
+}
+
+// HalfBlocks draws the code with half-block characters, one module per character
+// across and two down, surrounded by a quiet zone quiet modules wide. Light modules
+// are filled unless invert is set.
+func (c *Code) HalfBlocks(quiet int, invert bool) string {
+	n := c.Size + quiet*2
+	var sb strings.Builder
+	for y := 0; y < n; y += 2 {
+		if y > 0 {
+			sb.WriteByte('\n')
+		}
+		for x := range n {
+			top := c.lit(x, y, quiet, invert)
+			// An odd-sized code's last row of characters has only a top half
+			bottom := y+1 < n && c.lit(x, y+1, quiet, invert)
+			switch {
+			case top && bottom:
+				sb.WriteRune('█')
+			case top:
+				sb.WriteRune('▀')
+			case bottom:
+				sb.WriteRune('▄')
+			default:
+				sb.WriteRune(' ')
+			}
+		}
+	}
+	return sb.String()
+}
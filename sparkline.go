@@ -0,0 +1,67 @@
+package dots
+
+import "math"
+
+// Sparkline returns a single line of braille characters charting values as a
+// connected line from left to right, scaled between their minimum and maximum,
+// for embedding tiny trends in CLIs and prompts. Each character holds two values;
+// if there are more than 2×width values, they are averaged down to fit. A width
+// of 0 or less fits every value. NaN values leave gaps.
+func Sparkline(values []float64, width int) string {
+	if width <= 0 {
+		width = (len(values) + 1) / 2
+	}
+	values = resampleValues(values, width*2)
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			lo, hi = min(lo, v), max(hi, v)
+		}
+	}
+
+	c := NewCanvas(width*2, 4)
+	prev := -1
+	for x, v := range values {
+		if math.IsNaN(v) {
+			prev = -1
+			continue
+		}
+		// The maximum is the top row of dots; a flat line sits in the middle
+		y := 2
+		if hi > lo {
+			y = 3 - int(math.Round((v-lo)/(hi-lo)*3))
+		}
+		if prev < 0 {
+			c.Set(x, y)
+		} else {
+			c.Line(x-1, prev, x, y)
+		}
+		prev = y
+	}
+	return c.String()
+}
+
+// resampleValues averages values down to at most n buckets, ignoring NaN values.
+// A bucket with only NaN values is NaN.
+func resampleValues(values []float64, n int) []float64 {
+	if len(values) <= n {
+		return values
+	}
+	out := make([]float64, n)
+	for i := range out {
+		start, end := i*len(values)/n, (i+1)*len(values)/n
+		sum, count := 0.0, 0
+		for _, v := range values[start:end] {
+			if !math.IsNaN(v) {
+				sum += v
+				count++
+			}
+		}
+		out[i] = math.NaN()
+		if count > 0 {
+			out[i] = sum / float64(count)
+		}
+	}
+	return out
+}
@@ -0,0 +1,28 @@
+package dots
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{desc: "rise and fall", values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 7, 6, 5, 4, 3, 2, 1}, want: "⣀⠤⠒⠉⠑⠢⢄⡀"},
+		{desc: "flat", values: []float64{5, 5, 5, 5}, want: "⠤⠤"},
+		{desc: "gap", values: []float64{1, 2, math.NaN(), 3, 4}, want: "⡠⠐⠁"},
+		{desc: "padded", values: []float64{0, 1}, width: 3, want: "⡜⠀⠀"},
+		{desc: "averaged", values: []float64{0, 0, 1, 1, 2, 2, 3, 3}, width: 2, want: "⡠⠊"},
+		{desc: "empty", want: ""},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := Sparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("Sparkline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package dots
+
+import (
+	"image"
+	"time"
+)
+
+// StageTimes is the time spent in each stage of converting an image, for tuning
+// options where conversion speed matters, such as animation.
+type StageTimes struct {
+	Resize   time.Duration // Scaling to the output's dot dimensions and compositing over the matte
+	Filter   time.Duration // Filters, threshold percentile, caption, and building a palette
+	Dither   time.Duration // Choosing which dots are lit, including edge detection
+	Quantize time.Duration // Packing dots into cells and quantizing their colors
+	Encode   time.Duration // Emitting the cells as text and escape codes
+}
+
+// Total returns the time spent in all stages.
+func (s StageTimes) Total() time.Duration {
+	return s.Resize + s.Filter + s.Dither + s.Quantize + s.Encode
+}
+
+// Add returns the sum of s and t, stage by stage.
+func (s StageTimes) Add(t StageTimes) StageTimes {
+	return StageTimes{
+		Resize:   s.Resize + t.Resize,
+		Filter:   s.Filter + t.Filter,
+		Dither:   s.Dither + t.Dither,
+		Quantize: s.Quantize + t.Quantize,
+		Encode:   s.Encode + t.Encode,
+	}
+}
+
+// TimeStages converts an image as Convert does, returning its lines and the time
+// spent in each stage.
+func TimeStages(img image.Image, opts Options) ([]string, StageTimes) {
+	var times StageTimes
+	start := time.Now()
+	lap := func(d *time.Duration) {
+		now := time.Now()
+		*d = now.Sub(start)
+		start = now
+	}
+
+	renderOpts := opts
+	resized, mask, opts := resizeImage(img, opts)
+	lap(&times.Resize)
+	opts = filterImage(resized, mask, opts)
+	lap(&times.Filter)
+	dots := dotMatrix(resized, opts)
+	dither := newCellDither(opts)
+	lap(&times.Dither)
+	grid := make(Grid, opts.Height)
+	for row := range grid {
+		grid[row] = convertRow(resized, mask, dots, dither, row, opts)
+	}
+	lap(&times.Quantize)
+	lines := grid.Render(renderOpts)
+	lap(&times.Encode)
+	return lines, times
+}
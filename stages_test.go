@@ -0,0 +1,62 @@
+package dots
+
+import (
+	"image/png"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestTimeStages(t *testing.T) {
+	f, err := os.Open("testdata/rainbow.png")
+	if err != nil {
+		t.Fatalf("failed to open test image: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode test image: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"color", Options{Width: 12, Height: 6}},
+		{"no color", Options{Width: 12, Height: 6, NoColor: true}},
+		{"dither", Options{Width: 12, Height: 6, DitherAlgorithm: DitherFloydSteinberg, ColorDither: true}},
+		{"palette", Options{Width: 12, Height: 6, PaletteMode: PaletteKMeans, PaletteSize: 4}},
+		{"frame", Options{Width: 12, Height: 6, Frame: true, Caption: "hi"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, times := TimeStages(img, tt.opts)
+			if want := Convert(img, tt.opts); !slices.Equal(lines, want) {
+				t.Errorf("TimeStages lines = %q, want %q", lines, want)
+			}
+			if times.Total() <= 0 {
+				t.Errorf("Total() = %v, want positive", times.Total())
+			}
+			for name, d := range map[string]int64{
+				"Resize": int64(times.Resize), "Filter": int64(times.Filter), "Dither": int64(times.Dither),
+				"Quantize": int64(times.Quantize), "Encode": int64(times.Encode),
+			} {
+				if d < 0 {
+					t.Errorf("%s = %d, want non-negative", name, d)
+				}
+			}
+		})
+	}
+}
+
+func TestStageTimesAdd(t *testing.T) {
+	a := StageTimes{Resize: 1, Filter: 2, Dither: 3, Quantize: 4, Encode: 5}
+	got := a.Add(a)
+	want := StageTimes{Resize: 2, Filter: 4, Dither: 6, Quantize: 8, Encode: 10}
+	if got != want {
+		t.Errorf("Add = %+v, want %+v", got, want)
+	}
+	if got.Total() != 30 {
+		t.Errorf("Total = %v, want 30", got.Total())
+	}
+}
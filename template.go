@@ -0,0 +1,40 @@
+package dots
+
+import (
+	"os"
+	"strings"
+	"text/template"
+)
+
+// TemplateFuncs returns functions for text/template (or html/template, after
+// converting to its FuncMap) that inline braille renders into pages and reports:
+//
+//	brailleImage path width  the image file at path, width characters wide
+//	sparkline values width   a sparkline of values, as Sparkline
+//	progressBar frac width   a progress bar, as ProgressBar
+//
+// Images are rendered without color, since escape codes don't belong in
+// documents, and for cells twice as tall as they are wide rather than for the
+// terminal the template happens to run in, so the output is the same everywhere.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"brailleImage": templateImage,
+		"sparkline":    Sparkline,
+		"progressBar":  ProgressBar,
+	}
+}
+
+// templateImage implements brailleImage for TemplateFuncs.
+func templateImage(path string, width int) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	img, _, err := Decode(f)
+	if err != nil {
+		return "", err
+	}
+	lines := Convert(img, Options{Width: width, NoColor: true, CellAspect: 0.5})
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,47 @@
+package dots
+
+import (
+	"image"
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(TemplateFuncs()).Parse(
+		`{{brailleImage .Path 10}}|{{sparkline .Values 3}}|{{progressBar 0.5 2}}`))
+	var sb strings.Builder
+	data := struct {
+		Path   string
+		Values []float64
+	}{"testdata/gradient.png", []float64{1, 2, 3}}
+	if err := tmpl.Execute(&sb, data); err != nil {
+		t.Fatalf("Execute() = %v", err)
+	}
+
+	f, err := os.Open(data.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join(Convert(img, Options{Width: 10, NoColor: true, CellAspect: 0.5}), "\n") +
+		"|" + Sparkline(data.Values, 3) + "|" + ProgressBar(0.5, 2)
+	if got := sb.String(); got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+	if strings.Contains(sb.String(), "\x1b") {
+		t.Errorf("Execute() output has escape codes: %q", sb.String())
+	}
+}
+
+func TestTemplateFuncsMissingImage(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(TemplateFuncs()).Parse(`{{brailleImage "testdata/missing.png" 10}}`))
+	if err := tmpl.Execute(&strings.Builder{}, nil); err == nil {
+		t.Error("Execute() with a missing image succeeded, want an error")
+	}
+}
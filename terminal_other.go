@@ -0,0 +1,22 @@
+//go:build !unix
+
+package dots
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// queryTerminal is not supported on this platform.
+func queryTerminal(string, int, time.Duration) (string, error) {
+	return "", errors.ErrUnsupported
+}
+
+// terminalCellAspect is not supported on this platform.
+func terminalCellAspect() float64 {
+	return 0
+}
+
+// NotifyResize does nothing on this platform, which has no resize signal.
+func NotifyResize(chan<- os.Signal) {}
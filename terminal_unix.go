@@ -0,0 +1,75 @@
+//go:build unix
+
+package dots
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"golang.org/x/term"
+)
+
+// terminalCellAspect returns the width/height ratio of a character cell on stdout's
+// terminal, from the pixel size reported by TIOCGWINSZ. It returns 0 if the terminal
+// doesn't report pixel sizes, as many don't.
+func terminalCellAspect() float64 {
+	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil || ws.Col == 0 || ws.Row == 0 || ws.Xpixel == 0 || ws.Ypixel == 0 {
+		return 0
+	}
+	cellWidth := float64(ws.Xpixel) / float64(ws.Col)
+	cellHeight := float64(ws.Ypixel) / float64(ws.Row)
+	return cellWidth / cellHeight
+}
+
+// NotifyResize relays terminal resize signals (SIGWINCH) to c, as signal.Notify does.
+// It does nothing on platforms without them. Use signal.Stop to stop relaying.
+func NotifyResize(c chan<- os.Signal) {
+	signal.Notify(c, unix.SIGWINCH)
+}
+
+// queryTerminal writes a query escape sequence to the controlling terminal and
+// returns its responses, each of which must end with a string terminator (ESC \ or BEL).
+func queryTerminal(query string, responses int, timeout time.Duration) (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = tty.Close() }()
+
+	// Raw mode stops the response from being echoed or line-buffered
+	state, err := term.MakeRaw(int(tty.Fd()))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = term.Restore(int(tty.Fd()), state) }()
+
+	// Set the deadline before querying, so failing to set it can't leave a
+	// response on the terminal for the shell to read as input
+	if err := tty.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return "", err
+	}
+	if _, err := tty.WriteString(query); err != nil {
+		return "", err
+	}
+
+	var resp strings.Builder
+	buf := make([]byte, 64)
+	for {
+		n, err := tty.Read(buf)
+		resp.Write(buf[:n])
+		if s := resp.String(); strings.Count(s, "\x1b\\")+strings.Count(s, "\a") >= responses {
+			return s, nil
+		}
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				return "", errors.New("terminal did not respond")
+			}
+			return "", err
+		}
+	}
+}
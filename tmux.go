@@ -0,0 +1,29 @@
+package dots
+
+import (
+	"os"
+	"strings"
+)
+
+// InTmux reports whether the program is running inside tmux, which sets $TMUX in
+// the sessions it starts.
+func InTmux() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+// TmuxPassthrough wraps an escape sequence in tmux's DCS passthrough sequence, so
+// tmux hands it to the terminal it runs in rather than dropping it or drawing it
+// as text. Sequences tmux doesn't understand itself, like sixel, kitty, and
+// iTerm2 images, need it. Since tmux 3.3, the allow-passthrough option must be on.
+func TmuxPassthrough(seq string) string {
+	return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+}
+
+// Passthrough returns seq wrapped with TmuxPassthrough inside tmux, and seq
+// unchanged outside it.
+func Passthrough(seq string) string {
+	if InTmux() {
+		return TmuxPassthrough(seq)
+	}
+	return seq
+}
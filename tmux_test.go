@@ -0,0 +1,35 @@
+package dots
+
+import "testing"
+
+func TestTmuxPassthrough(t *testing.T) {
+	for _, tt := range []struct {
+		desc, seq, want string
+	}{{
+		desc: "escapes are doubled",
+		seq:  "\x1b_Ga=T;AAAA\x1b\\",
+		want: "\x1bPtmux;\x1b\x1b_Ga=T;AAAA\x1b\x1b\\\x1b\\",
+	}, {
+		desc: "no escapes",
+		seq:  "abc",
+		want: "\x1bPtmux;abc\x1b\\",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := TmuxPassthrough(tt.seq); got != tt.want {
+				t.Errorf("TmuxPassthrough(%q) = %q, want %q", tt.seq, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPassthrough(t *testing.T) {
+	const seq = "\x1bPq#0~-\x1b\\"
+	t.Setenv("TMUX", "")
+	if got := Passthrough(seq); got != seq {
+		t.Errorf("Passthrough() outside tmux = %q, want %q", got, seq)
+	}
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	if got, want := Passthrough(seq), TmuxPassthrough(seq); got != want {
+		t.Errorf("Passthrough() inside tmux = %q, want %q", got, want)
+	}
+}
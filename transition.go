@@ -0,0 +1,155 @@
+package dots
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"time"
+)
+
+// Transition is an effect for changing from one image to the next in a slideshow.
+type Transition string
+
+// Supported transitions.
+const (
+	TransitionCut      Transition = "cut"      // Replace the image at once
+	TransitionWipe     Transition = "wipe"     // Sweep the new image in from the left
+	TransitionDissolve Transition = "dissolve" // Switch dots to the new image in random order
+)
+
+// Transitions lists the names of all supported transitions.
+var Transitions = []Transition{TransitionCut, TransitionWipe, TransitionDissolve}
+
+// ParseTransition validates a transition name. The empty string selects a cut.
+func ParseTransition(s string) (Transition, error) {
+	if s == "" {
+		return TransitionCut, nil
+	}
+	for _, t := range Transitions {
+		if Transition(s) == t {
+			return t, nil
+		}
+	}
+	return TransitionCut, fmt.Errorf("unknown transition %q", s)
+}
+
+// Blend returns the grid partway through the transition from one grid to another,
+// at progress from 0 (from) to 1 (to). The result has the size of to; cells missing
+// from from are blank.
+func (t Transition) Blend(from, to Grid, progress float64) Grid {
+	blended := make(Grid, len(to))
+	for row, cells := range to {
+		blended[row] = make([]Cell, len(cells))
+		for col, cell := range cells {
+			old := Cell{Rune: 0x2800}
+			if row < len(from) && col < len(from[row]) {
+				old = from[row][col]
+			}
+			switch t {
+			case TransitionWipe:
+				if float64(col) < progress*float64(len(cells)) {
+					old = cell
+				}
+			case TransitionDissolve:
+				old = dissolveCell(old, cell, col, row, progress)
+			default:
+				if progress >= 1 {
+					old = cell
+				}
+			}
+			blended[row][col] = old
+		}
+	}
+	return blended
+}
+
+// dissolveCell switches each dot of the cell at (x, y) from the old pattern to
+// the new one once progress passes the dot's pseudo-random threshold. The colors
+// switch once half of the cell has.
+func dissolveCell(old, cell Cell, x, y int, progress float64) Cell {
+	pattern := old.Pattern
+	for bit := range 8 {
+		if dissolveThreshold(x, y, bit) < progress {
+			mask := uint8(1) << bit
+			pattern = pattern&^mask | cell.Pattern&mask
+		}
+	}
+	blended := old
+	if dissolveThreshold(x, y, 8) < progress {
+		blended = cell
+	}
+	blended.Pattern = pattern
+	blended.Rune = 0x2800 + rune(pattern)
+	return blended
+}
+
+// dissolveThreshold returns a pseudo-random value in [0, 1) for a dot, or for a
+// cell's colors if bit is 8, so the dissolve order is the same for every transition.
+func dissolveThreshold(x, y, bit int) float64 {
+	h := uint32(x)*0x9e3779b1 ^ uint32(y)*0x85ebca77 ^ uint32(bit)*0xc2b2ae3d
+	h ^= h >> 16
+	h *= 0x7feb352d
+	h ^= h >> 15
+	h *= 0x846ca68b
+	h ^= h >> 16
+	return float64(h) / (1 << 32)
+}
+
+// SlideshowOptions configures slideshow playback.
+type SlideshowOptions struct {
+	Duration           time.Duration // How long each image is shown, default 5s
+	Transition         Transition    // Effect between images, default TransitionCut
+	TransitionDuration time.Duration // How long transitions take, default 500ms
+	Labels             []string      // Lines of text above the images, by index, e.g. file names
+}
+
+// transitionFrameRate is the number of frames per second drawn during transitions.
+const transitionFrameRate = 30
+
+// Slideshow renders images to w, a terminal, one after another, changing between
+// them with a transition. It returns after showing the last image, or when ctx is done.
+func Slideshow(ctx context.Context, w io.Writer, images []image.Image, opts Options, show SlideshowOptions) error {
+	if show.Duration <= 0 {
+		show.Duration = 5 * time.Second
+	}
+	if show.TransitionDuration <= 0 {
+		show.TransitionDuration = 500 * time.Millisecond
+	}
+
+	p, err := newPlayer(w, opts)
+	if err != nil {
+		return err
+	}
+	defer p.close()
+
+	for i, img := range images {
+		convert := func() Grid {
+			grid := ConvertGrid(img, opts)
+			if i < len(show.Labels) {
+				grid = grid.WithHeader(show.Labels[i])
+			}
+			return grid
+		}
+		grid := convert()
+		if i > 0 && show.Transition != TransitionCut {
+			from := p.frames.prev
+			steps := max(1, int(show.TransitionDuration.Seconds()*transitionFrameRate))
+			for step := 1; step < steps; step++ {
+				if err := p.draw(show.Transition.Blend(from, grid, float64(step)/float64(steps))); err != nil {
+					return err
+				}
+				if err := p.wait(ctx, show.TransitionDuration/time.Duration(steps), convert); err != nil {
+					return err
+				}
+			}
+		}
+		if err := p.draw(grid); err != nil {
+			return err
+		}
+		if err := p.wait(ctx, show.Duration, convert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package dots
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseTransition(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    Transition
+		wantErr bool
+	}{
+		{in: "", want: TransitionCut},
+		{in: "cut", want: TransitionCut},
+		{in: "wipe", want: TransitionWipe},
+		{in: "dissolve", want: TransitionDissolve},
+		{in: "fade", want: TransitionCut, wantErr: true},
+	} {
+		got, err := ParseTransition(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTransition(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseTransition(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBlend(t *testing.T) {
+	fill := func(cell Cell) Grid {
+		g := make(Grid, 4)
+		for row := range g {
+			g[row] = slices.Repeat([]Cell{cell}, 8)
+		}
+		return g
+	}
+	empty := Cell{Rune: 0x2800, Fg: color.RGBA{0, 0, 255, 255}}
+	full := Cell{Rune: 0x28ff, Pattern: 0xff, Fg: color.RGBA{255, 0, 0, 255}}
+	from, to := fill(empty), fill(full)
+
+	count := func(g Grid, cell Cell) int {
+		n := 0
+		for _, cells := range g {
+			for _, c := range cells {
+				if c == cell {
+					n++
+				}
+			}
+		}
+		return n
+	}
+
+	for _, tt := range []struct {
+		desc       string
+		transition Transition
+		progress   float64
+		wantNew    int // Cells entirely switched to the new grid
+		wantOld    int // Cells unchanged from the old grid
+	}{
+		{desc: "cut before", transition: TransitionCut, progress: 0.5, wantOld: 32},
+		{desc: "cut after", transition: TransitionCut, progress: 1, wantNew: 32},
+		{desc: "wipe start", transition: TransitionWipe, progress: 0, wantOld: 32},
+		{desc: "wipe halfway", transition: TransitionWipe, progress: 0.5, wantNew: 16, wantOld: 16},
+		{desc: "wipe end", transition: TransitionWipe, progress: 1, wantNew: 32},
+		{desc: "dissolve start", transition: TransitionDissolve, progress: 0, wantOld: 32},
+		{desc: "dissolve end", transition: TransitionDissolve, progress: 1, wantNew: 32},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := tt.transition.Blend(from, to, tt.progress)
+			if n := count(got, full); n != tt.wantNew {
+				t.Errorf("%d new cells, want %d", n, tt.wantNew)
+			}
+			if n := count(got, empty); n != tt.wantOld {
+				t.Errorf("%d old cells, want %d", n, tt.wantOld)
+			}
+		})
+	}
+
+	t.Run("dissolve halfway", func(t *testing.T) {
+		got := TransitionDissolve.Blend(from, to, 0.5)
+		dots := 0
+		for _, cells := range got {
+			for _, c := range cells {
+				if c.Rune != 0x2800+rune(c.Pattern) {
+					t.Fatalf("cell rune %U doesn't match pattern %08b", c.Rune, c.Pattern)
+				}
+				for bit := range 8 {
+					dots += int(c.Pattern >> bit & 1)
+				}
+			}
+		}
+		// About half of the 256 dots have switched
+		if dots < 96 || dots > 160 {
+			t.Errorf("%d dots lit halfway through, want about 128", dots)
+		}
+	})
+
+	t.Run("different sizes", func(t *testing.T) {
+		got := TransitionWipe.Blend(fill(empty)[:2], to, 0)
+		if len(got) != 4 || got[3][0] != (Cell{Rune: 0x2800}) {
+			t.Errorf("Blend() didn't fill missing cells with blanks: %v", got)
+		}
+	})
+}
+
+func TestSlideshow(t *testing.T) {
+	black := image.NewGray(image.Rect(0, 0, 16, 16))
+	white := image.NewGray(image.Rect(0, 0, 16, 16))
+	for i := range white.Pix {
+		white.Pix[i] = 255
+	}
+
+	for _, transition := range Transitions {
+		t.Run(string(transition), func(t *testing.T) {
+			opts := Options{Width: 8, Height: 4, NoColor: true}
+			var buf bytes.Buffer
+			show := SlideshowOptions{Duration: time.Millisecond, Transition: transition, TransitionDuration: 10 * time.Millisecond}
+			if err := Slideshow(context.Background(), &buf, []image.Image{black, white}, opts, show); err != nil {
+				t.Fatalf("Slideshow() unexpected error: %v", err)
+			}
+			if got, want := screen(buf.String()), Convert(white, opts); !slices.Equal(got, want) {
+				t.Errorf("screen after Slideshow() = %q, want the last image %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package dots
+
+import (
+	"image"
+	"image/draw"
+)
+
+// MaxZoom is the largest magnification of a Viewport.
+const MaxZoom = 64
+
+// Viewport is a zoomed and panned view of part of an image, for interactive viewers.
+// Cropping to the view before converting renders it at higher detail than the whole image.
+type Viewport struct {
+	Zoom float64 // Magnification, from 1 (the whole image) to MaxZoom
+	X, Y float64 // Center of the view, as fractions of the image's width and height
+}
+
+// NewViewport returns a view of the whole image.
+func NewViewport() Viewport {
+	return Viewport{Zoom: 1, X: 0.5, Y: 0.5}
+}
+
+// Pan moves the view by dx and dy, as fractions of the view's width and height,
+// keeping it inside the image.
+func (v *Viewport) Pan(dx, dy float64) {
+	v.X += dx / v.Zoom
+	v.Y += dy / v.Zoom
+	v.clamp()
+}
+
+// ZoomBy multiplies the magnification by factor, keeping the center of the view in place.
+func (v *Viewport) ZoomBy(factor float64) {
+	v.ZoomAt(factor, 0.5, 0.5)
+}
+
+// ZoomAt multiplies the magnification by factor, keeping the point at (fx, fy) in place,
+// as fractions of the view's width and height, e.g. the point under the mouse.
+func (v *Viewport) ZoomAt(factor, fx, fy float64) {
+	x := v.X + (fx-0.5)/v.Zoom
+	y := v.Y + (fy-0.5)/v.Zoom
+	v.Zoom = max(1, min(v.Zoom*factor, MaxZoom))
+	v.X = x - (fx-0.5)/v.Zoom
+	v.Y = y - (fy-0.5)/v.Zoom
+	v.clamp()
+}
+
+// clamp keeps the view inside the image.
+func (v *Viewport) clamp() {
+	half := 0.5 / v.Zoom
+	v.X = max(half, min(v.X, 1-half))
+	v.Y = max(half, min(v.Y, 1-half))
+}
+
+// Rect returns the part of an image with the given bounds that is in view.
+// It is at least one pixel in each dimension.
+func (v Viewport) Rect(bounds image.Rectangle) image.Rectangle {
+	w := max(1, int(float64(bounds.Dx())/v.Zoom+0.5))
+	h := max(1, int(float64(bounds.Dy())/v.Zoom+0.5))
+	x := bounds.Min.X + int(v.X*float64(bounds.Dx())+0.5) - w/2
+	y := bounds.Min.Y + int(v.Y*float64(bounds.Dy())+0.5) - h/2
+	x = max(bounds.Min.X, min(x, bounds.Max.X-w))
+	y = max(bounds.Min.Y, min(y, bounds.Max.Y-h))
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// Crop returns the part of img that is in view.
+func (v Viewport) Crop(img image.Image) image.Image {
+	r := v.Rect(img.Bounds())
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(r)
+	}
+	cropped := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), img, r.Min, draw.Src)
+	return cropped
+}
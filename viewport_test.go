@@ -0,0 +1,68 @@
+package dots
+
+import (
+	"image"
+	"testing"
+)
+
+func TestViewport(t *testing.T) {
+	bounds := image.Rect(0, 0, 200, 100)
+	for _, tt := range []struct {
+		desc string
+		move func(v *Viewport)
+		want image.Rectangle
+	}{{
+		desc: "whole image",
+		move: func(*Viewport) {},
+		want: bounds,
+	}, {
+		desc: "zoomed in on the center",
+		move: func(v *Viewport) { v.ZoomBy(2) },
+		want: image.Rect(50, 25, 150, 75),
+	}, {
+		desc: "panned right by half a view",
+		move: func(v *Viewport) { v.ZoomBy(2); v.Pan(0.5, 0) },
+		want: image.Rect(100, 25, 200, 75),
+	}, {
+		desc: "panning stops at the edge",
+		move: func(v *Viewport) { v.ZoomBy(2); v.Pan(-5, -5) },
+		want: image.Rect(0, 0, 100, 50),
+	}, {
+		desc: "can't pan the whole image",
+		move: func(v *Viewport) { v.Pan(1, 1) },
+		want: bounds,
+	}, {
+		desc: "can't zoom out past the whole image",
+		move: func(v *Viewport) { v.ZoomBy(2); v.Pan(1, 1); v.ZoomBy(0.1) },
+		want: bounds,
+	}, {
+		desc: "zoomed in on the top-left corner",
+		move: func(v *Viewport) { v.ZoomAt(2, 0, 0) },
+		want: image.Rect(0, 0, 100, 50),
+	}, {
+		desc: "zoomed in on a point",
+		move: func(v *Viewport) { v.ZoomAt(2, 0.75, 0.5) },
+		want: image.Rect(75, 25, 175, 75),
+	}, {
+		desc: "zooming is limited",
+		move: func(v *Viewport) { v.ZoomBy(1000) },
+		want: image.Rect(99, 49, 102, 51),
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			v := NewViewport()
+			tt.move(&v)
+			if got := v.Rect(bounds); got != tt.want {
+				t.Errorf("Rect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("crop", func(t *testing.T) {
+		img := image.NewRGBA(image.Rect(10, 10, 210, 110))
+		v := NewViewport()
+		v.ZoomBy(4)
+		if got, want := v.Crop(img).Bounds(), image.Rect(85, 48, 135, 73); got != want {
+			t.Errorf("Crop().Bounds() = %v, want %v", got, want)
+		}
+	})
+}
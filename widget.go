@@ -0,0 +1,93 @@
+package dots
+
+import (
+	"image"
+	"sync"
+)
+
+// Widget renders an image into a rectangular region of a TUI layout,
+// re-converting it whenever the region is resized or the image changes.
+//
+// Its SetRect and GetRect methods and embedded mutex match the conventions
+// of termui's Drawable, so adapting it only takes a Draw method:
+//
+//	type ImageWidget struct{ *dots.Widget }
+//
+//	func (w ImageWidget) Draw(buf *ui.Buffer) {
+//		w.Widget.Draw(func(x, y int, c dots.Cell) {
+//			style := ui.NewStyle(ui.Color(dots.ANSICode(c.Fg)))
+//			buf.SetCell(ui.NewCell(c.Rune, style), image.Pt(x, y))
+//		})
+//	}
+//
+// Options.Width and Options.Height are ignored: the image is fit inside the
+// widget's rectangle, maintaining its aspect ratio. Options.Frame is ignored.
+type Widget struct {
+	sync.Mutex
+
+	img  image.Image
+	opts Options
+	rect image.Rectangle
+
+	grid     Grid
+	gridSize image.Point // rectangle size the grid was converted for
+}
+
+// NewWidget creates a widget displaying img.
+func NewWidget(img image.Image, opts Options) *Widget {
+	return &Widget{img: img, opts: opts}
+}
+
+// SetImage replaces the displayed image, e.g. with a new camera snapshot.
+func (w *Widget) SetImage(img image.Image) {
+	w.img = img
+	w.grid = nil
+}
+
+// SetOptions replaces the conversion options.
+func (w *Widget) SetOptions(opts Options) {
+	w.opts = opts
+	w.grid = nil
+}
+
+// SetRect sets the widget's region in terminal cells.
+func (w *Widget) SetRect(x1, y1, x2, y2 int) {
+	w.rect = image.Rect(x1, y1, x2, y2)
+}
+
+// GetRect returns the widget's region in terminal cells.
+func (w *Widget) GetRect() image.Rectangle {
+	return w.rect
+}
+
+// Grid returns the converted image for the widget's current size,
+// re-converting only if the size or image changed since the last call.
+func (w *Widget) Grid() Grid {
+	size := w.rect.Size()
+	if w.grid != nil && size == w.gridSize {
+		return w.grid
+	}
+	w.gridSize = size
+	if w.img == nil || size.X <= 0 || size.Y <= 0 {
+		w.grid = Grid{}
+		return w.grid
+	}
+
+	bounds := w.img.Bounds()
+	opts := w.opts
+	opts.Frame = false
+	opts.Width, opts.Height = CalculateAspectDimensions(bounds.Dx(), bounds.Dy(), 0, 0, size.X, size.Y, opts.cellAspect())
+	if opts.Width < 1 {
+		opts.Width = 1
+	}
+	if opts.Height < 1 {
+		opts.Height = 1
+	}
+	w.grid = ConvertGrid(w.img, opts)
+	return w.grid
+}
+
+// Draw calls set for every cell of the converted image, positioned at the widget's top-left corner.
+func (w *Widget) Draw(set func(x, y int, cell Cell)) {
+	w.Grid().Draw(w.rect.Min.X, w.rect.Min.Y, set)
+}
@@ -0,0 +1,48 @@
+package dots
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestWidget(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	w := NewWidget(img, Options{NoColor: true})
+	w.SetRect(2, 3, 42, 23)
+
+	grid := w.Grid()
+	// A square image in a 40×20 region fits exactly: 40 wide, 20 tall
+	if len(grid) != 20 || len(grid[0]) != 40 {
+		t.Errorf("grid size = %dx%d, want 40x20", len(grid[0]), len(grid))
+	}
+	if again := w.Grid(); &again[0][0] != &grid[0][0] {
+		t.Error("Grid() should reuse the cached grid when the size is unchanged")
+	}
+
+	// Resizing re-renders
+	w.SetRect(0, 0, 10, 10)
+	grid = w.Grid()
+	if len(grid) != 5 || len(grid[0]) != 10 {
+		t.Errorf("resized grid size = %dx%d, want 10x5", len(grid[0]), len(grid))
+	}
+
+	minX, minY := -1, -1
+	w.Draw(func(x, y int, c Cell) {
+		if minX == -1 || x < minX {
+			minX = x
+		}
+		if minY == -1 || y < minY {
+			minY = y
+		}
+	})
+	if minX != 0 || minY != 0 {
+		t.Errorf("Draw() top-left = (%d, %d), want (0, 0)", minX, minY)
+	}
+}